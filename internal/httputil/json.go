@@ -0,0 +1,21 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package httputil contains helpers shared by mailroom's HTTP handlers
+package httputil
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+)
+
+// WriteJSON encodes the value as the JSON body of the response
+func WriteJSON(ctx context.Context, writer http.ResponseWriter, value any) {
+	if err := json.NewEncoder(writer).Encode(value); err != nil {
+		slog.ErrorContext(ctx, "failed to encode response", "error", err)
+		writer.WriteHeader(500)
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package httputil_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/seatgeek/mailroom/internal/httputil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	writer := httptest.NewRecorder()
+	httputil.WriteJSON(t.Context(), writer, map[string]int{"unread": 3})
+
+	assert.Equal(t, 200, writer.Code)
+	assert.JSONEq(t, `{"unread": 3}`, writer.Body.String())
+}
+
+func TestWriteJSON_Unencodable(t *testing.T) {
+	t.Parallel()
+
+	writer := httptest.NewRecorder()
+	httputil.WriteJSON(t.Context(), writer, make(chan int))
+
+	assert.Equal(t, 500, writer.Code)
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package httputil
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor doesn't refer to anything in the list being paginated
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+const (
+	// DefaultLimit is the number of results returned per page when no limit is given
+	DefaultLimit = 20
+	// MaxLimit is the largest number of results that can be requested per page
+	MaxLimit = 100
+)
+
+// PageOptions selects a page of a paginated list
+type PageOptions struct {
+	// Limit is the maximum number of results to return (DefaultLimit if zero)
+	Limit int
+	// Cursor continues a previous listing from the next cursor of its last page
+	Cursor string
+}
+
+// PageSize returns the number of results to return per page, applying DefaultLimit and MaxLimit
+func (o PageOptions) PageSize() int {
+	if o.Limit <= 0 {
+		return DefaultLimit
+	}
+
+	return min(o.Limit, MaxLimit)
+}
+
+// ParsePageOptions reads the "limit" and "cursor" query parameters
+func ParsePageOptions(query url.Values) (PageOptions, error) {
+	opts := PageOptions{Cursor: query.Get("cursor")}
+
+	if limit := query.Get("limit"); limit != "" {
+		var err error
+		opts.Limit, err = strconv.Atoi(limit)
+		if err != nil || opts.Limit < 1 || opts.Limit > MaxLimit {
+			return opts, errors.New("limit must be between 1 and " + strconv.Itoa(MaxLimit))
+		}
+	}
+
+	return opts, nil
+}
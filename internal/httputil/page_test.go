@@ -0,0 +1,85 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package httputil_test
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/seatgeek/mailroom/internal/httputil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePageOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		query    string
+		want     httputil.PageOptions
+		wantSize int
+		wantErr  string
+	}{
+		{
+			name:     "defaults",
+			want:     httputil.PageOptions{},
+			wantSize: httputil.DefaultLimit,
+		},
+		{
+			name:     "limit and cursor",
+			query:    "limit=5&cursor=abc",
+			want:     httputil.PageOptions{Limit: 5, Cursor: "abc"},
+			wantSize: 5,
+		},
+		{
+			name:     "largest limit",
+			query:    "limit=100",
+			want:     httputil.PageOptions{Limit: 100},
+			wantSize: httputil.MaxLimit,
+		},
+		{
+			name:    "limit too large",
+			query:   "limit=101",
+			wantErr: "limit must be between 1 and 100",
+		},
+		{
+			name:    "limit too small",
+			query:   "limit=0",
+			wantErr: "limit must be between 1 and 100",
+		},
+		{
+			name:    "limit isn't a number",
+			query:   "limit=lots",
+			wantErr: "limit must be between 1 and 100",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			query, err := url.ParseQuery(tc.query)
+			assert.NoError(t, err)
+
+			got, err := httputil.ParsePageOptions(query)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+			assert.Equal(t, tc.wantSize, got.PageSize())
+		})
+	}
+}
+
+func TestPageOptions_PageSize(t *testing.T) {
+	t.Parallel()
+
+	// Stores may be called directly with limits the handlers would reject
+	assert.Equal(t, httputil.MaxLimit, httputil.PageOptions{Limit: 1000}.PageSize())
+	assert.Equal(t, httputil.DefaultLimit, httputil.PageOptions{Limit: -1}.PageSize())
+}
@@ -0,0 +1,156 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package content describes the structure of a notification's content (its title, body, fields, links, etc.),
+// so that each transport can render it in its native format
+package content
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+)
+
+// Severity indicates how urgent or important a notification is; transports may use it to pick colors or icons
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeveritySuccess  Severity = "success"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// ActionStyle changes how an action's button looks, where supported
+type ActionStyle string
+
+const (
+	ActionStyleDefault ActionStyle = ""
+	ActionStylePrimary ActionStyle = "primary"
+	ActionStyleDanger  ActionStyle = "danger"
+)
+
+// Content is the structured content of a notification
+// Every field is optional, though a Title or Body is expected.
+type Content struct {
+	Title    string   `json:"title,omitempty"`
+	Body     string   `json:"body,omitempty"`
+	Severity Severity `json:"severity,omitempty"`
+	Fields   []Field  `json:"fields,omitempty"`
+	Links    []Link   `json:"links,omitempty"`
+	Actions  []Action `json:"actions,omitempty"`
+	Image    *Image   `json:"image,omitempty"`
+}
+
+// Field is a key-value pair, like "Pipeline: #1234"
+type Field struct {
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+	// Short fields may be shown side-by-side
+	Short bool `json:"short,omitempty"`
+}
+
+// Link is a link to something relevant to the notification
+type Link struct {
+	Text string `json:"text,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+// Action is a button
+// Buttons with a URL open it; others are interactive, like Slack buttons handled by actions.Handler,
+// and are left out by transports which don't support them.
+type Action struct {
+	// ID identifies interactive actions, like a Slack action_id
+	ID    string      `json:"id,omitempty"`
+	Text  string      `json:"text,omitempty"`
+	Value string      `json:"value,omitempty"`
+	URL   string      `json:"url,omitempty"`
+	Style ActionStyle `json:"style,omitempty"`
+}
+
+// Image is an image to show with the notification
+type Image struct {
+	URL     string `json:"url,omitempty"`
+	AltText string `json:"alt_text,omitempty"`
+}
+
+// Notification is an optional interface that can be implemented by any notification with structured content
+// Transports which support it render the Content in their native format; others use Render as before.
+type Notification interface {
+	event.Notification
+	// GetContent returns the notification's content, or nil
+	GetContent() *Content
+}
+
+// Of returns the content of the notification, or nil if it doesn't have any
+func Of(notification event.Notification) *Content {
+	if n, ok := notification.(Notification); ok {
+		return n.GetContent()
+	}
+
+	return nil
+}
+
+// Copy returns a deep copy of the content
+func (c *Content) Copy() *Content {
+	if c == nil {
+		return nil
+	}
+
+	res := *c
+	res.Fields = slices.Clone(c.Fields)
+	res.Links = slices.Clone(c.Links)
+	res.Actions = slices.Clone(c.Actions)
+	if c.Image != nil {
+		image := *c.Image
+		res.Image = &image
+	}
+
+	return &res
+}
+
+// Text renders the content as plain text, for transports which don't support anything richer
+// Interactive actions are left out, since they can't be clicked.
+func (c *Content) Text() string {
+	if c == nil {
+		return ""
+	}
+
+	var sections []string
+	if heading := strings.TrimSpace(c.Title + "\n" + c.Body); heading != "" {
+		sections = append(sections, heading)
+	}
+
+	var fields []string
+	for _, f := range c.Fields {
+		fields = append(fields, f.Name+": "+f.Value)
+	}
+
+	var links []string
+	for _, l := range c.Links {
+		links = append(links, label(l.Text, l.URL))
+	}
+	for _, a := range c.Actions {
+		if a.URL != "" {
+			links = append(links, label(a.Text, a.URL))
+		}
+	}
+
+	for _, section := range [][]string{fields, links} {
+		if len(section) > 0 {
+			sections = append(sections, strings.Join(section, "\n"))
+		}
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+func label(text string, url string) string {
+	if text == "" {
+		return url
+	}
+
+	return text + ": " + url
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package content_test
+
+import (
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/content"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContent_Text(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content *content.Content
+		want    string
+	}{
+		{
+			name:    "nil",
+			content: nil,
+			want:    "",
+		},
+		{
+			name:    "title only",
+			content: &content.Content{Title: "Pipeline failed"},
+			want:    "Pipeline failed",
+		},
+		{
+			name:    "body only",
+			content: &content.Content{Body: "Your pipeline failed"},
+			want:    "Your pipeline failed",
+		},
+		{
+			name: "everything",
+			content: &content.Content{
+				Title:    "Pipeline failed",
+				Body:     "The build job failed on main",
+				Severity: content.SeverityCritical,
+				Fields: []content.Field{
+					{Name: "Project", Value: "seatgeek/mailroom", Short: true},
+					{Name: "Pipeline", Value: "#1234", Short: true},
+				},
+				Links: []content.Link{
+					{Text: "Pipeline", URL: "https://gitlab.example.com/pipelines/1234"},
+					{URL: "https://gitlab.example.com/commits/abc123"},
+				},
+				Actions: []content.Action{
+					{Text: "Retry", URL: "https://gitlab.example.com/pipelines/1234/retry", Style: content.ActionStylePrimary},
+					{ID: "mute", Text: "Mute", Value: "pipeline"},
+				},
+				Image: &content.Image{URL: "https://example.com/graph.png", AltText: "Build times"},
+			},
+			want: "Pipeline failed\nThe build job failed on main\n\n" +
+				"Project: seatgeek/mailroom\nPipeline: #1234\n\n" +
+				"Pipeline: https://gitlab.example.com/pipelines/1234\nhttps://gitlab.example.com/commits/abc123\nRetry: https://gitlab.example.com/pipelines/1234/retry",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, tc.content.Text())
+		})
+	}
+}
+
+func TestContent_Copy(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, (*content.Content)(nil).Copy())
+
+	original := &content.Content{
+		Title:   "Pipeline failed",
+		Fields:  []content.Field{{Name: "Project", Value: "seatgeek/mailroom"}},
+		Links:   []content.Link{{Text: "Pipeline", URL: "https://gitlab.example.com/pipelines/1234"}},
+		Actions: []content.Action{{ID: "mute", Text: "Mute"}},
+		Image:   &content.Image{URL: "https://example.com/graph.png"},
+	}
+
+	cp := original.Copy()
+	assert.Equal(t, original, cp)
+
+	cp.Fields[0].Value = "seatgeek/other"
+	cp.Links[0].URL = "https://example.com"
+	cp.Actions[0].Text = "Unmute"
+	cp.Image.URL = "https://example.com/other.png"
+
+	assert.Equal(t, "seatgeek/mailroom", original.Fields[0].Value)
+	assert.Equal(t, "https://gitlab.example.com/pipelines/1234", original.Links[0].URL)
+	assert.Equal(t, "Mute", original.Actions[0].Text)
+	assert.Equal(t, "https://example.com/graph.png", original.Image.URL)
+}
+
+func TestOf(t *testing.T) {
+	t.Parallel()
+
+	n := event.NewMockNotification(t)
+	assert.Nil(t, content.Of(n))
+}
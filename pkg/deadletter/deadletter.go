@@ -0,0 +1,153 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package deadletter captures notifications which could not be delivered so that they can be inspected and replayed later
+package deadletter
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seatgeek/mailroom/internal/httputil"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/seatgeek/mailroom/pkg/validation"
+)
+
+var (
+	// ErrLetterNotFound is returned when a dead letter does not exist in the Store
+	ErrLetterNotFound = errors.New("dead letter not found")
+	// ErrInvalidCursor is returned when a pagination cursor doesn't refer to a dead letter in the Store
+	ErrInvalidCursor = httputil.ErrInvalidCursor
+)
+
+const (
+	// DefaultLimit is the number of dead letters returned per page when no limit is given
+	DefaultLimit = httputil.DefaultLimit
+	// MaxLimit is the largest number of dead letters that can be requested per page
+	MaxLimit = httputil.MaxLimit
+)
+
+// Letter is a notification which some transport failed to deliver
+type Letter struct {
+	ID        string                `json:"id"`
+	Envelope  notification.Envelope `json:"notification"`
+	Transport event.TransportKey    `json:"transport"`
+	// Errors is the chain of errors returned by the transport, outermost first
+	Errors []string `json:"errors"`
+	// Attempts is the total number of delivery attempts made so far, including replays
+	Attempts uint      `json:"attempts"`
+	FailedAt time.Time `json:"failed_at"`
+}
+
+// NewLetter creates a new Letter for a notification that the given transport failed to deliver
+// Notifications which can't be serialized return notification.ErrUnserializable, since they couldn't be replayed as they were.
+func NewLetter(n event.Notification, transport event.TransportKey, err error) (*Letter, error) {
+	env, sealErr := notification.Seal(n, []event.TransportKey{transport})
+	if sealErr != nil {
+		return nil, sealErr
+	}
+
+	return &Letter{
+		ID:        uuid.New().String(),
+		Envelope:  env,
+		Transport: transport,
+		Errors:    errorChain(err),
+		Attempts:  attemptsFor(err),
+		FailedAt:  time.Now(),
+	}, nil
+}
+
+// Notification returns the notification which failed to be delivered
+func (l *Letter) Notification() event.Notification {
+	return l.Envelope.Open()
+}
+
+// ListOptions selects the page of dead letters returned by Store.List
+type ListOptions = httputil.PageOptions
+
+// Page is a page of dead letters, most recent failures first
+type Page struct {
+	DeadLetters []*Letter `json:"dead_letters"`
+	// NextCursor can be passed as ListOptions.Cursor to get the next page; it's empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Store persists dead letters.
+// Implementations may be backed by a SQL database, an in-memory store, or something else.
+type Store interface {
+	// Add upserts a dead letter
+	Add(ctx context.Context, letter *Letter) error
+	// Get returns a dead letter by its ID, or ErrLetterNotFound
+	Get(ctx context.Context, id string) (*Letter, error)
+	// List returns a page of dead letters, most recent failures first, or ErrInvalidCursor
+	List(ctx context.Context, opts ListOptions) (*Page, error)
+	// Delete removes a dead letter by its ID, or returns ErrLetterNotFound
+	Delete(ctx context.Context, id string) error
+	// Purge removes all dead letters
+	Purge(ctx context.Context) error
+}
+
+// WithDeadLetters decorates the given Transport so that any notification it fails to deliver is written to the Store.
+// It should be the outermost decorator (e.g. wrapping notifier.WithRetry) so that only final failures are captured.
+func WithDeadLetters(transport notifier.Transport, store Store) notifier.Transport {
+	return &withDeadLetters{
+		Transport: transport,
+		store:     store,
+	}
+}
+
+type withDeadLetters struct {
+	notifier.Transport
+	store Store
+}
+
+func (w *withDeadLetters) Push(ctx context.Context, n event.Notification) error {
+	err := w.Transport.Push(ctx, n)
+	if err == nil {
+		return nil
+	}
+
+	letter, sealErr := NewLetter(n, w.Key(), err)
+	if sealErr != nil {
+		slog.ErrorContext(ctx, "failed to create dead letter", "id", n.Context().ID, "transport", w.Key(), "error", sealErr)
+	} else if storeErr := w.store.Add(ctx, letter); storeErr != nil {
+		slog.ErrorContext(ctx, "failed to store dead letter", "id", n.Context().ID, "transport", w.Key(), "error", storeErr)
+	} else {
+		slog.WarnContext(ctx, "notification moved to dead letter store", "id", n.Context().ID, "transport", w.Key(), "dead_letter_id", letter.ID)
+	}
+
+	return err
+}
+
+func (w *withDeadLetters) Validate(ctx context.Context) error {
+	if v, ok := w.Transport.(validation.Validator); ok {
+		return v.Validate(ctx)
+	}
+
+	return nil
+}
+
+func errorChain(err error) []string {
+	var chain []string
+	for err != nil {
+		chain = append(chain, err.Error())
+		err = errors.Unwrap(err)
+	}
+
+	return chain
+}
+
+func attemptsFor(err error) uint {
+	var retryErr *notifier.RetryError
+	if errors.As(err, &retryErr) {
+		return retryErr.Attempts
+	}
+
+	return 1
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package deadletter_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/seatgeek/mailroom/pkg/deadletter"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errSomethingFailed = errors.New("something failed")
+
+func TestWithDeadLetters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		transport    notifier.Transport
+		notification event.Notification
+		wantErr      error
+		wantLetter   bool
+		wantErrors   []string
+		wantAttempts uint
+	}{
+		{
+			name:      "successful delivery",
+			transport: transportThatReturns(nil),
+		},
+		{
+			name:         "failed delivery",
+			transport:    transportThatReturns(fmt.Errorf("wrapped: %w", errSomethingFailed)),
+			wantErr:      errSomethingFailed,
+			wantLetter:   true,
+			wantErrors:   []string{"wrapped: something failed", "something failed"},
+			wantAttempts: 1,
+		},
+		{
+			name: "failed delivery after retries",
+			transport: notifier.WithRetry(transportThatReturns(errSomethingFailed), 3, func() notifier.BackOff {
+				return backoff.NewConstantBackOff(time.Millisecond)
+			}),
+			wantErr:      errSomethingFailed,
+			wantLetter:   true,
+			wantErrors:   []string{"gave up after 3 attempt(s): something failed", "something failed"},
+			wantAttempts: 3,
+		},
+		{
+			name:      "failed delivery which can't be serialized",
+			transport: transportThatReturns(errSomethingFailed),
+			notification: notification.NewBuilder(event.Context{ID: "a1c11a53-c4be-488f-89b6-f83bf2d48dab"}).
+				WithDefaultMessage("hello world").
+				WithSlackOptions(slack.MsgOptionText("hello world", false)).
+				Build(),
+			wantErr: errSomethingFailed,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			store := deadletter.NewInMemoryStore()
+			wrapped := deadletter.WithDeadLetters(tc.transport, store)
+			assert.Equal(t, tc.transport.Key(), wrapped.Key())
+
+			n := tc.notification
+			if n == nil {
+				n = someNotification()
+			}
+
+			err := wrapped.Push(t.Context(), n)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+
+			page, err := store.List(t.Context(), deadletter.ListOptions{})
+			require.NoError(t, err)
+
+			if !tc.wantLetter {
+				assert.Empty(t, page.DeadLetters)
+				return
+			}
+
+			require.Len(t, page.DeadLetters, 1)
+			assert.Equal(t, event.TransportKey("test"), page.DeadLetters[0].Transport)
+			assert.Equal(t, tc.wantErrors, page.DeadLetters[0].Errors)
+			assert.Equal(t, tc.wantAttempts, page.DeadLetters[0].Attempts)
+			assert.Equal(t, "hello world", page.DeadLetters[0].Notification().Render("test"))
+		})
+	}
+}
+
+func transportThatReturns(err error) notifier.Transport {
+	return notifier.NewTransport("test", func(_ context.Context, _ event.Notification) error {
+		return err
+	})
+}
+
+func someNotification() event.Notification {
+	return notification.NewBuilder(event.Context{
+		ID:   "a1c11a53-c4be-488f-89b6-f83bf2d48dab",
+		Type: "com.example.test",
+	}).
+		WithRecipientIdentifiers(identifier.New(identifier.GenericUsername, "codell")).
+		WithDefaultMessage("hello world").
+		Build()
+}
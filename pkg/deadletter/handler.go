@@ -0,0 +1,158 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package deadletter
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/seatgeek/mailroom/internal/httputil"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+)
+
+// Handler exposes an HTTP API for inspecting, replaying and purging dead letters
+type Handler struct {
+	store      Store
+	transports map[event.TransportKey]notifier.Transport
+}
+
+// NewHandler creates a new Handler
+// Replayed notifications are pushed directly to the matching transport from the given list, bypassing user preferences.
+func NewHandler(store Store, transports []notifier.Transport) *Handler {
+	byKey := make(map[event.TransportKey]notifier.Transport, len(transports))
+	for _, t := range transports {
+		byKey[t.Key()] = t
+	}
+
+	return &Handler{
+		store:      store,
+		transports: byKey,
+	}
+}
+
+// List returns a page of dead letters
+// It supports the "limit" and "cursor" query parameters (see ListOptions).
+func (h *Handler) List(writer http.ResponseWriter, request *http.Request) {
+	opts, err := httputil.ParsePageOptions(request.URL.Query())
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.store.List(request.Context(), opts)
+	if err != nil {
+		if errors.Is(err, ErrInvalidCursor) {
+			http.Error(writer, "invalid cursor", http.StatusBadRequest)
+			return
+		}
+
+		slog.ErrorContext(request.Context(), "failed to list dead letters", "error", err)
+		http.Error(writer, "failed to list dead letters", http.StatusInternalServerError)
+		return
+	}
+
+	httputil.WriteJSON(request.Context(), writer, page)
+}
+
+// Get returns a single dead letter
+func (h *Handler) Get(writer http.ResponseWriter, request *http.Request) {
+	letter, ok := h.getLetter(writer, request)
+	if !ok {
+		return
+	}
+
+	httputil.WriteJSON(request.Context(), writer, letter)
+}
+
+// Replay attempts to deliver a dead letter again via the transport that originally failed.
+// The letter is removed if delivery succeeds; otherwise it is updated with the latest error.
+func (h *Handler) Replay(writer http.ResponseWriter, request *http.Request) {
+	letter, ok := h.getLetter(writer, request)
+	if !ok {
+		return
+	}
+
+	transport, ok := h.transports[letter.Transport]
+	if !ok {
+		slog.WarnContext(request.Context(), "cannot replay dead letter for unknown transport", "id", letter.ID, "transport", letter.Transport)
+		http.Error(writer, "transport is no longer configured", http.StatusConflict)
+		return
+	}
+
+	if err := transport.Push(request.Context(), letter.Notification()); err != nil {
+		slog.WarnContext(request.Context(), "failed to replay dead letter", "id", letter.ID, "transport", letter.Transport, "error", err)
+
+		letter.Errors = errorChain(err)
+		letter.Attempts += attemptsFor(err)
+		letter.FailedAt = time.Now()
+		if err = h.store.Add(request.Context(), letter); err != nil {
+			slog.ErrorContext(request.Context(), "failed to update dead letter", "id", letter.ID, "error", err)
+		}
+
+		writer.WriteHeader(http.StatusBadGateway)
+		httputil.WriteJSON(request.Context(), writer, letter)
+		return
+	}
+
+	if err := h.store.Delete(request.Context(), letter.ID); err != nil && !errors.Is(err, ErrLetterNotFound) {
+		slog.ErrorContext(request.Context(), "failed to delete replayed dead letter", "id", letter.ID, "error", err)
+		http.Error(writer, "notification was delivered but the dead letter could not be deleted", http.StatusInternalServerError)
+		return
+	}
+
+	slog.InfoContext(request.Context(), "replayed dead letter", "id", letter.ID, "transport", letter.Transport)
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// Delete removes a single dead letter without replaying it
+func (h *Handler) Delete(writer http.ResponseWriter, request *http.Request) {
+	id := mux.Vars(request)["id"]
+
+	if err := h.store.Delete(request.Context(), id); err != nil {
+		if errors.Is(err, ErrLetterNotFound) {
+			http.Error(writer, "dead letter not found", http.StatusNotFound)
+			return
+		}
+
+		slog.ErrorContext(request.Context(), "failed to delete dead letter", "id", id, "error", err)
+		http.Error(writer, "failed to delete dead letter", http.StatusInternalServerError)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// Purge removes all dead letters
+func (h *Handler) Purge(writer http.ResponseWriter, request *http.Request) {
+	if err := h.store.Purge(request.Context()); err != nil {
+		slog.ErrorContext(request.Context(), "failed to purge dead letters", "error", err)
+		http.Error(writer, "failed to purge dead letters", http.StatusInternalServerError)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) getLetter(writer http.ResponseWriter, request *http.Request) (*Letter, bool) {
+	id := mux.Vars(request)["id"]
+
+	letter, err := h.store.Get(request.Context(), id)
+	if err != nil {
+		if errors.Is(err, ErrLetterNotFound) {
+			http.Error(writer, "dead letter not found", http.StatusNotFound)
+			return nil, false
+		}
+
+		slog.ErrorContext(request.Context(), "failed to get dead letter", "id", id, "error", err)
+		http.Error(writer, "failed to get dead letter", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return letter, true
+}
@@ -0,0 +1,252 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package deadletter_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/seatgeek/mailroom/pkg/deadletter"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_List(t *testing.T) {
+	t.Parallel()
+
+	router, store := createRouter(t, transportThatReturns(nil))
+	failedAt := time.Now()
+	for i := range 3 {
+		letter, err := deadletter.NewLetter(someNotification(), "test", errSomethingFailed)
+		require.NoError(t, err)
+		letter.ID = fmt.Sprintf("letter-%02d", i)
+		letter.FailedAt = failedAt.Add(time.Duration(i) * time.Second)
+		require.NoError(t, store.Add(t.Context(), letter))
+	}
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantIDs    []string
+		wantCursor string
+	}{
+		{
+			name:       "Happy path",
+			url:        "/dead-letters",
+			wantStatus: 200,
+			wantIDs:    []string{"letter-02", "letter-01", "letter-00"},
+		},
+		{
+			name:       "Paginated",
+			url:        "/dead-letters?limit=2",
+			wantStatus: 200,
+			wantIDs:    []string{"letter-02", "letter-01"},
+			wantCursor: "letter-01",
+		},
+		{
+			name:       "Next page",
+			url:        "/dead-letters?limit=2&cursor=letter-01",
+			wantStatus: 200,
+			wantIDs:    []string{"letter-00"},
+		},
+		{
+			name:       "Invalid limit",
+			url:        "/dead-letters?limit=1000",
+			wantStatus: 400,
+		},
+		{
+			name:       "Invalid cursor",
+			url:        "/dead-letters?cursor=nope",
+			wantStatus: 400,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			writer := httptest.NewRecorder()
+			router.ServeHTTP(writer, httptest.NewRequestWithContext(t.Context(), "GET", tc.url, nil))
+
+			assert.Equal(t, tc.wantStatus, writer.Code)
+			if tc.wantStatus != 200 {
+				return
+			}
+
+			var page deadletter.Page
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), &page))
+			assert.Equal(t, tc.wantIDs, letterIDs(&page))
+			assert.Equal(t, tc.wantCursor, page.NextCursor)
+		})
+	}
+}
+
+func TestHandler_Get(t *testing.T) {
+	t.Parallel()
+
+	router, store := createRouter(t, transportThatReturns(nil))
+	letter := addLetter(t, store, "test")
+
+	t.Run("Happy path", func(t *testing.T) {
+		t.Parallel()
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, httptest.NewRequestWithContext(t.Context(), "GET", "/dead-letters/"+letter.ID, nil))
+
+		assert.Equal(t, 200, writer.Code)
+
+		var got deadletter.Letter
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), &got))
+		assert.Equal(t, letter.ID, got.ID)
+		assert.Equal(t, event.TransportKey("test"), got.Transport)
+		assert.Equal(t, []string{"something failed"}, got.Errors)
+		assert.Equal(t, "hello world", got.Notification().Render("test"))
+	})
+
+	t.Run("Letter doesn't exist", func(t *testing.T) {
+		t.Parallel()
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, httptest.NewRequestWithContext(t.Context(), "GET", "/dead-letters/nope", nil))
+
+		assert.Equal(t, 404, writer.Code)
+	})
+}
+
+func TestHandler_Replay(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		transport      notifier.Transport
+		letterFor      event.TransportKey
+		wantStatusCode int
+		wantRemaining  bool
+		wantAttempts   uint
+	}{
+		{
+			name:           "successful replay",
+			transport:      transportThatReturns(nil),
+			letterFor:      "test",
+			wantStatusCode: 204,
+			wantRemaining:  false,
+		},
+		{
+			name:           "failed replay",
+			transport:      transportThatReturns(errSomethingFailed),
+			letterFor:      "test",
+			wantStatusCode: 502,
+			wantRemaining:  true,
+			wantAttempts:   2,
+		},
+		{
+			name:           "transport no longer exists",
+			transport:      transportThatReturns(nil),
+			letterFor:      "carrierpigeon",
+			wantStatusCode: 409,
+			wantRemaining:  true,
+			wantAttempts:   1,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var pushed []event.Notification
+			transport := notifier.NewTransport(tc.transport.Key(), func(ctx context.Context, n event.Notification) error {
+				pushed = append(pushed, n)
+				return tc.transport.Push(ctx, n)
+			})
+
+			router, store := createRouter(t, transport)
+			letter := addLetter(t, store, tc.letterFor)
+
+			writer := httptest.NewRecorder()
+			router.ServeHTTP(writer, httptest.NewRequestWithContext(t.Context(), "POST", "/dead-letters/"+letter.ID+"/replay", nil))
+
+			assert.Equal(t, tc.wantStatusCode, writer.Code)
+
+			got, err := store.Get(t.Context(), letter.ID)
+			if !tc.wantRemaining {
+				assert.ErrorIs(t, err, deadletter.ErrLetterNotFound)
+				require.Len(t, pushed, 1)
+				assert.Equal(t, "hello world", pushed[0].Render("test"))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantAttempts, got.Attempts)
+		})
+	}
+}
+
+func TestHandler_Delete(t *testing.T) {
+	t.Parallel()
+
+	router, store := createRouter(t, transportThatReturns(nil))
+	letter := addLetter(t, store, "test")
+
+	writer := httptest.NewRecorder()
+	router.ServeHTTP(writer, httptest.NewRequestWithContext(t.Context(), "DELETE", "/dead-letters/"+letter.ID, nil))
+	assert.Equal(t, 204, writer.Code)
+
+	_, err := store.Get(t.Context(), letter.ID)
+	assert.ErrorIs(t, err, deadletter.ErrLetterNotFound)
+
+	writer = httptest.NewRecorder()
+	router.ServeHTTP(writer, httptest.NewRequestWithContext(t.Context(), "DELETE", "/dead-letters/"+letter.ID, nil))
+	assert.Equal(t, 404, writer.Code)
+}
+
+func TestHandler_Purge(t *testing.T) {
+	t.Parallel()
+
+	router, store := createRouter(t, transportThatReturns(nil))
+	addLetter(t, store, "test")
+	addLetter(t, store, "test")
+
+	writer := httptest.NewRecorder()
+	router.ServeHTTP(writer, httptest.NewRequestWithContext(t.Context(), "DELETE", "/dead-letters", nil))
+	assert.Equal(t, 204, writer.Code)
+
+	page, err := store.List(t.Context(), deadletter.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, page.DeadLetters)
+}
+
+func createRouter(t *testing.T, transport notifier.Transport) (*mux.Router, deadletter.Store) {
+	t.Helper()
+
+	store := deadletter.NewInMemoryStore()
+	handler := deadletter.NewHandler(store, []notifier.Transport{transport})
+
+	router := mux.NewRouter()
+	router.HandleFunc("/dead-letters", handler.List).Methods("GET")
+	router.HandleFunc("/dead-letters", handler.Purge).Methods("DELETE")
+	router.HandleFunc("/dead-letters/{id}", handler.Get).Methods("GET")
+	router.HandleFunc("/dead-letters/{id}", handler.Delete).Methods("DELETE")
+	router.HandleFunc("/dead-letters/{id}/replay", handler.Replay).Methods("POST")
+
+	return router, store
+}
+
+func addLetter(t *testing.T, store deadletter.Store, transport event.TransportKey) *deadletter.Letter {
+	t.Helper()
+
+	letter, err := deadletter.NewLetter(someNotification(), transport, errSomethingFailed)
+	require.NoError(t, err)
+	require.NoError(t, store.Add(t.Context(), letter))
+
+	return letter
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package postgres provides a postgresql-backed implementation of the deadletter.Store interface
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/deadletter"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"gorm.io/gorm"
+)
+
+// LetterModel is the gorm model for a dead letter
+type LetterModel struct {
+	ID        string                `gorm:"primarykey"`
+	Envelope  notification.Envelope `gorm:"serializer:json"`
+	Transport event.TransportKey
+	Errors    []string `gorm:"serializer:json"`
+	Attempts  uint
+	FailedAt  time.Time `gorm:"index"`
+}
+
+func (l *LetterModel) TableName() string {
+	return "dead_letters"
+}
+
+// ToLetter converts a LetterModel to a deadletter.Letter
+func (l *LetterModel) ToLetter() *deadletter.Letter {
+	return &deadletter.Letter{
+		ID:        l.ID,
+		Envelope:  l.Envelope,
+		Transport: l.Transport,
+		Errors:    l.Errors,
+		Attempts:  l.Attempts,
+		FailedAt:  l.FailedAt,
+	}
+}
+
+type Store struct {
+	db *gorm.DB
+}
+
+var _ deadletter.Store = &Store{}
+
+// NewPostgresStore creates a new postgres store
+func NewPostgresStore(db *gorm.DB) *Store {
+	return &Store{db: db}
+}
+
+// Add implements deadletter.Store.
+func (s *Store) Add(ctx context.Context, letter *deadletter.Letter) error {
+	return s.db.WithContext(ctx).Save(&LetterModel{
+		ID:        letter.ID,
+		Envelope:  letter.Envelope,
+		Transport: letter.Transport,
+		Errors:    letter.Errors,
+		Attempts:  letter.Attempts,
+		FailedAt:  letter.FailedAt,
+	}).Error
+}
+
+// Get implements deadletter.Store.
+func (s *Store) Get(ctx context.Context, id string) (*deadletter.Letter, error) {
+	var l LetterModel
+	if err := s.db.WithContext(ctx).Where("id = ?", id).First(&l).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, deadletter.ErrLetterNotFound
+		}
+		return nil, err
+	}
+
+	return l.ToLetter(), nil
+}
+
+// List implements deadletter.Store.
+func (s *Store) List(ctx context.Context, opts deadletter.ListOptions) (*deadletter.Page, error) {
+	query := s.db.WithContext(ctx)
+
+	if opts.Cursor != "" {
+		var cursor LetterModel
+		if err := s.db.WithContext(ctx).Where("id = ?", opts.Cursor).First(&cursor).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, deadletter.ErrInvalidCursor
+			}
+			return nil, err
+		}
+
+		query = query.Where("failed_at < ? OR (failed_at = ? AND id > ?)", cursor.FailedAt, cursor.FailedAt, cursor.ID)
+	}
+
+	limit := opts.PageSize()
+
+	// Fetch one extra letter to find out whether there's another page
+	var models []LetterModel
+	if err := query.Order("failed_at desc, id").Limit(limit + 1).Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	page := &deadletter.Page{DeadLetters: make([]*deadletter.Letter, 0, len(models))}
+	for i := range models {
+		if i == limit {
+			page.NextCursor = models[i-1].ID
+			break
+		}
+		page.DeadLetters = append(page.DeadLetters, models[i].ToLetter())
+	}
+
+	return page, nil
+}
+
+// Delete implements deadletter.Store.
+func (s *Store) Delete(ctx context.Context, id string) error {
+	result := s.db.WithContext(ctx).Delete(&LetterModel{ID: id})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return deadletter.ErrLetterNotFound
+	}
+
+	return nil
+}
+
+// Purge implements deadletter.Store.
+func (s *Store) Purge(ctx context.Context) error {
+	return s.db.WithContext(ctx).Where("1 = 1").Delete(&LetterModel{}).Error
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/deadletter"
+	"github.com/seatgeek/mailroom/pkg/deadletter/postgres"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	pgtc "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	pg "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestPostgresStore(t *testing.T) {
+	t.Parallel()
+
+	store := createDatastore(t)
+
+	n := notification.NewBuilder(event.Context{
+		ID:     "a1c11a53-c4be-488f-89b6-f83bf2d48dab",
+		Source: event.MustSource("https://example.com"),
+		Type:   "com.example.test",
+	}).
+		WithRecipientIdentifiers(identifier.New(identifier.GenericUsername, "codell")).
+		WithDefaultMessage("hello world").
+		Build()
+
+	older, err := deadletter.NewLetter(n, "email", errors.New("something failed"))
+	require.NoError(t, err)
+	older.FailedAt = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	newer, err := deadletter.NewLetter(n, "slack", errors.New("something else failed"))
+	require.NoError(t, err)
+	newer.FailedAt = older.FailedAt.Add(time.Second)
+
+	assert.NoError(t, store.Add(t.Context(), older))
+	assert.NoError(t, store.Add(t.Context(), newer))
+
+	// List returns the most recent failures first
+	page, err := store.List(t.Context(), deadletter.ListOptions{})
+	assert.NoError(t, err)
+	if assert.Len(t, page.DeadLetters, 2) {
+		assert.Equal(t, newer.ID, page.DeadLetters[0].ID)
+		assert.Equal(t, older.ID, page.DeadLetters[1].ID)
+	}
+	assert.Empty(t, page.NextCursor)
+
+	// And can be paginated
+	page, err = store.List(t.Context(), deadletter.ListOptions{Limit: 1})
+	assert.NoError(t, err)
+	if assert.Len(t, page.DeadLetters, 1) {
+		assert.Equal(t, newer.ID, page.DeadLetters[0].ID)
+	}
+	assert.Equal(t, newer.ID, page.NextCursor)
+
+	page, err = store.List(t.Context(), deadletter.ListOptions{Limit: 1, Cursor: page.NextCursor})
+	assert.NoError(t, err)
+	if assert.Len(t, page.DeadLetters, 1) {
+		assert.Equal(t, older.ID, page.DeadLetters[0].ID)
+	}
+	assert.Empty(t, page.NextCursor)
+
+	_, err = store.List(t.Context(), deadletter.ListOptions{Cursor: "nope"})
+	assert.ErrorIs(t, err, deadletter.ErrInvalidCursor)
+
+	got, err := store.Get(t.Context(), older.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, older.Envelope, got.Envelope)
+	assert.Equal(t, older.Errors, got.Errors)
+	assert.Equal(t, event.TransportKey("email"), got.Transport)
+
+	// Add upserts
+	older.Attempts = 5
+	assert.NoError(t, store.Add(t.Context(), older))
+	got, err = store.Get(t.Context(), older.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(5), got.Attempts)
+
+	assert.NoError(t, store.Delete(t.Context(), older.ID))
+	_, err = store.Get(t.Context(), older.ID)
+	assert.ErrorIs(t, err, deadletter.ErrLetterNotFound)
+	assert.ErrorIs(t, store.Delete(t.Context(), older.ID), deadletter.ErrLetterNotFound)
+
+	assert.NoError(t, store.Purge(t.Context()))
+	page, err = store.List(t.Context(), deadletter.ListOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, page.DeadLetters)
+}
+
+func createDatastore(t *testing.T) *postgres.Store {
+	t.Helper()
+
+	ctx := context.Background()
+
+	container, err := pgtc.Run(ctx, "postgres:16.2",
+		pgtc.WithInitScripts("../../../test/initdb/init.sql"),
+		pgtc.WithDatabase("mailroom"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second)),
+	)
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, container.Terminate(ctx))
+	})
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable", "application_name=test")
+	assert.NoError(t, err)
+
+	db, err := gorm.Open(pg.Open(dsn), &gorm.Config{})
+	assert.NoError(t, err)
+
+	return postgres.NewPostgresStore(db)
+}
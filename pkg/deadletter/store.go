@@ -0,0 +1,108 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package deadletter
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+)
+
+// InMemoryStore is a simple in-memory implementation of the Store interface
+// This is especially useful for testing, but can also be used for simple applications which don't need durable storage.
+type InMemoryStore struct {
+	letters map[string]*Letter
+	mu      sync.RWMutex
+}
+
+var _ Store = &InMemoryStore{}
+
+// NewInMemoryStore creates a new, empty in-memory store
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{letters: make(map[string]*Letter)}
+}
+
+func (s *InMemoryStore) Add(_ context.Context, letter *Letter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.letters[letter.ID] = letter
+	return nil
+}
+
+func (s *InMemoryStore) Get(_ context.Context, id string) (*Letter, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	letter, ok := s.letters[id]
+	if !ok {
+		return nil, ErrLetterNotFound
+	}
+
+	return letter, nil
+}
+
+func (s *InMemoryStore) List(_ context.Context, opts ListOptions) (*Page, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	letters := make([]*Letter, 0, len(s.letters))
+	for _, letter := range s.letters {
+		letters = append(letters, letter)
+	}
+
+	slices.SortFunc(letters, mostRecentFirst)
+
+	if opts.Cursor != "" {
+		cursor, ok := s.letters[opts.Cursor]
+		if !ok {
+			return nil, ErrInvalidCursor
+		}
+
+		// Skip everything up to and including the cursor
+		start, found := slices.BinarySearchFunc(letters, cursor, mostRecentFirst)
+		if found {
+			start++
+		}
+		letters = letters[start:]
+	}
+
+	page := &Page{DeadLetters: make([]*Letter, 0, opts.PageSize())}
+	for i, letter := range letters {
+		if i == opts.PageSize() {
+			page.NextCursor = page.DeadLetters[i-1].ID
+			break
+		}
+
+		page.DeadLetters = append(page.DeadLetters, letter)
+	}
+
+	return page, nil
+}
+
+func (s *InMemoryStore) Delete(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.letters[id]; !ok {
+		return ErrLetterNotFound
+	}
+
+	delete(s.letters, id)
+	return nil
+}
+
+func (s *InMemoryStore) Purge(_ context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.letters)
+	return nil
+}
+
+func mostRecentFirst(a, b *Letter) int {
+	return cmp.Or(b.FailedAt.Compare(a.FailedAt), cmp.Compare(a.ID, b.ID))
+}
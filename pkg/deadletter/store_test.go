@@ -0,0 +1,98 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package deadletter_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/deadletter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	store := deadletter.NewInMemoryStore()
+
+	older, err := deadletter.NewLetter(someNotification(), "email", errSomethingFailed)
+	require.NoError(t, err)
+	newer, err := deadletter.NewLetter(someNotification(), "slack", errSomethingFailed)
+	require.NoError(t, err)
+	newer.FailedAt = older.FailedAt.Add(time.Second)
+
+	require.NoError(t, store.Add(t.Context(), older))
+	require.NoError(t, store.Add(t.Context(), newer))
+
+	// List returns the most recent failures first
+	page, err := store.List(t.Context(), deadletter.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []*deadletter.Letter{newer, older}, page.DeadLetters)
+	assert.Empty(t, page.NextCursor)
+
+	got, err := store.Get(t.Context(), older.ID)
+	require.NoError(t, err)
+	assert.Equal(t, older, got)
+
+	// Add upserts
+	older.Attempts = 5
+	require.NoError(t, store.Add(t.Context(), older))
+	got, err = store.Get(t.Context(), older.ID)
+	require.NoError(t, err)
+	assert.Equal(t, uint(5), got.Attempts)
+
+	require.NoError(t, store.Delete(t.Context(), older.ID))
+	_, err = store.Get(t.Context(), older.ID)
+	assert.ErrorIs(t, err, deadletter.ErrLetterNotFound)
+	assert.ErrorIs(t, store.Delete(t.Context(), older.ID), deadletter.ErrLetterNotFound)
+
+	require.NoError(t, store.Purge(t.Context()))
+	page, err = store.List(t.Context(), deadletter.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, page.DeadLetters)
+}
+
+func TestInMemoryStore_List_Pagination(t *testing.T) {
+	t.Parallel()
+
+	store := deadletter.NewInMemoryStore()
+
+	failedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := range 5 {
+		letter, err := deadletter.NewLetter(someNotification(), "email", errSomethingFailed)
+		require.NoError(t, err)
+		letter.ID = fmt.Sprintf("letter-%02d", i)
+		letter.FailedAt = failedAt.Add(time.Duration(i/2) * time.Second)
+		require.NoError(t, store.Add(t.Context(), letter))
+	}
+
+	page, err := store.List(t.Context(), deadletter.ListOptions{Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"letter-04", "letter-02"}, letterIDs(page))
+	assert.Equal(t, "letter-02", page.NextCursor)
+
+	page, err = store.List(t.Context(), deadletter.ListOptions{Limit: 2, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"letter-03", "letter-00"}, letterIDs(page))
+	assert.Equal(t, "letter-00", page.NextCursor)
+
+	page, err = store.List(t.Context(), deadletter.ListOptions{Limit: 2, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"letter-01"}, letterIDs(page))
+	assert.Empty(t, page.NextCursor)
+
+	_, err = store.List(t.Context(), deadletter.ListOptions{Cursor: "nope"})
+	assert.ErrorIs(t, err, deadletter.ErrInvalidCursor)
+}
+
+func letterIDs(page *deadletter.Page) []string {
+	ids := make([]string, len(page.DeadLetters))
+	for i, letter := range page.DeadLetters {
+		ids[i] = letter.ID
+	}
+	return ids
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package dedup prevents the same incoming event from being processed more than once
+package dedup
+
+import (
+	"context"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/validation"
+)
+
+// Store remembers which events have already been seen.
+// Implementations may be backed by a SQL database, an in-memory cache, or something else.
+type Store interface {
+	// Claim atomically records the given key as seen for the given TTL.
+	// It returns false if the key was already claimed and has not yet expired.
+	Claim(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// Release forgets the given key so that it may be claimed again
+	Release(ctx context.Context, key string) error
+}
+
+// Deduplicator decides whether an incoming event has already been processed, based on its parser and event.ID
+type Deduplicator struct {
+	store   Store
+	window  time.Duration
+	windows map[string]time.Duration
+}
+
+var _ validation.Validator = &Deduplicator{}
+
+// Option configures a Deduplicator
+type Option func(*Deduplicator)
+
+// WithWindow sets how long event IDs are remembered for (default 24h)
+func WithWindow(window time.Duration) Option {
+	return func(d *Deduplicator) {
+		d.window = window
+	}
+}
+
+// WithWindowFor overrides how long event IDs are remembered for events from the given parser key.
+// A window of zero disables deduplication for that parser entirely.
+func WithWindowFor(parserKey string, window time.Duration) Option {
+	return func(d *Deduplicator) {
+		d.windows[parserKey] = window
+	}
+}
+
+// New creates a new Deduplicator backed by the given Store
+func New(store Store, opts ...Option) *Deduplicator {
+	d := &Deduplicator{
+		store:   store,
+		window:  24 * time.Hour,
+		windows: make(map[string]time.Duration),
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// Claim marks the given event as being processed, returning false if it was already processed within the window.
+// Events without an ID, or from parsers with deduplication disabled, are always claimable.
+func (d *Deduplicator) Claim(ctx context.Context, parserKey string, id event.ID) (bool, error) {
+	window := d.windowFor(parserKey)
+	if id == "" || window <= 0 {
+		return true, nil
+	}
+
+	return d.store.Claim(ctx, key(parserKey, id), window)
+}
+
+// Release forgets the given event, allowing it to be processed again (e.g. after processing failed)
+func (d *Deduplicator) Release(ctx context.Context, parserKey string, id event.ID) error {
+	if id == "" || d.windowFor(parserKey) <= 0 {
+		return nil
+	}
+
+	return d.store.Release(ctx, key(parserKey, id))
+}
+
+// Validate validates the underlying Store, if supported
+func (d *Deduplicator) Validate(ctx context.Context) error {
+	if v, ok := d.store.(validation.Validator); ok {
+		return v.Validate(ctx)
+	}
+
+	return nil
+}
+
+func (d *Deduplicator) windowFor(parserKey string) time.Duration {
+	if window, ok := d.windows[parserKey]; ok {
+		return window
+	}
+
+	return d.window
+}
+
+func key(parserKey string, id event.ID) string {
+	return parserKey + "/" + string(id)
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package dedup_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/dedup"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeduplicator_Claim(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		opts        []dedup.Option
+		parserKey   string
+		id          event.ID
+		wantClaimed []bool
+	}{
+		{
+			name:        "duplicate events are not claimable",
+			parserKey:   "gitlab",
+			id:          "a1c11a53-c4be-488f-89b6-f83bf2d48dab",
+			wantClaimed: []bool{true, false, false},
+		},
+		{
+			name:        "events without an ID are always claimable",
+			parserKey:   "gitlab",
+			id:          "",
+			wantClaimed: []bool{true, true},
+		},
+		{
+			name:        "dedup can be disabled per parser",
+			opts:        []dedup.Option{dedup.WithWindowFor("gitlab", 0)},
+			parserKey:   "gitlab",
+			id:          "a1c11a53-c4be-488f-89b6-f83bf2d48dab",
+			wantClaimed: []bool{true, true},
+		},
+		{
+			name:        "per-parser windows only apply to that parser",
+			opts:        []dedup.Option{dedup.WithWindowFor("github", 0)},
+			parserKey:   "gitlab",
+			id:          "a1c11a53-c4be-488f-89b6-f83bf2d48dab",
+			wantClaimed: []bool{true, false},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			d := dedup.New(dedup.NewInMemoryStore(), tc.opts...)
+
+			for i, want := range tc.wantClaimed {
+				got, err := d.Claim(t.Context(), tc.parserKey, tc.id)
+				require.NoError(t, err)
+				assert.Equal(t, want, got, "claim #%d", i+1)
+			}
+		})
+	}
+}
+
+func TestDeduplicator_Release(t *testing.T) {
+	t.Parallel()
+
+	d := dedup.New(dedup.NewInMemoryStore())
+
+	claimed, err := d.Claim(t.Context(), "gitlab", "some-id")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	// The same ID from a different parser is a different event
+	claimed, err = d.Claim(t.Context(), "github", "some-id")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	require.NoError(t, d.Release(t.Context(), "gitlab", "some-id"))
+
+	claimed, err = d.Claim(t.Context(), "gitlab", "some-id")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+}
+
+func TestDeduplicator_Window(t *testing.T) {
+	t.Parallel()
+
+	d := dedup.New(dedup.NewInMemoryStore(), dedup.WithWindow(50*time.Millisecond))
+
+	claimed, err := d.Claim(t.Context(), "gitlab", "some-id")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = d.Claim(t.Context(), "gitlab", "some-id")
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	time.Sleep(60 * time.Millisecond)
+
+	claimed, err = d.Claim(t.Context(), "gitlab", "some-id")
+	require.NoError(t, err)
+	assert.True(t, claimed)
+}
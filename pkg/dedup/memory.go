@@ -0,0 +1,75 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package dedup
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+)
+
+// InMemoryStore is a simple in-memory implementation of the Store interface which behaves like a TTL cache
+// Seen events are forgotten on restart, and are not shared between replicas.
+type InMemoryStore struct {
+	expiries  map[string]time.Time
+	lastSweep time.Time
+	mu        sync.Mutex
+}
+
+var _ Store = &InMemoryStore{}
+
+// sweepInterval is how often expired entries are evicted from the in-memory store
+const sweepInterval = time.Minute
+
+// NewInMemoryStore creates a new, empty in-memory store
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{
+		expiries:  make(map[string]time.Time),
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *InMemoryStore) Claim(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.sweep(now)
+
+	if expiry, ok := s.expiries[key]; ok && expiry.After(now) {
+		return false, nil
+	}
+
+	s.expiries[key] = now.Add(ttl)
+	return true, nil
+}
+
+func (s *InMemoryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.expiries, key)
+	return nil
+}
+
+// Len returns the number of keys currently held by the store (including any expired keys not yet evicted)
+func (s *InMemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.expiries)
+}
+
+func (s *InMemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+
+	maps.DeleteFunc(s.expiries, func(_ string, expiry time.Time) bool {
+		return !expiry.After(now)
+	})
+	s.lastSweep = now
+}
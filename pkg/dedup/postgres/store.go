@@ -0,0 +1,83 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package postgres provides a postgresql-backed implementation of the dedup.Store interface
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/dedup"
+	"github.com/seatgeek/mailroom/pkg/validation"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SeenEventModel is the gorm model for an event which has already been seen
+type SeenEventModel struct {
+	Key       string    `gorm:"primarykey"`
+	ExpiresAt time.Time `gorm:"index"`
+}
+
+func (s *SeenEventModel) TableName() string {
+	return "seen_events"
+}
+
+type Store struct {
+	db *gorm.DB
+}
+
+var (
+	_ dedup.Store          = &Store{}
+	_ validation.Validator = &Store{}
+)
+
+// NewPostgresStore creates a new postgres store
+func NewPostgresStore(db *gorm.DB) *Store {
+	return &Store{db: db}
+}
+
+// Claim implements dedup.Store.
+// Expired keys are overwritten in place, so the claim is atomic even across multiple replicas.
+func (s *Store) Claim(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+
+	result := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"expires_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "seen_events.expires_at <= ?", Vars: []any{now}},
+		}},
+	}).Create(&SeenEventModel{
+		Key:       key,
+		ExpiresAt: now.Add(ttl),
+	})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// Release implements dedup.Store.
+func (s *Store) Release(ctx context.Context, key string) error {
+	return s.db.WithContext(ctx).Delete(&SeenEventModel{Key: key}).Error
+}
+
+// Prune deletes all expired keys.
+// This is not required for correctness, but should be called periodically to keep the table small.
+func (s *Store) Prune(ctx context.Context) error {
+	return s.db.WithContext(ctx).Where("expires_at <= ?", time.Now()).Delete(&SeenEventModel{}).Error
+}
+
+// Validate checks that the seen events table exists
+func (s *Store) Validate(ctx context.Context) error {
+	if !s.db.WithContext(ctx).Migrator().HasTable(&SeenEventModel{}) {
+		return fmt.Errorf("table %q does not exist", (&SeenEventModel{}).TableName())
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/dedup/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+	pgtc "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	pg "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestPostgresStore_Claim(t *testing.T) {
+	t.Parallel()
+
+	store := createDatastore(t)
+	assert.NoError(t, store.Validate(t.Context()))
+
+	claimed, err := store.Claim(t.Context(), "gitlab/some-id", 500*time.Millisecond)
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+
+	// Already claimed
+	claimed, err = store.Claim(t.Context(), "gitlab/some-id", 500*time.Millisecond)
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+
+	// Different key
+	claimed, err = store.Claim(t.Context(), "github/some-id", 500*time.Millisecond)
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+
+	// Expired claims can be claimed again
+	time.Sleep(600 * time.Millisecond)
+	claimed, err = store.Claim(t.Context(), "gitlab/some-id", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+
+	// Released claims can be claimed again
+	assert.NoError(t, store.Release(t.Context(), "gitlab/some-id"))
+	claimed, err = store.Claim(t.Context(), "gitlab/some-id", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, claimed)
+
+	// Pruning only removes expired claims
+	assert.NoError(t, store.Prune(t.Context()))
+	claimed, err = store.Claim(t.Context(), "gitlab/some-id", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+}
+
+func createDatastore(t *testing.T) *postgres.Store {
+	t.Helper()
+
+	ctx := context.Background()
+
+	container, err := pgtc.Run(ctx, "postgres:16.2",
+		pgtc.WithInitScripts("../../../test/initdb/init.sql"),
+		pgtc.WithDatabase("mailroom"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second)),
+	)
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, container.Terminate(ctx))
+	})
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable", "application_name=test")
+	assert.NoError(t, err)
+
+	db, err := gorm.Open(pg.Open(dsn), &gorm.Config{})
+	assert.NoError(t, err)
+
+	return postgres.NewPostgresStore(db)
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package delivery keeps a log of every attempt to deliver a notification so that support staff can find out what happened to it
+package delivery
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seatgeek/mailroom/internal/httputil"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor doesn't refer to a delivery record in the Store
+var ErrInvalidCursor = httputil.ErrInvalidCursor
+
+const (
+	// DefaultLimit is the number of records returned per page when no limit is given
+	DefaultLimit = httputil.DefaultLimit
+	// MaxLimit is the largest number of records that can be requested per page
+	MaxLimit = httputil.MaxLimit
+)
+
+// Preference describes the recipient's preference which applied to a delivery attempt
+type Preference string
+
+const (
+	// PreferenceWanted means the recipient explicitly opted in to this notification via this transport
+	PreferenceWanted Preference = "wanted"
+	// PreferenceUnwanted means the recipient explicitly opted out of this notification via this transport
+	PreferenceUnwanted Preference = "unwanted"
+	// PreferenceDefault means the recipient had no explicit preference, so the notification was sent anyway
+	PreferenceDefault Preference = "default"
+)
+
+// Outcome describes the result of a delivery attempt
+type Outcome string
+
+const (
+	OutcomeDelivered Outcome = "delivered"
+	OutcomeFailed    Outcome = "failed"
+	OutcomeSkipped   Outcome = "skipped"
+)
+
+// Record describes a single attempt to deliver a notification via some transport
+type Record struct {
+	ID           string                                 `json:"id"`
+	EventID      event.ID                               `json:"event_id"`
+	Notification notification.Envelope                  `json:"notification"`
+	Recipient    map[identifier.NamespaceAndKind]string `json:"recipient"`
+	Transport    event.TransportKey                     `json:"transport"`
+	Preference   Preference                             `json:"preference"`
+	Outcome      Outcome                                `json:"outcome"`
+	Error        string                                 `json:"error,omitempty"`
+	StartedAt    time.Time                              `json:"started_at"`
+	FinishedAt   time.Time                              `json:"finished_at"`
+}
+
+// NewRecord creates a new Record from a notifier.Attempt
+func NewRecord(attempt notifier.Attempt) *Record {
+	// Records are never replayed, so anything which can't be serialized is fine to leave out
+	env, _ := notification.Seal(attempt.Notification, []event.TransportKey{attempt.Transport})
+
+	r := &Record{
+		ID:           uuid.New().String(),
+		EventID:      attempt.Notification.Context().ID,
+		Notification: env,
+		Recipient:    attempt.Notification.Recipient().ToMap(),
+		Transport:    attempt.Transport,
+		Preference:   PreferenceDefault,
+		Outcome:      OutcomeDelivered,
+		StartedAt:    attempt.StartedAt,
+		FinishedAt:   attempt.FinishedAt,
+	}
+
+	if attempt.Wants != nil {
+		if *attempt.Wants {
+			r.Preference = PreferenceWanted
+		} else {
+			r.Preference = PreferenceUnwanted
+		}
+	}
+
+	if attempt.Skipped {
+		r.Outcome = OutcomeSkipped
+	} else if attempt.Err != nil {
+		r.Outcome = OutcomeFailed
+		r.Error = attempt.Err.Error()
+	}
+
+	return r
+}
+
+// ListOptions selects the page of records returned by Store.ListByEvent and Store.ListByRecipient
+type ListOptions = httputil.PageOptions
+
+// Page is a page of delivery records, oldest first
+type Page struct {
+	Deliveries []*Record `json:"deliveries"`
+	// NextCursor can be passed as ListOptions.Cursor to get the next page; it's empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Store persists delivery records.
+// Implementations may be backed by a SQL database, an in-memory store, or something else.
+type Store interface {
+	// Add stores a new delivery record
+	Add(ctx context.Context, record *Record) error
+	// ListByEvent returns a page of records for notifications generated from the given event, oldest first, or ErrInvalidCursor
+	ListByEvent(ctx context.Context, id event.ID, opts ListOptions) (*Page, error)
+	// ListByRecipient returns a page of records for notifications whose recipient matches any of the given identifiers,
+	// oldest first, or ErrInvalidCursor
+	ListByRecipient(ctx context.Context, identifiers identifier.Set, opts ListOptions) (*Page, error)
+}
+
+// Recorder is a notifier.Observer which writes a Record for every delivery attempt to a Store
+type Recorder struct {
+	store Store
+}
+
+var _ notifier.Observer = &Recorder{}
+
+// NewRecorder creates a new Recorder
+func NewRecorder(store Store) *Recorder {
+	return &Recorder{store: store}
+}
+
+func (r *Recorder) Observe(ctx context.Context, attempt notifier.Attempt) {
+	record := NewRecord(attempt)
+	if err := r.store.Add(ctx, record); err != nil {
+		slog.ErrorContext(ctx, "failed to record delivery attempt", "id", record.EventID, "transport", record.Transport, "outcome", record.Outcome, "error", err)
+	}
+}
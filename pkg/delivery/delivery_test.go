@@ -0,0 +1,109 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package delivery_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/delivery"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errSomethingFailed = errors.New("something failed")
+
+func TestNewRecord(t *testing.T) {
+	t.Parallel()
+
+	yes, no := true, false
+	started := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		attempt        notifier.Attempt
+		wantPreference delivery.Preference
+		wantOutcome    delivery.Outcome
+		wantError      string
+	}{
+		{
+			name:           "delivered without explicit preference",
+			attempt:        notifier.Attempt{Transport: "email"},
+			wantPreference: delivery.PreferenceDefault,
+			wantOutcome:    delivery.OutcomeDelivered,
+		},
+		{
+			name:           "delivered because wanted",
+			attempt:        notifier.Attempt{Transport: "email", Wants: &yes},
+			wantPreference: delivery.PreferenceWanted,
+			wantOutcome:    delivery.OutcomeDelivered,
+		},
+		{
+			name:           "skipped because unwanted",
+			attempt:        notifier.Attempt{Transport: "email", Wants: &no, Skipped: true},
+			wantPreference: delivery.PreferenceUnwanted,
+			wantOutcome:    delivery.OutcomeSkipped,
+		},
+		{
+			name:           "failed",
+			attempt:        notifier.Attempt{Transport: "email", Err: errSomethingFailed},
+			wantPreference: delivery.PreferenceDefault,
+			wantOutcome:    delivery.OutcomeFailed,
+			wantError:      "something failed",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tc.attempt.Notification = someNotification()
+			tc.attempt.StartedAt = started
+			tc.attempt.FinishedAt = started.Add(time.Second)
+
+			record := delivery.NewRecord(tc.attempt)
+
+			assert.NotEmpty(t, record.ID)
+			assert.Equal(t, event.ID("a1c11a53-c4be-488f-89b6-f83bf2d48dab"), record.EventID)
+			assert.Equal(t, "hello world", record.Notification.Open().Render("email"))
+			assert.Equal(t, map[identifier.NamespaceAndKind]string{identifier.GenericUsername: "codell"}, record.Recipient)
+			assert.Equal(t, event.TransportKey("email"), record.Transport)
+			assert.Equal(t, tc.wantPreference, record.Preference)
+			assert.Equal(t, tc.wantOutcome, record.Outcome)
+			assert.Equal(t, tc.wantError, record.Error)
+			assert.Equal(t, started, record.StartedAt)
+			assert.Equal(t, started.Add(time.Second), record.FinishedAt)
+		})
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	store := delivery.NewInMemoryStore()
+	recorder := delivery.NewRecorder(store)
+
+	recorder.Observe(t.Context(), notifier.Attempt{Notification: someNotification(), Transport: "email"})
+
+	page, err := store.ListByEvent(t.Context(), "a1c11a53-c4be-488f-89b6-f83bf2d48dab", delivery.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, page.Deliveries, 1)
+	assert.Equal(t, delivery.OutcomeDelivered, page.Deliveries[0].Outcome)
+}
+
+func someNotification() event.Notification {
+	return notification.NewBuilder(event.Context{
+		ID:   "a1c11a53-c4be-488f-89b6-f83bf2d48dab",
+		Type: "com.example.test",
+	}).
+		WithRecipientIdentifiers(identifier.New(identifier.GenericUsername, "codell")).
+		WithDefaultMessage("hello world").
+		Build()
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package delivery
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/seatgeek/mailroom/internal/httputil"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/user"
+)
+
+// Handler exposes an HTTP API for querying delivery records
+type Handler struct {
+	store     Store
+	userStore user.Store
+}
+
+// NewHandler creates a new Handler
+func NewHandler(store Store, userStore user.Store) *Handler {
+	return &Handler{
+		store:     store,
+		userStore: userStore,
+	}
+}
+
+// ListByEvent returns a page of delivery records for a given event ID
+// It supports the "limit" and "cursor" query parameters (see ListOptions).
+func (h *Handler) ListByEvent(writer http.ResponseWriter, request *http.Request) {
+	id := event.ID(mux.Vars(request)["id"])
+
+	opts, err := httputil.ParsePageOptions(request.URL.Query())
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.store.ListByEvent(request.Context(), id, opts)
+	if err != nil {
+		if errors.Is(err, ErrInvalidCursor) {
+			http.Error(writer, "invalid cursor", http.StatusBadRequest)
+			return
+		}
+
+		slog.ErrorContext(request.Context(), "failed to list deliveries", "id", id, "error", err)
+		http.Error(writer, "failed to list deliveries", http.StatusInternalServerError)
+		return
+	}
+
+	httputil.WriteJSON(request.Context(), writer, page)
+}
+
+// ListByUser returns a page of delivery records for notifications sent to a given user
+// It supports the "limit" and "cursor" query parameters (see ListOptions).
+func (h *Handler) ListByUser(writer http.ResponseWriter, request *http.Request) {
+	key := mux.Vars(request)["key"]
+
+	opts, err := httputil.ParsePageOptions(request.URL.Query())
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if h.userStore == nil {
+		http.Error(writer, "user not found", http.StatusNotFound)
+		return
+	}
+
+	u, err := h.userStore.Get(request.Context(), key)
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			slog.InfoContext(request.Context(), "user not found", "key", key)
+			http.Error(writer, "user not found", http.StatusNotFound)
+			return
+		}
+
+		slog.ErrorContext(request.Context(), "failed to get user", "key", key, "error", err)
+		http.Error(writer, "failed to get user", http.StatusInternalServerError)
+		return
+	}
+
+	page, err := h.store.ListByRecipient(request.Context(), u.Identifiers, opts)
+	if err != nil {
+		if errors.Is(err, ErrInvalidCursor) {
+			http.Error(writer, "invalid cursor", http.StatusBadRequest)
+			return
+		}
+
+		slog.ErrorContext(request.Context(), "failed to list deliveries", "key", key, "error", err)
+		http.Error(writer, "failed to list deliveries", http.StatusInternalServerError)
+		return
+	}
+
+	httputil.WriteJSON(request.Context(), writer, page)
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package delivery_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/seatgeek/mailroom/pkg/delivery"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/seatgeek/mailroom/pkg/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type listBody struct {
+	Deliveries []delivery.Record `json:"deliveries"`
+}
+
+func TestHandler_ListByEvent(t *testing.T) {
+	t.Parallel()
+
+	router, record := createRouter(t)
+
+	t.Run("Happy path", func(t *testing.T) {
+		t.Parallel()
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, httptest.NewRequestWithContext(t.Context(), "GET", "/events/a1c11a53-c4be-488f-89b6-f83bf2d48dab/deliveries", nil))
+
+		assert.Equal(t, 200, writer.Code)
+
+		var body listBody
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), &body))
+		require.Len(t, body.Deliveries, 1)
+		assert.Equal(t, record.ID, body.Deliveries[0].ID)
+		assert.Equal(t, event.TransportKey("email"), body.Deliveries[0].Transport)
+		assert.Equal(t, delivery.OutcomeFailed, body.Deliveries[0].Outcome)
+		assert.Equal(t, "something failed", body.Deliveries[0].Error)
+	})
+
+	t.Run("Unknown event", func(t *testing.T) {
+		t.Parallel()
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, httptest.NewRequestWithContext(t.Context(), "GET", "/events/nope/deliveries", nil))
+
+		assert.Equal(t, 200, writer.Code)
+		assert.JSONEq(t, `{"deliveries": []}`, writer.Body.String())
+	})
+
+	t.Run("Next page", func(t *testing.T) {
+		t.Parallel()
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, httptest.NewRequestWithContext(t.Context(), "GET", "/events/a1c11a53-c4be-488f-89b6-f83bf2d48dab/deliveries?limit=1&cursor="+record.ID, nil))
+
+		assert.Equal(t, 200, writer.Code)
+		assert.JSONEq(t, `{"deliveries": []}`, writer.Body.String())
+	})
+
+	t.Run("Invalid limit", func(t *testing.T) {
+		t.Parallel()
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, httptest.NewRequestWithContext(t.Context(), "GET", "/events/a1c11a53-c4be-488f-89b6-f83bf2d48dab/deliveries?limit=1000", nil))
+
+		assert.Equal(t, 400, writer.Code)
+	})
+
+	t.Run("Invalid cursor", func(t *testing.T) {
+		t.Parallel()
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, httptest.NewRequestWithContext(t.Context(), "GET", "/events/a1c11a53-c4be-488f-89b6-f83bf2d48dab/deliveries?cursor=nope", nil))
+
+		assert.Equal(t, 400, writer.Code)
+	})
+}
+
+func TestHandler_ListByUser(t *testing.T) {
+	t.Parallel()
+
+	router, record := createRouter(t)
+
+	t.Run("Happy path", func(t *testing.T) {
+		t.Parallel()
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, httptest.NewRequestWithContext(t.Context(), "GET", "/users/codell/deliveries", nil))
+
+		assert.Equal(t, 200, writer.Code)
+
+		var body listBody
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), &body))
+		require.Len(t, body.Deliveries, 1)
+		assert.Equal(t, record.ID, body.Deliveries[0].ID)
+	})
+
+	t.Run("User doesn't exist", func(t *testing.T) {
+		t.Parallel()
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, httptest.NewRequestWithContext(t.Context(), "GET", "/users/nope/deliveries", nil))
+
+		assert.Equal(t, 404, writer.Code)
+	})
+
+	t.Run("Invalid cursor", func(t *testing.T) {
+		t.Parallel()
+
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, httptest.NewRequestWithContext(t.Context(), "GET", "/users/codell/deliveries?cursor=nope", nil))
+
+		assert.Equal(t, 400, writer.Code)
+	})
+}
+
+func createRouter(t *testing.T) (*mux.Router, *delivery.Record) {
+	t.Helper()
+
+	store := delivery.NewInMemoryStore()
+	record := delivery.NewRecord(notifier.Attempt{Notification: someNotification(), Transport: "email", Err: errSomethingFailed})
+	require.NoError(t, store.Add(t.Context(), record))
+
+	userStore := user.NewInMemoryStore(
+		user.New("codell", user.WithIdentifier(identifier.New(identifier.GenericUsername, "codell"))),
+	)
+
+	handler := delivery.NewHandler(store, userStore)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/events/{id}/deliveries", handler.ListByEvent).Methods("GET")
+	router.HandleFunc("/users/{key}/deliveries", handler.ListByUser).Methods("GET")
+
+	return router, record
+}
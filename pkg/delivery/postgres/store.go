@@ -0,0 +1,133 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package postgres provides a postgresql-backed implementation of the delivery.Store interface
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/delivery"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"gorm.io/gorm"
+)
+
+// RecordModel is the gorm model for a delivery record
+type RecordModel struct {
+	ID           string                                 `gorm:"primarykey"`
+	EventID      event.ID                               `gorm:"index"`
+	Notification notification.Envelope                  `gorm:"serializer:json"`
+	Recipient    map[identifier.NamespaceAndKind]string `gorm:"serializer:json"`
+	Transport    event.TransportKey
+	Preference   delivery.Preference
+	Outcome      delivery.Outcome
+	Error        string
+	StartedAt    time.Time
+	FinishedAt   time.Time
+}
+
+func (r *RecordModel) TableName() string {
+	return "delivery_records"
+}
+
+// ToRecord converts a RecordModel to a delivery.Record
+func (r *RecordModel) ToRecord() *delivery.Record {
+	return &delivery.Record{
+		ID:           r.ID,
+		EventID:      r.EventID,
+		Notification: r.Notification,
+		Recipient:    r.Recipient,
+		Transport:    r.Transport,
+		Preference:   r.Preference,
+		Outcome:      r.Outcome,
+		Error:        r.Error,
+		StartedAt:    r.StartedAt,
+		FinishedAt:   r.FinishedAt,
+	}
+}
+
+type Store struct {
+	db *gorm.DB
+}
+
+var _ delivery.Store = &Store{}
+
+// NewPostgresStore creates a new postgres store
+func NewPostgresStore(db *gorm.DB) *Store {
+	return &Store{db: db}
+}
+
+// Add implements delivery.Store.
+func (s *Store) Add(ctx context.Context, record *delivery.Record) error {
+	return s.db.WithContext(ctx).Create(&RecordModel{
+		ID:           record.ID,
+		EventID:      record.EventID,
+		Notification: record.Notification,
+		Recipient:    record.Recipient,
+		Transport:    record.Transport,
+		Preference:   record.Preference,
+		Outcome:      record.Outcome,
+		Error:        record.Error,
+		StartedAt:    record.StartedAt,
+		FinishedAt:   record.FinishedAt,
+	}).Error
+}
+
+// ListByEvent implements delivery.Store.
+func (s *Store) ListByEvent(ctx context.Context, id event.ID, opts delivery.ListOptions) (*delivery.Page, error) {
+	return s.find(ctx, s.db.WithContext(ctx).Where("event_id = ?", id), opts)
+}
+
+// ListByRecipient implements delivery.Store.
+func (s *Store) ListByRecipient(ctx context.Context, identifiers identifier.Set, opts delivery.ListOptions) (*delivery.Page, error) {
+	if identifiers.Len() == 0 {
+		return &delivery.Page{Deliveries: []*delivery.Record{}}, nil
+	}
+
+	matches := s.db
+	for _, id := range identifiers.ToList() {
+		matches = matches.Or("recipient @> ?", fmt.Sprintf(`{"%s": "%s"}`, id.NamespaceAndKind, id.Value))
+	}
+
+	// Group the recipient conditions so that the cursor applies to all of them
+	return s.find(ctx, s.db.WithContext(ctx).Model(&RecordModel{}).Where(matches), opts)
+}
+
+func (s *Store) find(ctx context.Context, query *gorm.DB, opts delivery.ListOptions) (*delivery.Page, error) {
+	if opts.Cursor != "" {
+		var cursor RecordModel
+		if err := s.db.WithContext(ctx).Where("id = ?", opts.Cursor).First(&cursor).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, delivery.ErrInvalidCursor
+			}
+			return nil, err
+		}
+
+		query = query.Where("(started_at, id) > (?, ?)", cursor.StartedAt, cursor.ID)
+	}
+
+	limit := opts.PageSize()
+
+	// Fetch one extra record to find out whether there's another page
+	var models []RecordModel
+	if err := query.Order("started_at, id").Limit(limit + 1).Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	page := &delivery.Page{Deliveries: make([]*delivery.Record, 0, len(models))}
+	for i := range models {
+		if i == limit {
+			page.NextCursor = models[i-1].ID
+			break
+		}
+		page.Deliveries = append(page.Deliveries, models[i].ToRecord())
+	}
+
+	return page, nil
+}
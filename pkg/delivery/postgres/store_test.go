@@ -0,0 +1,117 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/delivery"
+	"github.com/seatgeek/mailroom/pkg/delivery/postgres"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+	pgtc "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	pg "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestPostgresStore(t *testing.T) {
+	t.Parallel()
+
+	store := createDatastore(t)
+
+	n := notification.NewBuilder(event.Context{
+		ID:     "a1c11a53-c4be-488f-89b6-f83bf2d48dab",
+		Source: event.MustSource("https://example.com"),
+		Type:   "com.example.test",
+	}).
+		WithRecipientIdentifiers(identifier.New(identifier.GenericUsername, "codell")).
+		WithDefaultMessage("hello world").
+		Build()
+
+	started := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	first := delivery.NewRecord(notifier.Attempt{Notification: n, Transport: "email", StartedAt: started, FinishedAt: started})
+	second := delivery.NewRecord(notifier.Attempt{Notification: n, Transport: "slack", Err: errors.New("something failed"), StartedAt: started.Add(time.Second), FinishedAt: started.Add(time.Second)})
+
+	assert.NoError(t, store.Add(t.Context(), second))
+	assert.NoError(t, store.Add(t.Context(), first))
+
+	// Records are returned oldest first
+	page, err := store.ListByEvent(t.Context(), n.Context().ID, delivery.ListOptions{})
+	assert.NoError(t, err)
+	if assert.Len(t, page.Deliveries, 2) {
+		assert.Equal(t, first.ID, page.Deliveries[0].ID)
+		assert.Equal(t, second.ID, page.Deliveries[1].ID)
+		assert.Equal(t, first.Notification, page.Deliveries[0].Notification)
+		assert.Equal(t, delivery.OutcomeFailed, page.Deliveries[1].Outcome)
+		assert.Equal(t, "something failed", page.Deliveries[1].Error)
+	}
+	assert.Empty(t, page.NextCursor)
+
+	recipient := identifier.NewSet(
+		identifier.New(identifier.GenericEmail, "codell@example.com"),
+		identifier.New(identifier.GenericUsername, "codell"),
+	)
+	page, err = store.ListByRecipient(t.Context(), recipient, delivery.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, page.Deliveries, 2)
+
+	// And can be paginated
+	page, err = store.ListByRecipient(t.Context(), recipient, delivery.ListOptions{Limit: 1})
+	assert.NoError(t, err)
+	if assert.Len(t, page.Deliveries, 1) {
+		assert.Equal(t, first.ID, page.Deliveries[0].ID)
+	}
+	assert.Equal(t, first.ID, page.NextCursor)
+
+	page, err = store.ListByEvent(t.Context(), n.Context().ID, delivery.ListOptions{Limit: 1, Cursor: page.NextCursor})
+	assert.NoError(t, err)
+	if assert.Len(t, page.Deliveries, 1) {
+		assert.Equal(t, second.ID, page.Deliveries[0].ID)
+	}
+	assert.Empty(t, page.NextCursor)
+
+	_, err = store.ListByEvent(t.Context(), n.Context().ID, delivery.ListOptions{Cursor: "nope"})
+	assert.ErrorIs(t, err, delivery.ErrInvalidCursor)
+
+	page, err = store.ListByRecipient(t.Context(), identifier.NewSet(identifier.New(identifier.GenericUsername, "rufus")), delivery.ListOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, page.Deliveries)
+}
+
+func createDatastore(t *testing.T) *postgres.Store {
+	t.Helper()
+
+	ctx := context.Background()
+
+	container, err := pgtc.Run(ctx, "postgres:16.2",
+		pgtc.WithInitScripts("../../../test/initdb/init.sql"),
+		pgtc.WithDatabase("mailroom"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second)),
+	)
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, container.Terminate(ctx))
+	})
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable", "application_name=test")
+	assert.NoError(t, err)
+
+	db, err := gorm.Open(pg.Open(dsn), &gorm.Config{})
+	assert.NoError(t, err)
+
+	return postgres.NewPostgresStore(db)
+}
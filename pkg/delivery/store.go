@@ -0,0 +1,96 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package delivery
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+)
+
+// InMemoryStore is a simple in-memory implementation of the Store interface
+// This is especially useful for testing, but records are lost on restart and are kept forever otherwise.
+type InMemoryStore struct {
+	records []*Record
+	mu      sync.RWMutex
+}
+
+var _ Store = &InMemoryStore{}
+
+// NewInMemoryStore creates a new, empty in-memory store
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{}
+}
+
+func (s *InMemoryStore) Add(_ context.Context, record *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *InMemoryStore) ListByEvent(_ context.Context, id event.ID, opts ListOptions) (*Page, error) {
+	return s.filter(opts, func(r *Record) bool {
+		return r.EventID == id
+	})
+}
+
+func (s *InMemoryStore) ListByRecipient(_ context.Context, identifiers identifier.Set, opts ListOptions) (*Page, error) {
+	return s.filter(opts, func(r *Record) bool {
+		return identifiers.Intersect(identifier.NewSetFromMap(r.Recipient)).Len() > 0
+	})
+}
+
+func (s *InMemoryStore) filter(opts ListOptions, match func(*Record) bool) (*Page, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	matches := make([]*Record, 0)
+	for _, r := range s.records {
+		if match(r) {
+			matches = append(matches, r)
+		}
+	}
+
+	slices.SortFunc(matches, oldestFirst)
+
+	if opts.Cursor != "" {
+		i := slices.IndexFunc(s.records, func(r *Record) bool {
+			return r.ID == opts.Cursor
+		})
+		if i < 0 {
+			return nil, ErrInvalidCursor
+		}
+
+		// Skip everything up to and including the cursor
+		start, found := slices.BinarySearchFunc(matches, s.records[i], oldestFirst)
+		if found {
+			start++
+		}
+		matches = matches[start:]
+	}
+
+	page := &Page{Deliveries: make([]*Record, 0, opts.PageSize())}
+	for i, r := range matches {
+		if i == opts.PageSize() {
+			page.NextCursor = page.Deliveries[i-1].ID
+			break
+		}
+
+		page.Deliveries = append(page.Deliveries, r)
+	}
+
+	return page, nil
+}
+
+// oldestFirst orders records the same way as the postgres store, by when they started and then by ID
+func oldestFirst(a, b *Record) int {
+	return cmp.Or(a.StartedAt.Compare(b.StartedAt), cmp.Compare(a.ID, b.ID))
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package delivery_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/delivery"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	store := delivery.NewInMemoryStore()
+
+	started := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	email := delivery.NewRecord(notifier.Attempt{Notification: someNotification(), Transport: "email", StartedAt: started})
+	slack := delivery.NewRecord(notifier.Attempt{Notification: someNotification(), Transport: "slack", Err: errSomethingFailed, StartedAt: started.Add(time.Second)})
+
+	// Records are returned oldest first, whatever order they were added in
+	require.NoError(t, store.Add(t.Context(), slack))
+	require.NoError(t, store.Add(t.Context(), email))
+
+	page, err := store.ListByEvent(t.Context(), "a1c11a53-c4be-488f-89b6-f83bf2d48dab", delivery.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []*delivery.Record{email, slack}, page.Deliveries)
+
+	page, err = store.ListByEvent(t.Context(), "some-other-event", delivery.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, page.Deliveries)
+
+	page, err = store.ListByRecipient(t.Context(), identifier.NewSet(
+		identifier.New(identifier.GenericEmail, "codell@example.com"),
+		identifier.New(identifier.GenericUsername, "codell"),
+	), delivery.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []*delivery.Record{email, slack}, page.Deliveries)
+
+	page, err = store.ListByRecipient(t.Context(), identifier.NewSet(identifier.New(identifier.GenericUsername, "rufus")), delivery.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, page.Deliveries)
+}
+
+func TestInMemoryStore_Pagination(t *testing.T) {
+	t.Parallel()
+
+	store := delivery.NewInMemoryStore()
+
+	// Records which started at the same time are ordered by ID
+	started := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, i := range []int{4, 3, 2, 1, 0} {
+		record := delivery.NewRecord(notifier.Attempt{Notification: someNotification(), Transport: "email", StartedAt: started.Add(time.Duration(i/2) * time.Second)})
+		record.ID = fmt.Sprintf("record-%02d", i)
+		require.NoError(t, store.Add(t.Context(), record))
+	}
+
+	page, err := store.ListByEvent(t.Context(), "a1c11a53-c4be-488f-89b6-f83bf2d48dab", delivery.ListOptions{Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"record-00", "record-01"}, recordIDs(page))
+	assert.Equal(t, "record-01", page.NextCursor)
+
+	page, err = store.ListByEvent(t.Context(), "a1c11a53-c4be-488f-89b6-f83bf2d48dab", delivery.ListOptions{Limit: 2, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"record-02", "record-03"}, recordIDs(page))
+	assert.Equal(t, "record-03", page.NextCursor)
+
+	recipient := identifier.NewSet(identifier.New(identifier.GenericUsername, "codell"))
+	page, err = store.ListByRecipient(t.Context(), recipient, delivery.ListOptions{Limit: 2, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"record-04"}, recordIDs(page))
+	assert.Empty(t, page.NextCursor)
+
+	_, err = store.ListByEvent(t.Context(), "a1c11a53-c4be-488f-89b6-f83bf2d48dab", delivery.ListOptions{Cursor: "nope"})
+	assert.ErrorIs(t, err, delivery.ErrInvalidCursor)
+}
+
+func recordIDs(page *delivery.Page) []string {
+	ids := make([]string, len(page.Deliveries))
+	for i, record := range page.Deliveries {
+		ids[i] = record.ID
+	}
+	return ids
+}
@@ -0,0 +1,310 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package digest holds back notifications for noisy event types, combining each recipient's notifications into one
+package digest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/seatgeek/mailroom/pkg/notifier/email"
+)
+
+// Item is a notification waiting to be included in a digest
+type Item struct {
+	ID string `json:"id"`
+	// Group identifies the digest the item belongs to; there's one group per event type and recipient
+	Group    string                `json:"group"`
+	Envelope notification.Envelope `json:"envelope"`
+	AddedAt  time.Time             `json:"added_at"`
+	// FlushAt is when the item's group should be flushed; a group is flushed as soon as any of its items are due
+	FlushAt time.Time `json:"flush_at"`
+}
+
+// Notification returns the notification carried by the item
+func (i *Item) Notification() event.Notification {
+	return i.Envelope.Open()
+}
+
+// Store holds items until their group is flushed
+type Store interface {
+	// Add stores an item
+	Add(ctx context.Context, item *Item) error
+	// Due returns the groups with any items due to be flushed at the given time
+	Due(ctx context.Context, at time.Time) ([]string, error)
+	// Take removes and returns all of a group's items, oldest first
+	Take(ctx context.Context, group string) ([]*Item, error)
+}
+
+// Schedule decides when a group of notifications should be flushed
+type Schedule interface {
+	// Next returns when a group which receives a notification at the given time should be flushed
+	Next(t time.Time) time.Time
+}
+
+// ScheduleFunc is a function which implements Schedule
+type ScheduleFunc func(t time.Time) time.Time
+
+func (f ScheduleFunc) Next(t time.Time) time.Time {
+	return f(t)
+}
+
+// Window flushes each group a fixed duration after its first notification
+func Window(d time.Duration) Schedule {
+	return ScheduleFunc(func(t time.Time) time.Time {
+		return t.Add(d)
+	})
+}
+
+// Daily flushes every group at the given time of day, in the given time zone (or UTC if nil)
+func Daily(hour, minute int, location *time.Location) Schedule {
+	if location == nil {
+		location = time.UTC
+	}
+
+	return ScheduleFunc(func(t time.Time) time.Time {
+		t = t.In(location)
+		next := time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, location)
+		if !next.After(t) {
+			next = time.Date(t.Year(), t.Month(), t.Day()+1, hour, minute, 0, 0, location)
+		}
+		return next
+	})
+}
+
+// Summarizer combines a group's items (oldest first) into a single notification
+type Summarizer func(items []*Item) event.Notification
+
+// Digester is a notifier.Notifier which holds back notifications for some event types,
+// pushing one combined notification per recipient and event type to the next notifier on a Schedule
+// Notifications for any other event types are pushed straight through.
+type Digester struct {
+	store              Store
+	notifier           notifier.Notifier
+	transports         []event.TransportKey
+	schedules          map[event.Type]Schedule
+	summarize          Summarizer
+	pollInterval       time.Duration
+	flushAllOnShutdown bool
+}
+
+var _ notifier.Notifier = &Digester{}
+
+// Option configures a Digester
+type Option func(*Digester)
+
+// WithSchedule holds back notifications of the given event type until the schedule says to flush them
+func WithSchedule(eventType event.Type, schedule Schedule) Option {
+	return func(d *Digester) {
+		d.schedules[eventType] = schedule
+	}
+}
+
+// WithSummarizer changes how a group's notifications are combined (default Summarize)
+func WithSummarizer(summarize Summarizer) Option {
+	return func(d *Digester) {
+		d.summarize = summarize
+	}
+}
+
+// WithPollInterval sets how often Run checks for groups which are due (default 1m)
+func WithPollInterval(interval time.Duration) Option {
+	return func(d *Digester) {
+		d.pollInterval = interval
+	}
+}
+
+// WithFlushAllOnShutdown makes Run flush every group when it stops, even if they're not due yet
+// This is a good idea if the Store doesn't survive restarts.
+func WithFlushAllOnShutdown() Option {
+	return func(d *Digester) {
+		d.flushAllOnShutdown = true
+	}
+}
+
+// New creates a Digester which keeps notifications in the given Store before pushing them to the given notifier.Notifier
+// Notifications are rendered for each of the given transports at the time they're held back.
+func New(store Store, n notifier.Notifier, transports []event.TransportKey, opts ...Option) *Digester {
+	d := &Digester{
+		store:        store,
+		notifier:     n,
+		transports:   transports,
+		schedules:    make(map[event.Type]Schedule),
+		summarize:    Summarize,
+		pollInterval: time.Minute,
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// Push holds back the notification if its event type has a Schedule, or pushes it to the next notifier otherwise
+func (d *Digester) Push(ctx context.Context, n event.Notification) error {
+	schedule, ok := d.schedules[n.Context().Type]
+	if !ok || n.Recipient() == nil || n.Recipient().Len() == 0 {
+		return d.notifier.Push(ctx, n)
+	}
+
+	env, err := notification.Seal(n, d.transports)
+	if err != nil {
+		return fmt.Errorf("failed to add notification %s to digest: %w", n.Context().ID, err)
+	}
+
+	now := time.Now()
+	item := &Item{
+		ID:       uuid.New().String(),
+		Group:    groupOf(n),
+		Envelope: env,
+		AddedAt:  now,
+		FlushAt:  schedule.Next(now),
+	}
+
+	if err := d.store.Add(ctx, item); err != nil {
+		return fmt.Errorf("failed to add notification %s to digest: %w", n.Context().ID, err)
+	}
+
+	slog.DebugContext(ctx, "added notification to digest", "id", n.Context().ID, "type", n.Context().Type, "recipient", n.Recipient().String(), "flush_at", item.FlushAt)
+	return nil
+}
+
+// groupOf returns the group for the notification's event type and recipient
+func groupOf(n event.Notification) string {
+	return string(n.Context().Type) + " " + n.Recipient().String()
+}
+
+// Run flushes groups as they become due, blocking until the given context is canceled
+// Before returning, it flushes anything which is due (or every group, with WithFlushAllOnShutdown).
+func (d *Digester) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			shutdownCtx := context.WithoutCancel(ctx)
+			dueBy := time.Now()
+			if d.flushAllOnShutdown {
+				dueBy = endOfTime
+			}
+
+			slog.InfoContext(shutdownCtx, "flushing digests before shutting down", "all", d.flushAllOnShutdown)
+			if err := d.Flush(shutdownCtx, dueBy); err != nil {
+				slog.ErrorContext(shutdownCtx, "failed to flush digests", "error", err)
+			}
+			return
+		case now := <-ticker.C:
+			if err := d.Flush(ctx, now); err != nil {
+				slog.ErrorContext(ctx, "failed to flush digests", "error", err)
+			}
+		}
+	}
+}
+
+// endOfTime is late enough for every group to be due, while still fitting in a database timestamp
+var endOfTime = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// Flush pushes a combined notification for each group which is due at the given time
+// Groups which fail to push are put back in the store, to be tried again on the next flush.
+func (d *Digester) Flush(ctx context.Context, dueBy time.Time) error {
+	groups, err := d.store.Due(ctx, dueBy)
+	if err != nil {
+		return fmt.Errorf("failed to find digests which are due: %w", err)
+	}
+
+	var errs []error
+	for _, group := range groups {
+		items, err := d.store.Take(ctx, group)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to take digest %q: %w", group, err))
+			continue
+		}
+
+		if len(items) == 0 {
+			continue // Another replica got here first
+		}
+
+		n := d.summarize(items)
+		slog.InfoContext(ctx, "flushing digest", "id", n.Context().ID, "type", n.Context().Type, "recipient", n.Recipient().String(), "notifications", len(items))
+		if err = d.notifier.Push(ctx, n); err != nil {
+			errs = append(errs, fmt.Errorf("failed to push digest %q: %w", group, err))
+			errs = append(errs, d.putBack(ctx, items))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// putBack returns items which couldn't be pushed to the store
+func (d *Digester) putBack(ctx context.Context, items []*Item) error {
+	// Don't lose the items just because the flush was canceled
+	ctx = context.WithoutCancel(ctx)
+
+	var errs []error
+	for _, item := range items {
+		if err := d.store.Add(ctx, item); err != nil {
+			slog.ErrorContext(ctx, "failed to put back digest item; it will be lost", "group", item.Group, "id", item.ID, "error", err)
+			errs = append(errs, fmt.Errorf("failed to put back digest item %s: %w", item.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Summarize is the default Summarizer
+// A lone notification is sent as it was; otherwise each transport gets a bulleted list of its messages.
+func Summarize(items []*Item) event.Notification {
+	if len(items) == 1 {
+		return items[0].Notification()
+	}
+
+	notifications := make([]event.Notification, len(items))
+	transports := make(map[event.TransportKey]struct{})
+	for i, item := range items {
+		notifications[i] = item.Notification()
+		for key := range item.Envelope.Messages {
+			transports[key] = struct{}{}
+		}
+	}
+
+	// The latest notification has the most up-to-date recipient
+	latest := notifications[len(notifications)-1]
+	title := fmt.Sprintf("You have %d new notifications", len(items))
+
+	b := notification.NewBuilder(latest.Context().
+		WithID(event.ID("digest-" + items[0].ID)).
+		WithSubject(title)).
+		WithRecipient(latest.Recipient()).
+		WithExtensions(email.Subject.Value(title)).
+		WithDefaultMessage(summarize(title, notifications, ""))
+
+	for _, key := range slices.Sorted(maps.Keys(transports)) {
+		b.WithMessageForTransport(key, summarize(title, notifications, key))
+	}
+
+	return b.Build()
+}
+
+func summarize(title string, notifications []event.Notification, key event.TransportKey) string {
+	var sb strings.Builder
+	sb.WriteString(title + ":\n")
+	for _, n := range notifications {
+		sb.WriteString("\n• " + n.Render(key))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,296 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package digest_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/digest"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/seatgeek/mailroom/pkg/notifier/email"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	commented event.Type = "com.example.comment.created"
+	merged    event.Type = "com.example.merge_request.merged"
+)
+
+var transports = []event.TransportKey{"email", "slack"}
+
+func someNotification(id event.ID, eventType event.Type, username string) event.Notification {
+	return notification.NewBuilder(event.Context{ID: id, Type: eventType, Subject: "MR !1"}).
+		WithRecipientIdentifiers(identifier.New(identifier.GenericUsername, username)).
+		WithDefaultMessage("Comment "+string(id)).
+		WithMessageForTransport("slack", "*Comment* "+string(id)).
+		Build()
+}
+
+// recorder is a notifier.Notifier which records every notification pushed to it
+type recorder struct {
+	pushed []event.Notification
+	err    error
+	mu     sync.Mutex
+}
+
+func (r *recorder) Push(_ context.Context, n event.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pushed = append(r.pushed, n)
+	return r.err
+}
+
+func (r *recorder) notifications() []event.Notification {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]event.Notification(nil), r.pushed...)
+}
+
+var _ notifier.Notifier = &recorder{}
+
+func TestDigester_Push(t *testing.T) {
+	t.Parallel()
+
+	store := digest.NewInMemoryStore()
+	next := &recorder{}
+	d := digest.New(store, next, transports, digest.WithSchedule(commented, digest.Window(time.Hour)))
+
+	// Other event types go straight through
+	require.NoError(t, d.Push(t.Context(), someNotification("1", merged, "codell")))
+	assert.Len(t, next.notifications(), 1)
+	assert.Equal(t, 0, store.Len())
+
+	// Notifications without a recipient can't be grouped, so they go straight through too
+	require.NoError(t, d.Push(t.Context(), notification.NewBuilder(event.Context{ID: "2", Type: commented}).Build()))
+	assert.Len(t, next.notifications(), 2)
+
+	// Digested event types are held back
+	require.NoError(t, d.Push(t.Context(), someNotification("3", commented, "codell")))
+	require.NoError(t, d.Push(t.Context(), someNotification("4", commented, "rufus")))
+	assert.Len(t, next.notifications(), 2)
+	assert.Equal(t, 2, store.Len())
+
+	// Nothing is due until the window has passed
+	require.NoError(t, d.Flush(t.Context(), time.Now()))
+	assert.Len(t, next.notifications(), 2)
+
+	require.NoError(t, d.Flush(t.Context(), time.Now().Add(time.Hour)))
+	assert.Len(t, next.notifications(), 4)
+	assert.Equal(t, 0, store.Len())
+}
+
+func TestDigester_Flush(t *testing.T) {
+	t.Parallel()
+
+	store := digest.NewInMemoryStore()
+	next := &recorder{}
+	d := digest.New(store, next, transports, digest.WithSchedule(commented, digest.Window(time.Minute)))
+
+	for _, id := range []event.ID{"1", "2", "3"} {
+		require.NoError(t, d.Push(t.Context(), someNotification(id, commented, "codell")))
+	}
+	require.NoError(t, d.Push(t.Context(), someNotification("4", commented, "rufus")))
+
+	require.NoError(t, d.Flush(t.Context(), time.Now().Add(time.Minute)))
+
+	pushed := next.notifications()
+	require.Len(t, pushed, 2)
+
+	// Each recipient gets one notification
+	combined, single := pushed[0], pushed[1]
+	if combined.Recipient().String() != identifier.NewSet(identifier.New(identifier.GenericUsername, "codell")).String() {
+		combined, single = single, combined
+	}
+
+	assert.Equal(t, commented, combined.Context().Type)
+	assert.Equal(t, "You have 3 new notifications", combined.Context().Subject)
+	assert.Equal(t, "You have 3 new notifications:\n\n• Comment 1\n• Comment 2\n• Comment 3", combined.Render("email"))
+	assert.Equal(t, "You have 3 new notifications:\n\n• *Comment* 1\n• *Comment* 2\n• *Comment* 3", combined.Render("slack"))
+	subject, _ := email.Subject.Of(combined)
+	assert.Equal(t, "You have 3 new notifications", subject)
+
+	// A lone notification is sent as it was
+	assert.Equal(t, event.ID("4"), single.Context().ID)
+	assert.Equal(t, "Comment 4", single.Render("email"))
+}
+
+func TestDigester_Flush_Errors(t *testing.T) {
+	t.Parallel()
+
+	store := digest.NewInMemoryStore()
+	next := &recorder{err: errors.New("some error")}
+	d := digest.New(store, next, transports, digest.WithSchedule(commented, digest.Window(0)))
+
+	require.NoError(t, d.Push(t.Context(), someNotification("1", commented, "codell")))
+	require.NoError(t, d.Push(t.Context(), someNotification("2", commented, "rufus")))
+
+	err := d.Flush(t.Context(), time.Now())
+	assert.ErrorContains(t, err, "failed to push digest")
+	assert.Len(t, next.notifications(), 2)
+
+	// The items are kept for the next flush
+	assert.Equal(t, 2, store.Len())
+
+	next.err = nil
+	require.NoError(t, d.Flush(t.Context(), time.Now()))
+	assert.Len(t, next.notifications(), 4)
+	assert.Equal(t, 0, store.Len())
+}
+
+func TestWithSummarizer(t *testing.T) {
+	t.Parallel()
+
+	next := &recorder{}
+	d := digest.New(digest.NewInMemoryStore(), next, transports,
+		digest.WithSchedule(commented, digest.Window(0)),
+		digest.WithSummarizer(func(items []*digest.Item) event.Notification {
+			return notification.NewBuilder(items[0].Envelope.Open().Context()).
+				WithRecipient(items[0].Envelope.Open().Recipient()).
+				WithDefaultMessage("Lots of comments").
+				Build()
+		}),
+	)
+
+	require.NoError(t, d.Push(t.Context(), someNotification("1", commented, "codell")))
+	require.NoError(t, d.Push(t.Context(), someNotification("2", commented, "codell")))
+	require.NoError(t, d.Flush(t.Context(), time.Now()))
+
+	require.Len(t, next.notifications(), 1)
+	assert.Equal(t, "Lots of comments", next.notifications()[0].Render("email"))
+}
+
+func TestDigester_Run(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		opts       []digest.Option
+		wantPushed int
+	}{
+		{
+			name:       "flushes due digests on shutdown",
+			wantPushed: 1,
+		},
+		{
+			name:       "flushes all digests on shutdown",
+			opts:       []digest.Option{digest.WithFlushAllOnShutdown()},
+			wantPushed: 2,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			next := &recorder{}
+			d := digest.New(digest.NewInMemoryStore(), next, transports, append([]digest.Option{
+				digest.WithSchedule(commented, digest.Window(20*time.Millisecond)),
+				digest.WithSchedule(merged, digest.Window(time.Hour)),
+				digest.WithPollInterval(time.Hour),
+			}, tc.opts...)...)
+
+			require.NoError(t, d.Push(t.Context(), someNotification("1", commented, "codell")))
+			require.NoError(t, d.Push(t.Context(), someNotification("2", merged, "codell")))
+
+			ctx, cancel := context.WithCancel(t.Context())
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				d.Run(ctx)
+			}()
+
+			time.Sleep(30 * time.Millisecond)
+			cancel()
+			<-done
+
+			assert.Len(t, next.notifications(), tc.wantPushed)
+		})
+	}
+}
+
+func TestDigester_Run_Polls(t *testing.T) {
+	t.Parallel()
+
+	next := &recorder{}
+	d := digest.New(digest.NewInMemoryStore(), next, transports,
+		digest.WithSchedule(commented, digest.Window(0)),
+		digest.WithPollInterval(10*time.Millisecond),
+	)
+	require.NoError(t, d.Push(t.Context(), someNotification("1", commented, "codell")))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	go d.Run(ctx)
+
+	assert.Eventually(t, func() bool {
+		return len(next.notifications()) == 1
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestDaily(t *testing.T) {
+	t.Parallel()
+
+	toronto, err := time.LoadLocation("America/Toronto")
+	require.NoError(t, err)
+
+	schedule := digest.Daily(9, 30, toronto)
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want time.Time
+	}{
+		{
+			name: "before the time of day",
+			at:   time.Date(2025, 3, 14, 8, 0, 0, 0, toronto),
+			want: time.Date(2025, 3, 14, 9, 30, 0, 0, toronto),
+		},
+		{
+			name: "after the time of day",
+			at:   time.Date(2025, 3, 14, 12, 0, 0, 0, toronto),
+			want: time.Date(2025, 3, 15, 9, 30, 0, 0, toronto),
+		},
+		{
+			name: "at the time of day",
+			at:   time.Date(2025, 3, 14, 9, 30, 0, 0, toronto),
+			want: time.Date(2025, 3, 15, 9, 30, 0, 0, toronto),
+		},
+		{
+			name: "in another time zone",
+			at:   time.Date(2025, 3, 14, 15, 0, 0, 0, time.UTC),
+			want: time.Date(2025, 3, 15, 9, 30, 0, 0, toronto),
+		},
+		{
+			name: "end of the month",
+			at:   time.Date(2025, 3, 31, 23, 0, 0, 0, toronto),
+			want: time.Date(2025, 4, 1, 9, 30, 0, 0, toronto),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.True(t, tc.want.Equal(schedule.Next(tc.at)), "got %s", schedule.Next(tc.at))
+		})
+	}
+}
+
+func TestWindow(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2025, 3, 14, 8, 0, 0, 0, time.UTC)
+	assert.Equal(t, at.Add(15*time.Minute), digest.Window(15*time.Minute).Next(at))
+}
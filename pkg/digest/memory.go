@@ -0,0 +1,72 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package digest
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// InMemoryStore is a simple in-memory implementation of the Store interface
+// Items do not survive restarts, so use it with WithFlushAllOnShutdown, or for testing.
+type InMemoryStore struct {
+	groups map[string][]*Item
+	mu     sync.Mutex
+}
+
+var _ Store = &InMemoryStore{}
+
+// NewInMemoryStore creates a new in-memory store
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{groups: make(map[string][]*Item)}
+}
+
+func (s *InMemoryStore) Add(_ context.Context, item *Item) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.groups[item.Group] = append(s.groups[item.Group], item)
+	return nil
+}
+
+func (s *InMemoryStore) Due(_ context.Context, at time.Time) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []string
+	for group, items := range s.groups {
+		if slices.ContainsFunc(items, func(item *Item) bool { return !item.FlushAt.After(at) }) {
+			due = append(due, group)
+		}
+	}
+
+	slices.Sort(due)
+	return due, nil
+}
+
+func (s *InMemoryStore) Take(_ context.Context, group string) ([]*Item, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	items := s.groups[group]
+	delete(s.groups, group)
+
+	return items, nil
+}
+
+// Len returns the number of items in the store
+func (s *InMemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var n int
+	for _, items := range s.groups {
+		n += len(items)
+	}
+
+	return n
+}
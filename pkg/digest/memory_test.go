@@ -0,0 +1,47 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package digest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	store := digest.NewInMemoryStore()
+	now := time.Now()
+
+	require.NoError(t, store.Add(t.Context(), &digest.Item{ID: "1", Group: "a", FlushAt: now.Add(time.Hour)}))
+	require.NoError(t, store.Add(t.Context(), &digest.Item{ID: "2", Group: "b", FlushAt: now}))
+	require.NoError(t, store.Add(t.Context(), &digest.Item{ID: "3", Group: "a", FlushAt: now.Add(2 * time.Hour)}))
+	assert.Equal(t, 3, store.Len())
+
+	// Groups are due as soon as any of their items are
+	due, err := store.Due(t.Context(), now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, due)
+
+	due, err = store.Due(t.Context(), now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, due)
+
+	// Taking a group removes all of its items
+	items, err := store.Take(t.Context(), "a")
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "1", items[0].ID)
+	assert.Equal(t, "3", items[1].ID)
+	assert.Equal(t, 1, store.Len())
+
+	items, err = store.Take(t.Context(), "a")
+	require.NoError(t, err)
+	assert.Empty(t, items)
+}
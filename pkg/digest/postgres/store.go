@@ -0,0 +1,109 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package postgres provides a postgresql-backed implementation of the digest.Store interface
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/digest"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/validation"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ItemModel is the gorm model for a notification waiting to be included in a digest
+type ItemModel struct {
+	ID       string                `gorm:"primarykey"`
+	Group    string                `gorm:"column:group_key;index"`
+	Envelope notification.Envelope `gorm:"serializer:json"`
+	AddedAt  time.Time
+	FlushAt  time.Time `gorm:"index"`
+}
+
+func (m *ItemModel) TableName() string {
+	return "digest_items"
+}
+
+// ToItem converts an ItemModel to a digest.Item
+func (m *ItemModel) ToItem() *digest.Item {
+	return &digest.Item{
+		ID:       m.ID,
+		Group:    m.Group,
+		Envelope: m.Envelope,
+		AddedAt:  m.AddedAt,
+		FlushAt:  m.FlushAt,
+	}
+}
+
+type Store struct {
+	db *gorm.DB
+}
+
+var (
+	_ digest.Store         = &Store{}
+	_ validation.Validator = &Store{}
+)
+
+// NewPostgresStore creates a new postgres-backed digest store
+func NewPostgresStore(db *gorm.DB) *Store {
+	return &Store{db: db}
+}
+
+// Add implements digest.Store.
+func (s *Store) Add(ctx context.Context, item *digest.Item) error {
+	return s.db.WithContext(ctx).Create(&ItemModel{
+		ID:       item.ID,
+		Group:    item.Group,
+		Envelope: item.Envelope,
+		AddedAt:  item.AddedAt,
+		FlushAt:  item.FlushAt,
+	}).Error
+}
+
+// Due implements digest.Store.
+func (s *Store) Due(ctx context.Context, at time.Time) ([]string, error) {
+	var groups []string
+	err := s.db.WithContext(ctx).Model(&ItemModel{}).
+		Distinct("group_key").
+		Where("flush_at <= ?", at).
+		Order("group_key").
+		Pluck("group_key", &groups).Error
+
+	return groups, err
+}
+
+// Take implements digest.Store.
+// Items are deleted and returned in a single statement, so that only one replica can flush each group.
+func (s *Store) Take(ctx context.Context, group string) ([]*digest.Item, error) {
+	var models []ItemModel
+	if err := s.db.WithContext(ctx).Clauses(clause.Returning{}).Where("group_key = ?", group).Delete(&models).Error; err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(models, func(a, b ItemModel) int {
+		return a.AddedAt.Compare(b.AddedAt)
+	})
+
+	items := make([]*digest.Item, len(models))
+	for i := range models {
+		items[i] = models[i].ToItem()
+	}
+
+	return items, nil
+}
+
+// Validate checks that the digest table exists
+func (s *Store) Validate(ctx context.Context) error {
+	if !s.db.WithContext(ctx).Migrator().HasTable(&ItemModel{}) {
+		return fmt.Errorf("table %q does not exist", (&ItemModel{}).TableName())
+	}
+
+	return nil
+}
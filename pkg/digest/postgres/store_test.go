@@ -0,0 +1,121 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/digest"
+	"github.com/seatgeek/mailroom/pkg/digest/postgres"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	pgtc "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	pg "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestPostgresStore(t *testing.T) {
+	t.Parallel()
+
+	store := createStore(t)
+
+	assert.NoError(t, store.Validate(t.Context()))
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	first := someItem(t, "1", "a", now, now.Add(time.Hour))
+	second := someItem(t, "2", "b", now.Add(time.Millisecond), now)
+	third := someItem(t, "3", "a", now.Add(2*time.Millisecond), now.Add(2*time.Hour))
+	for _, item := range []*digest.Item{third, second, first} {
+		assert.NoError(t, store.Add(t.Context(), item))
+	}
+
+	// Groups are due as soon as any of their items are
+	due, err := store.Due(t.Context(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b"}, due)
+
+	due, err = store.Due(t.Context(), now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, due)
+
+	// Taking a group removes all of its items, oldest first
+	items, err := store.Take(t.Context(), "a")
+	assert.NoError(t, err)
+	if assert.Len(t, items, 2) {
+		assert.Equal(t, first.ID, items[0].ID)
+		assert.Equal(t, first.Envelope, items[0].Envelope)
+		assert.True(t, first.FlushAt.Equal(items[0].FlushAt))
+		assert.Equal(t, third.ID, items[1].ID)
+	}
+
+	items, err = store.Take(t.Context(), "a")
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+
+	due, err = store.Due(t.Context(), now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b"}, due)
+}
+
+func someItem(t *testing.T, id string, group string, addedAt time.Time, flushAt time.Time) *digest.Item {
+	t.Helper()
+
+	env, err := notification.Seal(
+		notification.NewBuilder(event.Context{
+			ID:     event.ID(id),
+			Source: event.MustSource("https://example.com"),
+			Type:   "com.example.test",
+			Time:   time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		}).
+			WithRecipientIdentifiers(identifier.New(identifier.GenericUsername, "codell")).
+			WithDefaultMessage("hello").
+			Build(),
+		[]event.TransportKey{"email"},
+	)
+	require.NoError(t, err)
+
+	return &digest.Item{
+		ID:       id,
+		Group:    group,
+		Envelope: env,
+		AddedAt:  addedAt,
+		FlushAt:  flushAt,
+	}
+}
+
+func createStore(t *testing.T) *postgres.Store {
+	t.Helper()
+
+	ctx := context.Background()
+
+	container, err := pgtc.Run(ctx, "postgres:16.2",
+		pgtc.WithInitScripts("../../../test/initdb/init.sql"),
+		pgtc.WithDatabase("mailroom"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second)),
+	)
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, container.Terminate(ctx))
+	})
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable", "application_name=test")
+	assert.NoError(t, err)
+
+	db, err := gorm.Open(pg.Open(dsn), &gorm.Config{})
+	assert.NoError(t, err)
+
+	return postgres.NewPostgresStore(db)
+}
@@ -31,6 +31,15 @@ type Parser interface {
 	EventTypes() []TypeDescriptor
 }
 
+// BatchParser is a Parser which may receive several events in a single HTTP request.
+// When a parser implements this interface, ParseBatch is used instead of Parse and each event is processed in turn.
+type BatchParser interface {
+	Parser
+	// ParseBatch handles incoming webhooks, verifying them and returning every parsed Event (or an error).
+	// Returning an empty slice indicates that none of the events are interesting.
+	ParseBatch(req *http.Request) ([]*Event, error)
+}
+
 // Context contains the metadata for an event
 // The fields are based on the CloudEvent spec: https://github.com/cloudevents/spec/blob/main/cloudevents/spec.md
 type Context struct {
@@ -0,0 +1,237 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package i18n provides message catalogs for translating notifications into each recipient's language
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"maps"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/validation"
+)
+
+// TimeLayoutKey is the catalog key for the Go time layout used to format times in each locale
+const TimeLayoutKey = "time.layout"
+
+// DefaultTimeLayout is used to format times when the catalog doesn't say otherwise
+const DefaultTimeLayout = "Jan 2, 2006 3:04 PM MST"
+
+// Localizable is an optional interface that can be implemented by notifications which can be rendered in other
+// languages and time zones, such as those built from templates
+type Localizable interface {
+	// Localize renders the notification for the given locale (like "fr-CA") and time zone
+	// An empty locale means the default locale, and a nil location means UTC.
+	Localize(locale string, location *time.Location)
+}
+
+// Catalog contains the messages for each locale
+// Messages are fmt format strings, so they can use explicit argument indexes (like %[2]s) to reorder arguments.
+type Catalog struct {
+	defaultLocale string
+	strict        bool
+	messages      map[string]map[string]string // locale => key => message
+	err           error
+}
+
+var _ validation.Validator = &Catalog{}
+
+// Option configures a Catalog
+type Option func(*Catalog)
+
+// WithStrict makes validation fail when translations are missing, rather than just logging them
+func WithStrict() Option {
+	return func(c *Catalog) {
+		c.strict = true
+	}
+}
+
+// New loads a catalog from fsys (such as an embed.FS), with one <locale>.json file per locale containing
+// an object of keys to messages, like {"pipeline.failed": "Pipeline #%d failed"}
+// Any problems loading it are reported by Validate.
+func New(fsys fs.FS, defaultLocale string, opts ...Option) *Catalog {
+	c := newCatalog(defaultLocale, opts)
+
+	files, err := fs.Glob(fsys, "*.json")
+	errs := []error{err}
+	for _, file := range files {
+		if err := c.load(fsys, file); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", file, err))
+		}
+	}
+	c.err = errors.Join(errs...)
+
+	return c
+}
+
+// FromDir loads a catalog from the given directory (see New)
+func FromDir(dir string, defaultLocale string, opts ...Option) *Catalog {
+	return New(os.DirFS(dir), defaultLocale, opts...)
+}
+
+// FromMap creates a catalog from messages by locale and key, which is handy for tests
+func FromMap(messages map[string]map[string]string, defaultLocale string, opts ...Option) *Catalog {
+	c := newCatalog(defaultLocale, opts)
+	for locale, msgs := range messages {
+		c.messages[normalize(locale)] = maps.Clone(msgs)
+	}
+
+	return c
+}
+
+func newCatalog(defaultLocale string, opts []Option) *Catalog {
+	c := &Catalog{
+		defaultLocale: normalize(defaultLocale),
+		messages:      make(map[string]map[string]string),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+func (c *Catalog) load(fsys fs.FS, file string) error {
+	data, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return err
+	}
+
+	var messages map[string]string
+	if err = json.Unmarshal(data, &messages); err != nil {
+		return err
+	}
+
+	c.messages[normalize(strings.TrimSuffix(path.Base(file), ".json"))] = messages
+
+	return nil
+}
+
+// Locales returns the catalog's locales, in order
+func (c *Catalog) Locales() []string {
+	return slices.Sorted(maps.Keys(c.messages))
+}
+
+// Translate returns the message with the given key for the locale, formatted with the given arguments
+// If the locale (like "fr-CA") doesn't have the message, its language ("fr") is tried, then the default locale.
+// If none of them have the message, the key itself is returned.
+func (c *Catalog) Translate(locale string, key string, args ...any) string {
+	message, ok := c.lookup(locale, key)
+	if !ok {
+		slog.Warn("missing translation", "locale", locale, "key", key)
+		return key
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+
+	return fmt.Sprintf(message, args...)
+}
+
+// TimeLayout returns the Go time layout for the locale
+func (c *Catalog) TimeLayout(locale string) string {
+	if layout, ok := c.lookup(locale, TimeLayoutKey); ok {
+		return layout
+	}
+
+	return DefaultTimeLayout
+}
+
+func (c *Catalog) lookup(locale string, key string) (string, bool) {
+	for _, candidate := range c.candidates(locale) {
+		if message, ok := c.messages[candidate][key]; ok {
+			return message, true
+		}
+	}
+
+	return "", false
+}
+
+// candidates returns the locales to try for the given locale, in order
+func (c *Catalog) candidates(locale string) []string {
+	locale = normalize(locale)
+
+	var candidates []string
+	if locale != "" {
+		candidates = append(candidates, locale)
+		if language, _, ok := strings.Cut(locale, "-"); ok {
+			candidates = append(candidates, language)
+		}
+	}
+
+	return append(candidates, c.defaultLocale)
+}
+
+// Missing returns the keys which are in the default locale but missing from each other locale
+// Keys which fall back to the locale's language (like "fr" for "fr-CA") aren't considered missing.
+func (c *Catalog) Missing() map[string][]string {
+	missing := make(map[string][]string)
+
+	for locale := range c.messages {
+		if locale == c.defaultLocale {
+			continue
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(c.messages[c.defaultLocale])) {
+			if _, ok := c.messages[locale][key]; !ok && !c.inLanguage(locale, key) {
+				missing[locale] = append(missing[locale], key)
+			}
+		}
+	}
+
+	return missing
+}
+
+// inLanguage returns whether the key is translated in the locale's language
+func (c *Catalog) inLanguage(locale string, key string) bool {
+	language, _, ok := strings.Cut(locale, "-")
+	if !ok {
+		return false
+	}
+
+	_, ok = c.messages[language][key]
+	return ok
+}
+
+// Validate returns an error if the catalog failed to load or has no messages for the default locale
+// Missing translations are logged, or returned as an error with WithStrict.
+func (c *Catalog) Validate(ctx context.Context) error {
+	if c.err != nil {
+		return fmt.Errorf("failed to load message catalog: %w", c.err)
+	}
+
+	if _, ok := c.messages[c.defaultLocale]; !ok {
+		return fmt.Errorf("message catalog has no messages for the default locale %q", c.defaultLocale)
+	}
+
+	var errs []error
+	missing := c.Missing()
+	for _, locale := range slices.Sorted(maps.Keys(missing)) {
+		if c.strict {
+			errs = append(errs, fmt.Errorf("locale %q is missing translations for %s", locale, strings.Join(missing[locale], ", ")))
+			continue
+		}
+
+		slog.WarnContext(ctx, "missing translations will fall back to the default locale", "locale", locale, "default_locale", c.defaultLocale, "keys", missing[locale])
+	}
+
+	return errors.Join(errs...)
+}
+
+// normalize makes locales like "fr_CA" and "FR-ca" consistent
+func normalize(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
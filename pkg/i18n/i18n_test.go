@@ -0,0 +1,160 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package i18n_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/seatgeek/mailroom/pkg/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromDir(t *testing.T) {
+	t.Parallel()
+
+	catalog := i18n.FromDir("testdata", "en")
+	require.NoError(t, catalog.Validate(t.Context()))
+
+	assert.Equal(t, []string{"en", "es", "fr", "fr-ca"}, catalog.Locales())
+}
+
+func TestCatalog_Translate(t *testing.T) {
+	t.Parallel()
+
+	catalog := i18n.FromDir("testdata", "en")
+
+	tests := []struct {
+		name   string
+		locale string
+		key    string
+		want   string
+	}{
+		{
+			name:   "default locale",
+			locale: "en",
+			key:    "build.failed",
+			want:   "Your build of main failed",
+		},
+		{
+			name:   "no locale",
+			locale: "",
+			key:    "build.failed",
+			want:   "Your build of main failed",
+		},
+		{
+			name:   "exact locale",
+			locale: "fr-CA",
+			key:    "build.failed",
+			want:   "Ton build de main a planté",
+		},
+		{
+			name:   "locale with underscore",
+			locale: "fr_CA",
+			key:    "build.failed",
+			want:   "Ton build de main a planté",
+		},
+		{
+			name:   "falls back to language",
+			locale: "fr-CA",
+			key:    "build.passed",
+			want:   "Votre build de main a réussi",
+		},
+		{
+			name:   "falls back to default locale",
+			locale: "es",
+			key:    "build.passed",
+			want:   "Your build of main passed",
+		},
+		{
+			name:   "unknown locale",
+			locale: "de-DE",
+			key:    "build.failed",
+			want:   "Your build of main failed",
+		},
+		{
+			name:   "unknown key",
+			locale: "fr",
+			key:    "build.cancelled",
+			want:   "build.cancelled",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, catalog.Translate(tc.locale, tc.key, "main"))
+		})
+	}
+}
+
+func TestCatalog_TimeLayout(t *testing.T) {
+	t.Parallel()
+
+	catalog := i18n.FromDir("testdata", "en")
+
+	assert.Equal(t, "02/01/2006 15:04 MST", catalog.TimeLayout("fr-CA"))
+	assert.Equal(t, "Jan 2, 2006 3:04 PM MST", catalog.TimeLayout("es"))
+	assert.Equal(t, i18n.DefaultTimeLayout, i18n.FromMap(nil, "en").TimeLayout("en"))
+}
+
+func TestCatalog_Missing(t *testing.T) {
+	t.Parallel()
+
+	catalog := i18n.FromDir("testdata", "en")
+
+	// fr-CA falls back to fr, so nothing is missing
+	assert.Equal(t, map[string][]string{
+		"es": {"build.passed", "time.layout"},
+	}, catalog.Missing())
+}
+
+func TestCatalog_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		catalog *i18n.Catalog
+		wantErr string
+	}{
+		{
+			name:    "missing translations are only logged",
+			catalog: i18n.FromDir("testdata", "en"),
+		},
+		{
+			name:    "missing translations with strict",
+			catalog: i18n.FromDir("testdata", "en", i18n.WithStrict()),
+			wantErr: `locale "es" is missing translations for build.passed, time.layout`,
+		},
+		{
+			name:    "no default locale",
+			catalog: i18n.FromDir("testdata", "de"),
+			wantErr: `message catalog has no messages for the default locale "de"`,
+		},
+		{
+			name: "invalid JSON",
+			catalog: i18n.New(fstest.MapFS{
+				"en.json": {Data: []byte(`{"build.failed": `)},
+			}, "en"),
+			wantErr: "failed to load message catalog: en.json: unexpected end of JSON input",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.catalog.Validate(t.Context())
+
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -28,6 +28,9 @@ var (
 
 	// GenericID is any ID not associated with a specific namespace or system.
 	GenericID = NamespaceAndKind(KindID)
+
+	// GenericPhone is any phone number not associated with a specific namespace or system.
+	GenericPhone = NamespaceAndKind(KindPhone)
 )
 
 // Split returns the namespace and kind parts of the NamespaceAndKind.
@@ -67,6 +70,10 @@ const (
 	KindEmail    Kind = "email"
 	KindUsername Kind = "username"
 	KindID       Kind = "id"
+	// KindPhone values should be E.164 phone numbers (see NormalizePhone)
+	KindPhone Kind = "phone"
+	// KindDeviceToken values are push notification tokens; users often have several (see NewMulti)
+	KindDeviceToken Kind = "device_token"
 )
 
 // An Identifier is a unique reference to some user or group.
@@ -0,0 +1,50 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package identifier
+
+import (
+	"slices"
+	"strings"
+)
+
+// ValueSeparator separates the values of a multi-valued Identifier
+const ValueSeparator = ","
+
+// NewMulti creates an Identifier holding several values, like all the device tokens of a single user
+// Empty and duplicate values are dropped. The values themselves must not contain ValueSeparator.
+func NewMulti(namespaceAndKind NamespaceAndKind, values ...string) Identifier {
+	var kept []string
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v != "" && !slices.Contains(kept, v) {
+			kept = append(kept, v)
+		}
+	}
+
+	return Identifier{
+		NamespaceAndKind: namespaceAndKind,
+		Value:            strings.Join(kept, ValueSeparator),
+	}
+}
+
+// Values returns the individual values of a multi-valued Identifier
+// A regular Identifier has a single value (or none, if its Value is empty).
+func (i Identifier) Values() []string {
+	var values []string
+	for v := range strings.SplitSeq(i.Value, ValueSeparator) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
+
+// Without returns a copy of a multi-valued Identifier with the given value removed
+func (i Identifier) Without(value string) Identifier {
+	return NewMulti(i.NamespaceAndKind, slices.DeleteFunc(i.Values(), func(v string) bool {
+		return v == value
+	})...)
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package identifier_test
+
+import (
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/stretchr/testify/assert"
+)
+
+const deviceToken = identifier.NamespaceAndKind("apple.com/device_token")
+
+func TestNewMulti(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		values []string
+		want   string
+	}{
+		{name: "none", values: nil, want: ""},
+		{name: "one", values: []string{"abc"}, want: "abc"},
+		{name: "several", values: []string{"abc", "def"}, want: "abc,def"},
+		{name: "drops empty and duplicate values", values: []string{"abc", "", " ", "def", "abc"}, want: "abc,def"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, identifier.New(deviceToken, tc.want), identifier.NewMulti(deviceToken, tc.values...))
+		})
+	}
+}
+
+func TestIdentifier_Values(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, identifier.New(deviceToken, "").Values())
+	assert.Equal(t, []string{"abc"}, identifier.New(deviceToken, "abc").Values())
+	assert.Equal(t, []string{"abc", "def"}, identifier.New(deviceToken, "abc, def,").Values())
+}
+
+func TestIdentifier_Without(t *testing.T) {
+	t.Parallel()
+
+	id := identifier.NewMulti(deviceToken, "abc", "def", "ghi")
+
+	assert.Equal(t, identifier.New(deviceToken, "abc,ghi"), id.Without("def"))
+	assert.Equal(t, id, id.Without("xyz"))
+	assert.Equal(t, identifier.New(deviceToken, ""), identifier.New(deviceToken, "abc").Without("abc"))
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package identifier
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidPhone is returned when a phone number can't be normalized to E.164
+var ErrInvalidPhone = errors.New("invalid E.164 phone number")
+
+const (
+	// minPhoneDigits is the shortest number we'll accept: a country code plus a short subscriber number
+	minPhoneDigits = 7
+	// maxPhoneDigits is the longest number allowed by E.164
+	maxPhoneDigits = 15
+)
+
+// NormalizePhone converts an international phone number to E.164 format, like "+14155552671"
+// Common formatting characters (spaces, dashes, dots, parentheses) are removed, a leading "00" international
+// prefix is treated like "+", and a national trunk prefix written as "(0)" is dropped. Numbers without a country code
+// are rejected since we can't know which country they belong to.
+func NormalizePhone(raw string) (string, error) {
+	number := strings.TrimSpace(raw)
+	number = strings.Replace(number, "(0)", "", 1)
+
+	switch {
+	case strings.HasPrefix(number, "+"):
+		number = number[1:]
+	case strings.HasPrefix(number, "00"):
+		number = number[2:]
+	default:
+		return "", fmt.Errorf("%w: %q must start with + and a country code", ErrInvalidPhone, raw)
+	}
+
+	var digits strings.Builder
+	for _, r := range number {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == ' ', r == '-', r == '.', r == '(', r == ')':
+			// formatting
+		default:
+			return "", fmt.Errorf("%w: %q contains %q", ErrInvalidPhone, raw, r)
+		}
+	}
+
+	normalized := digits.String()
+	if len(normalized) < minPhoneDigits || len(normalized) > maxPhoneDigits {
+		return "", fmt.Errorf("%w: %q must have between %d and %d digits", ErrInvalidPhone, raw, minPhoneDigits, maxPhoneDigits)
+	}
+
+	// Country codes never start with 0
+	if normalized[0] == '0' {
+		return "", fmt.Errorf("%w: %q has an invalid country code", ErrInvalidPhone, raw)
+	}
+
+	return "+" + normalized, nil
+}
+
+// NewPhone creates a new phone Identifier in the given namespace, normalizing the number to E.164
+// Use an empty namespace for a GenericPhone.
+func NewPhone(namespace string, raw string) (Identifier, error) {
+	number, err := NormalizePhone(raw)
+	if err != nil {
+		return Identifier{}, err
+	}
+
+	return New(NewNamespaceAndKind(namespace, KindPhone), number), nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package identifier_test
+
+import (
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{raw: "+14155552671", want: "+14155552671"},
+		{raw: "  +1 (415) 555-2671 ", want: "+14155552671"},
+		{raw: "+1.415.555.2671", want: "+14155552671"},
+		{raw: "0044 20 7946 0958", want: "+442079460958"},
+		{raw: "+44 (0)20 7946 0958", want: "+442079460958"},
+		{raw: "4155552671", wantErr: true},
+		{raw: "(415) 555-2671", wantErr: true},
+		{raw: "+1 415 CALL NOW", wantErr: true},
+		{raw: "+12345", wantErr: true},
+		{raw: "+1234567890123456", wantErr: true},
+		{raw: "+0123456789", wantErr: true},
+		{raw: "", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.raw, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := identifier.NormalizePhone(tc.raw)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, identifier.ErrInvalidPhone)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestNewPhone(t *testing.T) {
+	t.Parallel()
+
+	id, err := identifier.NewPhone("", "+1 (415) 555-2671")
+	assert.NoError(t, err)
+	assert.Equal(t, identifier.New(identifier.GenericPhone, "+14155552671"), id)
+
+	id, err = identifier.NewPhone("pagerduty.com", "+44 20 7946 0958")
+	assert.NoError(t, err)
+	assert.Equal(t, identifier.New("pagerduty.com/phone", "+442079460958"), id)
+	assert.Equal(t, identifier.KindPhone, id.Kind())
+
+	_, err = identifier.NewPhone("", "555-2671")
+	assert.ErrorIs(t, err, identifier.ErrInvalidPhone)
+}
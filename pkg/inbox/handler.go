@@ -0,0 +1,144 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package inbox
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/seatgeek/mailroom/internal/httputil"
+	"github.com/seatgeek/mailroom/pkg/event"
+)
+
+// Handler exposes an HTTP API for reading and managing users' inboxes
+type Handler struct {
+	store Store
+}
+
+// NewHandler creates a new Handler
+func NewHandler(store Store) *Handler {
+	return &Handler{store: store}
+}
+
+type unreadCountBody struct {
+	Unread int `json:"unread"`
+}
+
+// List returns a page of the user's inbox
+// It supports the "unread", "archived", "type", "limit" and "cursor" query parameters (see Filter).
+func (h *Handler) List(writer http.ResponseWriter, request *http.Request) {
+	key := mux.Vars(request)["key"]
+
+	filter, err := parseFilter(request)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.store.List(request.Context(), key, filter)
+	if err != nil {
+		if errors.Is(err, ErrInvalidCursor) {
+			http.Error(writer, "invalid cursor", http.StatusBadRequest)
+			return
+		}
+
+		slog.ErrorContext(request.Context(), "failed to list inbox", "key", key, "error", err)
+		http.Error(writer, "failed to list inbox", http.StatusInternalServerError)
+		return
+	}
+
+	httputil.WriteJSON(request.Context(), writer, page)
+}
+
+// UnreadCount returns the number of unread items in the user's inbox
+func (h *Handler) UnreadCount(writer http.ResponseWriter, request *http.Request) {
+	key := mux.Vars(request)["key"]
+
+	count, err := h.store.UnreadCount(request.Context(), key)
+	if err != nil {
+		slog.ErrorContext(request.Context(), "failed to count unread inbox items", "key", key, "error", err)
+		http.Error(writer, "failed to count unread items", http.StatusInternalServerError)
+		return
+	}
+
+	httputil.WriteJSON(request.Context(), writer, unreadCountBody{Unread: count})
+}
+
+// MarkRead marks a single item as read
+func (h *Handler) MarkRead(writer http.ResponseWriter, request *http.Request) {
+	h.updateItem(writer, request, "mark inbox item as read", h.store.MarkRead)
+}
+
+// MarkAllRead marks every item in the user's inbox as read
+func (h *Handler) MarkAllRead(writer http.ResponseWriter, request *http.Request) {
+	key := mux.Vars(request)["key"]
+
+	if err := h.store.MarkAllRead(request.Context(), key); err != nil {
+		slog.ErrorContext(request.Context(), "failed to mark inbox as read", "key", key, "error", err)
+		http.Error(writer, "failed to mark inbox as read", http.StatusInternalServerError)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+// Archive removes a single item from the user's inbox
+func (h *Handler) Archive(writer http.ResponseWriter, request *http.Request) {
+	h.updateItem(writer, request, "archive inbox item", h.store.Archive)
+}
+
+func (h *Handler) updateItem(writer http.ResponseWriter, request *http.Request, action string, update func(ctx context.Context, userKey string, id string) error) {
+	key := mux.Vars(request)["key"]
+	id := mux.Vars(request)["id"]
+
+	if err := update(request.Context(), key, id); err != nil {
+		if errors.Is(err, ErrItemNotFound) {
+			http.Error(writer, "inbox item not found", http.StatusNotFound)
+			return
+		}
+
+		slog.ErrorContext(request.Context(), "failed to "+action, "key", key, "id", id, "error", err)
+		http.Error(writer, "failed to "+action, http.StatusInternalServerError)
+		return
+	}
+
+	writer.WriteHeader(http.StatusNoContent)
+}
+
+func parseFilter(request *http.Request) (Filter, error) {
+	query := request.URL.Query()
+
+	page, err := httputil.ParsePageOptions(query)
+	if err != nil {
+		return Filter{}, err
+	}
+
+	filter := Filter{
+		Type:   event.Type(query.Get("type")),
+		Limit:  page.Limit,
+		Cursor: page.Cursor,
+	}
+
+	if filter.Unread, err = parseBool(query.Get("unread")); err != nil {
+		return filter, errors.New("unread must be a boolean")
+	}
+	if filter.Archived, err = parseBool(query.Get("archived")); err != nil {
+		return filter, errors.New("archived must be a boolean")
+	}
+
+	return filter, nil
+}
+
+func parseBool(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+
+	return strconv.ParseBool(value)
+}
@@ -0,0 +1,149 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package inbox_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/seatgeek/mailroom/pkg/inbox"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_List(t *testing.T) {
+	t.Parallel()
+
+	router := createRouter(t)
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantIDs    []string
+		wantCursor string
+	}{
+		{
+			name:       "Happy path",
+			url:        "/users/codell/inbox",
+			wantStatus: 200,
+			wantIDs:    []string{"codell-02", "codell-01", "codell-00"},
+		},
+		{
+			name:       "Paginated",
+			url:        "/users/codell/inbox?limit=2",
+			wantStatus: 200,
+			wantIDs:    []string{"codell-02", "codell-01"},
+			wantCursor: "codell-01",
+		},
+		{
+			name:       "Next page",
+			url:        "/users/codell/inbox?limit=2&cursor=codell-01",
+			wantStatus: 200,
+			wantIDs:    []string{"codell-00"},
+		},
+		{
+			name:       "Filtered",
+			url:        "/users/codell/inbox?unread=true&type=com.example.review",
+			wantStatus: 200,
+			wantIDs:    []string{"codell-01"},
+		},
+		{
+			name:       "Empty inbox",
+			url:        "/users/rufus/inbox",
+			wantStatus: 200,
+			wantIDs:    []string{},
+		},
+		{
+			name:       "Invalid limit",
+			url:        "/users/codell/inbox?limit=1000",
+			wantStatus: 400,
+		},
+		{
+			name:       "Invalid boolean",
+			url:        "/users/codell/inbox?unread=maybe",
+			wantStatus: 400,
+		},
+		{
+			name:       "Invalid cursor",
+			url:        "/users/codell/inbox?cursor=nope",
+			wantStatus: 400,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			writer := httptest.NewRecorder()
+			router.ServeHTTP(writer, httptest.NewRequestWithContext(t.Context(), "GET", tc.url, nil))
+
+			assert.Equal(t, tc.wantStatus, writer.Code)
+			if tc.wantStatus != 200 {
+				return
+			}
+
+			var page inbox.Page
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), &page))
+			assert.Equal(t, tc.wantIDs, ids(&page))
+			assert.Equal(t, tc.wantCursor, page.NextCursor)
+		})
+	}
+}
+
+func TestHandler_ReadAndArchive(t *testing.T) {
+	t.Parallel()
+
+	router := createRouter(t)
+
+	request := func(method, url string) *httptest.ResponseRecorder {
+		writer := httptest.NewRecorder()
+		router.ServeHTTP(writer, httptest.NewRequestWithContext(t.Context(), method, url, nil))
+		return writer
+	}
+
+	writer := request("GET", "/users/codell/inbox/unread-count")
+	assert.Equal(t, 200, writer.Code)
+	assert.JSONEq(t, `{"unread": 2}`, writer.Body.String())
+
+	assert.Equal(t, 204, request("POST", "/users/codell/inbox/codell-00/read").Code)
+	assert.Equal(t, 404, request("POST", "/users/rufus/inbox/codell-00/read").Code)
+	assert.JSONEq(t, `{"unread": 1}`, request("GET", "/users/codell/inbox/unread-count").Body.String())
+
+	assert.Equal(t, 204, request("POST", "/users/codell/inbox/codell-01/archive").Code)
+	assert.Equal(t, 404, request("POST", "/users/codell/inbox/nope/archive").Code)
+	assert.JSONEq(t, `{"unread": 0}`, request("GET", "/users/codell/inbox/unread-count").Body.String())
+
+	var page inbox.Page
+	require.NoError(t, json.Unmarshal(request("GET", "/users/codell/inbox?archived=true").Body.Bytes(), &page))
+	assert.Equal(t, []string{"codell-01"}, ids(&page))
+
+	assert.Equal(t, 204, request("POST", "/users/codell/inbox/read").Code)
+	require.NoError(t, json.Unmarshal(request("GET", "/users/codell/inbox?unread=true&archived=true").Body.Bytes(), &page))
+	assert.Empty(t, page.Items)
+}
+
+func createRouter(t *testing.T) *mux.Router {
+	t.Helper()
+
+	store := inbox.NewInMemoryStore()
+	require.NoError(t, store.Add(t.Context(), newItem("codell", 0, "com.example.build")))
+	require.NoError(t, store.Add(t.Context(), newItem("codell", 1, "com.example.review")))
+	require.NoError(t, store.Add(t.Context(), newItem("codell", 2, "com.example.build")))
+	require.NoError(t, store.MarkRead(t.Context(), "codell", "codell-02"))
+
+	handler := inbox.NewHandler(store)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/users/{key}/inbox", handler.List).Methods("GET")
+	router.HandleFunc("/users/{key}/inbox/unread-count", handler.UnreadCount).Methods("GET")
+	router.HandleFunc("/users/{key}/inbox/read", handler.MarkAllRead).Methods("POST")
+	router.HandleFunc("/users/{key}/inbox/{id}/read", handler.MarkRead).Methods("POST")
+	router.HandleFunc("/users/{key}/inbox/{id}/archive", handler.Archive).Methods("POST")
+
+	return router
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package inbox provides an in-app notification inbox, which keeps notifications for each user so that your own UI can display them
+package inbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seatgeek/mailroom/internal/httputil"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/seatgeek/mailroom/pkg/user"
+	"github.com/seatgeek/mailroom/pkg/validation"
+)
+
+var (
+	// ErrItemNotFound is returned when an item does not exist in the user's inbox
+	ErrItemNotFound = errors.New("inbox item not found")
+	// ErrInvalidCursor is returned when a pagination cursor doesn't refer to an item in the user's inbox
+	ErrInvalidCursor = httputil.ErrInvalidCursor
+)
+
+const (
+	// DefaultLimit is the number of items returned per page when no limit is given
+	DefaultLimit = httputil.DefaultLimit
+	// MaxLimit is the largest number of items that can be requested per page
+	MaxLimit = httputil.MaxLimit
+)
+
+// Item is a notification in a user's inbox
+type Item struct {
+	ID         string                       `json:"id"`
+	UserKey    string                       `json:"user_key"`
+	Context    notification.EnvelopeContext `json:"context"`
+	Message    string                       `json:"message"`
+	CreatedAt  time.Time                    `json:"created_at"`
+	ReadAt     *time.Time                   `json:"read_at,omitempty"`
+	ArchivedAt *time.Time                   `json:"archived_at,omitempty"`
+}
+
+// NewItem creates a new, unread Item for the given user
+func NewItem(userKey string, n event.Notification, message string) *Item {
+	return &Item{
+		ID:        uuid.New().String(),
+		UserKey:   userKey,
+		Context:   notification.NewEnvelopeContext(n.Context()),
+		Message:   message,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Filter narrows down the items returned by Store.List
+type Filter struct {
+	// Unread only returns items which haven't been read
+	Unread bool
+	// Archived returns archived items instead of the ones still in the inbox
+	Archived bool
+	// Type only returns items for the given event type, if set
+	Type event.Type
+	// Limit is the maximum number of items to return (DefaultLimit if zero)
+	Limit int
+	// Cursor continues a previous listing from Page.NextCursor
+	Cursor string
+}
+
+// PageSize returns the number of items to return per page, applying DefaultLimit and MaxLimit
+func (f Filter) PageSize() int {
+	return httputil.PageOptions{Limit: f.Limit}.PageSize()
+}
+
+// Page is a page of inbox items, newest first
+type Page struct {
+	Items []*Item `json:"items"`
+	// NextCursor can be passed as Filter.Cursor to get the next page; it's empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// Store persists inbox items.
+// Implementations may be backed by a SQL database, an in-memory store, or something else.
+type Store interface {
+	// Add stores a new item
+	Add(ctx context.Context, item *Item) error
+	// List returns a page of the user's items matching the filter, newest first
+	List(ctx context.Context, userKey string, filter Filter) (*Page, error)
+	// UnreadCount returns the number of unread items in the user's inbox (excluding archived ones)
+	UnreadCount(ctx context.Context, userKey string) (int, error)
+	// MarkRead marks a single item as read, or returns ErrItemNotFound
+	MarkRead(ctx context.Context, userKey string, id string) error
+	// MarkAllRead marks all of the user's items as read
+	MarkAllRead(ctx context.Context, userKey string) error
+	// Archive removes an item from the user's inbox without deleting it, or returns ErrItemNotFound
+	Archive(ctx context.Context, userKey string, id string) error
+}
+
+// Transport delivers notifications to the inbox of the matching user.User
+type Transport struct {
+	key       event.TransportKey
+	store     Store
+	userStore user.Store
+}
+
+var (
+	_ notifier.Transport   = &Transport{}
+	_ validation.Validator = &Transport{}
+)
+
+// NewTransport creates a new inbox Transport
+// Recipients are looked up in the user.Store since inboxes are kept per user.User.Key.
+func NewTransport(key event.TransportKey, store Store, userStore user.Store) *Transport {
+	return &Transport{
+		key:       key,
+		store:     store,
+		userStore: userStore,
+	}
+}
+
+// Push adds the notification to the recipient's inbox
+func (t *Transport) Push(ctx context.Context, n event.Notification) error {
+	if t.userStore == nil {
+		return notifier.Permanent(errors.New("no user store configured"))
+	}
+
+	u, err := t.userStore.Find(ctx, n.Recipient())
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return notifier.Permanent(fmt.Errorf("recipient does not have an inbox: %w", err))
+		}
+		return err
+	}
+
+	message := n.Render(t.key)
+	if message == "" {
+		return notifier.Permanent(errors.New("message is empty"))
+	}
+
+	return t.store.Add(ctx, NewItem(u.Key, n, message))
+}
+
+func (t *Transport) Key() event.TransportKey {
+	return t.key
+}
+
+// Validate validates the Store, if it supports validation
+func (t *Transport) Validate(ctx context.Context) error {
+	if v, ok := t.store.(validation.Validator); ok {
+		if err := v.Validate(ctx); err != nil {
+			return fmt.Errorf("inbox store is invalid: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package inbox_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/inbox"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport_Push(t *testing.T) {
+	t.Parallel()
+
+	store := inbox.NewInMemoryStore()
+	users := user.NewInMemoryStore(user.New("codell", user.WithIdentifier(identifier.New(identifier.GenericEmail, "codell@example.com"))))
+	transport := inbox.NewTransport("inbox", store, users)
+
+	n := notification.NewBuilder(event.Context{
+		ID:     "a1c11a53-c4be-488f-89b6-f83bf2d48dab",
+		Type:   "com.example.test",
+		Labels: map[string]string{"project": "mailroom"},
+	}).
+		WithRecipientIdentifiers(identifier.New("gitlab.com/email", "codell@example.com")).
+		WithDefaultMessage("hello world").
+		WithMessageForTransport("inbox", "hello inbox").
+		Build()
+
+	require.NoError(t, transport.Push(t.Context(), n))
+
+	page, err := store.List(t.Context(), "codell", inbox.Filter{})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 1)
+
+	item := page.Items[0]
+	assert.NotEmpty(t, item.ID)
+	assert.Equal(t, "codell", item.UserKey)
+	assert.Equal(t, event.ID("a1c11a53-c4be-488f-89b6-f83bf2d48dab"), item.Context.ID)
+	assert.Equal(t, map[string]string{"project": "mailroom"}, item.Context.Labels)
+	assert.Equal(t, "hello inbox", item.Message)
+	assert.Nil(t, item.ReadAt)
+	assert.Nil(t, item.ArchivedAt)
+}
+
+func TestTransport_Push_Errors(t *testing.T) {
+	t.Parallel()
+
+	users := user.NewInMemoryStore(user.New("codell", user.WithIdentifier(identifier.New(identifier.GenericUsername, "codell"))))
+
+	tests := []struct {
+		name      string
+		userStore user.Store
+		recipient identifier.Identifier
+		message   string
+		wantErr   string
+	}{
+		{
+			name:      "no user store",
+			userStore: nil,
+			recipient: identifier.New(identifier.GenericUsername, "codell"),
+			message:   "hello",
+			wantErr:   "no user store configured",
+		},
+		{
+			name:      "unknown user",
+			userStore: users,
+			recipient: identifier.New(identifier.GenericUsername, "rufus"),
+			message:   "hello",
+			wantErr:   "recipient does not have an inbox",
+		},
+		{
+			name:      "empty message",
+			userStore: users,
+			recipient: identifier.New(identifier.GenericUsername, "codell"),
+			message:   "",
+			wantErr:   "message is empty",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			transport := inbox.NewTransport("inbox", inbox.NewInMemoryStore(), tc.userStore)
+
+			err := transport.Push(t.Context(), notification.NewBuilder(event.Context{Type: "com.example.test"}).
+				WithRecipientIdentifiers(tc.recipient).
+				WithDefaultMessage(tc.message).
+				Build())
+			assert.ErrorContains(t, err, tc.wantErr)
+
+			var permanent *backoff.PermanentError
+			assert.True(t, errors.As(err, &permanent))
+		})
+	}
+}
+
+func TestFilter_PageSize(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, inbox.DefaultLimit, inbox.Filter{}.PageSize())
+	assert.Equal(t, 5, inbox.Filter{Limit: 5}.PageSize())
+	assert.Equal(t, inbox.MaxLimit, inbox.Filter{Limit: 1000}.PageSize())
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package postgres provides a postgresql-backed implementation of the inbox.Store interface
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/inbox"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"gorm.io/gorm"
+)
+
+// ItemModel is the gorm model for an inbox item
+type ItemModel struct {
+	ID         string `gorm:"primarykey"`
+	UserKey    string
+	Type       event.Type
+	Context    notification.EnvelopeContext `gorm:"serializer:json"`
+	Message    string
+	CreatedAt  time.Time
+	ReadAt     *time.Time
+	ArchivedAt *time.Time
+}
+
+func (i *ItemModel) TableName() string {
+	return "inbox_items"
+}
+
+// ToItem converts an ItemModel to an inbox.Item
+func (i *ItemModel) ToItem() *inbox.Item {
+	return &inbox.Item{
+		ID:         i.ID,
+		UserKey:    i.UserKey,
+		Context:    i.Context,
+		Message:    i.Message,
+		CreatedAt:  i.CreatedAt,
+		ReadAt:     i.ReadAt,
+		ArchivedAt: i.ArchivedAt,
+	}
+}
+
+type Store struct {
+	db *gorm.DB
+}
+
+var _ inbox.Store = &Store{}
+
+// NewPostgresStore creates a new postgres store
+func NewPostgresStore(db *gorm.DB) *Store {
+	return &Store{db: db}
+}
+
+// Add implements inbox.Store.
+func (s *Store) Add(ctx context.Context, item *inbox.Item) error {
+	return s.db.WithContext(ctx).Create(&ItemModel{
+		ID:         item.ID,
+		UserKey:    item.UserKey,
+		Type:       item.Context.Type,
+		Context:    item.Context,
+		Message:    item.Message,
+		CreatedAt:  item.CreatedAt,
+		ReadAt:     item.ReadAt,
+		ArchivedAt: item.ArchivedAt,
+	}).Error
+}
+
+// List implements inbox.Store.
+func (s *Store) List(ctx context.Context, userKey string, filter inbox.Filter) (*inbox.Page, error) {
+	query := s.db.WithContext(ctx).Where("user_key = ?", userKey)
+
+	if filter.Archived {
+		query = query.Where("archived_at IS NOT NULL")
+	} else {
+		query = query.Where("archived_at IS NULL")
+	}
+
+	if filter.Unread {
+		query = query.Where("read_at IS NULL")
+	}
+
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+
+	if filter.Cursor != "" {
+		var cursor ItemModel
+		if err := s.db.WithContext(ctx).Where("id = ? AND user_key = ?", filter.Cursor, userKey).First(&cursor).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, inbox.ErrInvalidCursor
+			}
+			return nil, err
+		}
+
+		query = query.Where("(created_at, id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+	}
+
+	limit := filter.PageSize()
+
+	// Fetch one extra item to find out whether there's another page
+	var models []ItemModel
+	if err := query.Order("created_at DESC, id DESC").Limit(limit + 1).Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	page := &inbox.Page{Items: make([]*inbox.Item, 0, len(models))}
+	for i := range models {
+		if i == limit {
+			page.NextCursor = models[i-1].ID
+			break
+		}
+		page.Items = append(page.Items, models[i].ToItem())
+	}
+
+	return page, nil
+}
+
+// UnreadCount implements inbox.Store.
+func (s *Store) UnreadCount(ctx context.Context, userKey string) (int, error) {
+	var count int64
+	err := s.db.WithContext(ctx).Model(&ItemModel{}).
+		Where("user_key = ? AND read_at IS NULL AND archived_at IS NULL", userKey).
+		Count(&count).Error
+
+	return int(count), err
+}
+
+// MarkRead implements inbox.Store.
+func (s *Store) MarkRead(ctx context.Context, userKey string, id string) error {
+	return s.touch(ctx, userKey, id, "read_at")
+}
+
+// MarkAllRead implements inbox.Store.
+func (s *Store) MarkAllRead(ctx context.Context, userKey string) error {
+	return s.db.WithContext(ctx).Model(&ItemModel{}).
+		Where("user_key = ? AND read_at IS NULL", userKey).
+		Update("read_at", time.Now()).Error
+}
+
+// Archive implements inbox.Store.
+func (s *Store) Archive(ctx context.Context, userKey string, id string) error {
+	return s.touch(ctx, userKey, id, "archived_at")
+}
+
+// touch sets the given timestamp column on a single item, unless it was already set
+func (s *Store) touch(ctx context.Context, userKey string, id string, column string) error {
+	result := s.db.WithContext(ctx).Model(&ItemModel{}).
+		Where("id = ? AND user_key = ?", id, userKey).
+		Update(column, gorm.Expr("COALESCE("+column+", ?)", time.Now()))
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return inbox.ErrItemNotFound
+	}
+
+	return nil
+}
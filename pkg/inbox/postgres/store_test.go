@@ -0,0 +1,151 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/inbox"
+	"github.com/seatgeek/mailroom/pkg/inbox/postgres"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+	pgtc "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	pg "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func newItem(userKey string, minutes int, typ event.Type) *inbox.Item {
+	return &inbox.Item{
+		ID:        fmt.Sprintf("%s-%02d", userKey, minutes),
+		UserKey:   userKey,
+		Context:   notification.EnvelopeContext{ID: "a1c11a53-c4be-488f-89b6-f83bf2d48dab", Type: typ},
+		Message:   fmt.Sprintf("message %d", minutes),
+		CreatedAt: time.Date(2025, 1, 2, 3, minutes, 0, 0, time.UTC),
+	}
+}
+
+func ids(page *inbox.Page) []string {
+	res := make([]string, len(page.Items))
+	for i, item := range page.Items {
+		res[i] = item.ID
+	}
+	return res
+}
+
+func TestPostgresStore_List(t *testing.T) {
+	t.Parallel()
+
+	store := createDatastore(t)
+
+	for i := range 5 {
+		typ := event.Type("com.example.build")
+		if i%2 == 1 {
+			typ = "com.example.review"
+		}
+		assert.NoError(t, store.Add(t.Context(), newItem("codell", i, typ)))
+	}
+	assert.NoError(t, store.Add(t.Context(), newItem("rufus", 0, "com.example.build")))
+
+	assert.NoError(t, store.MarkRead(t.Context(), "codell", "codell-03"))
+	assert.NoError(t, store.Archive(t.Context(), "codell", "codell-04"))
+
+	page, err := store.List(t.Context(), "codell", inbox.Filter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"codell-03", "codell-02", "codell-01", "codell-00"}, ids(page))
+	assert.Equal(t, "message 3", page.Items[0].Message)
+	assert.Equal(t, event.Type("com.example.review"), page.Items[0].Context.Type)
+
+	page, err = store.List(t.Context(), "codell", inbox.Filter{Unread: true, Type: "com.example.review"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"codell-01"}, ids(page))
+
+	page, err = store.List(t.Context(), "codell", inbox.Filter{Archived: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"codell-04"}, ids(page))
+
+	// Pagination
+	page, err = store.List(t.Context(), "codell", inbox.Filter{Limit: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"codell-03", "codell-02", "codell-01"}, ids(page))
+	assert.Equal(t, "codell-01", page.NextCursor)
+
+	page, err = store.List(t.Context(), "codell", inbox.Filter{Limit: 3, Cursor: page.NextCursor})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"codell-00"}, ids(page))
+	assert.Empty(t, page.NextCursor)
+
+	_, err = store.List(t.Context(), "codell", inbox.Filter{Cursor: "rufus-00"})
+	assert.ErrorIs(t, err, inbox.ErrInvalidCursor)
+}
+
+func TestPostgresStore_ReadAndArchive(t *testing.T) {
+	t.Parallel()
+
+	store := createDatastore(t)
+
+	for i := range 3 {
+		assert.NoError(t, store.Add(t.Context(), newItem("codell", i, "com.example.test")))
+	}
+	assert.NoError(t, store.Add(t.Context(), newItem("rufus", 0, "com.example.test")))
+
+	count, err := store.UnreadCount(t.Context(), "codell")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	assert.NoError(t, store.MarkRead(t.Context(), "codell", "codell-00"))
+	assert.NoError(t, store.MarkRead(t.Context(), "codell", "codell-00"))
+	assert.ErrorIs(t, store.MarkRead(t.Context(), "codell", "rufus-00"), inbox.ErrItemNotFound)
+
+	assert.NoError(t, store.Archive(t.Context(), "codell", "codell-01"))
+	assert.ErrorIs(t, store.Archive(t.Context(), "codell", "missing"), inbox.ErrItemNotFound)
+
+	count, err = store.UnreadCount(t.Context(), "codell")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	assert.NoError(t, store.MarkAllRead(t.Context(), "codell"))
+
+	count, err = store.UnreadCount(t.Context(), "codell")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	count, err = store.UnreadCount(t.Context(), "rufus")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func createDatastore(t *testing.T) *postgres.Store {
+	t.Helper()
+
+	ctx := context.Background()
+
+	container, err := pgtc.Run(ctx, "postgres:16.2",
+		pgtc.WithInitScripts("../../../test/initdb/init.sql"),
+		pgtc.WithDatabase("mailroom"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second)),
+	)
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, container.Terminate(ctx))
+	})
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable", "application_name=test")
+	assert.NoError(t, err)
+
+	db, err := gorm.Open(pg.Open(dsn), &gorm.Config{})
+	assert.NoError(t, err)
+
+	return postgres.NewPostgresStore(db)
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package inbox
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// InMemoryStore is a simple in-memory implementation of the Store interface
+// This is especially useful for testing, but items are lost on restart.
+type InMemoryStore struct {
+	items []*Item
+	mu    sync.RWMutex
+}
+
+var _ Store = &InMemoryStore{}
+
+// NewInMemoryStore creates a new, empty in-memory store
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{}
+}
+
+func (s *InMemoryStore) Add(_ context.Context, item *Item) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.items = append(s.items, item)
+	return nil
+}
+
+func (s *InMemoryStore) List(_ context.Context, userKey string, filter Filter) (*Page, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	matches := make([]*Item, 0)
+	for _, item := range s.items {
+		if item.UserKey != userKey || (item.ArchivedAt != nil) != filter.Archived {
+			continue
+		}
+		if filter.Unread && item.ReadAt != nil {
+			continue
+		}
+		if filter.Type != "" && item.Context.Type != filter.Type {
+			continue
+		}
+		matches = append(matches, item)
+	}
+
+	slices.SortFunc(matches, newestFirst)
+
+	if filter.Cursor != "" {
+		cursor := s.find(userKey, filter.Cursor)
+		if cursor == nil {
+			return nil, ErrInvalidCursor
+		}
+
+		// Skip everything up to and including the cursor
+		start, _ := slices.BinarySearchFunc(matches, cursor, newestFirst)
+		if start < len(matches) && matches[start].ID == cursor.ID {
+			start++
+		}
+		matches = matches[start:]
+	}
+
+	page := &Page{Items: make([]*Item, 0, filter.PageSize())}
+	for i, item := range matches {
+		if i == filter.PageSize() {
+			page.NextCursor = page.Items[i-1].ID
+			break
+		}
+
+		copied := *item
+		page.Items = append(page.Items, &copied)
+	}
+
+	return page, nil
+}
+
+func (s *InMemoryStore) UnreadCount(_ context.Context, userKey string) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	count := 0
+	for _, item := range s.items {
+		if item.UserKey == userKey && item.ReadAt == nil && item.ArchivedAt == nil {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (s *InMemoryStore) MarkRead(_ context.Context, userKey string, id string) error {
+	return s.update(userKey, id, func(item *Item, now time.Time) {
+		if item.ReadAt == nil {
+			item.ReadAt = &now
+		}
+	})
+}
+
+func (s *InMemoryStore) MarkAllRead(_ context.Context, userKey string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for _, item := range s.items {
+		if item.UserKey == userKey && item.ReadAt == nil {
+			item.ReadAt = &now
+		}
+	}
+
+	return nil
+}
+
+func (s *InMemoryStore) Archive(_ context.Context, userKey string, id string) error {
+	return s.update(userKey, id, func(item *Item, now time.Time) {
+		if item.ArchivedAt == nil {
+			item.ArchivedAt = &now
+		}
+	})
+}
+
+func (s *InMemoryStore) update(userKey string, id string, apply func(*Item, time.Time)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item := s.find(userKey, id)
+	if item == nil {
+		return ErrItemNotFound
+	}
+
+	apply(item, time.Now())
+	return nil
+}
+
+// find returns the user's item with the given ID, or nil (the caller must hold the lock)
+func (s *InMemoryStore) find(userKey string, id string) *Item {
+	for _, item := range s.items {
+		if item.UserKey == userKey && item.ID == id {
+			return item
+		}
+	}
+
+	return nil
+}
+
+// newestFirst orders items by creation time (descending), using the ID to break ties
+func newestFirst(a, b *Item) int {
+	if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(b.ID, a.ID)
+}
@@ -0,0 +1,178 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package inbox_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/inbox"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newItem creates an item which was created the given number of minutes after some fixed time
+func newItem(userKey string, minutes int, typ event.Type) *inbox.Item {
+	return &inbox.Item{
+		ID:        fmt.Sprintf("%s-%02d", userKey, minutes),
+		UserKey:   userKey,
+		Context:   notification.EnvelopeContext{Type: typ},
+		Message:   fmt.Sprintf("message %d", minutes),
+		CreatedAt: time.Date(2025, 1, 2, 3, minutes, 0, 0, time.UTC),
+	}
+}
+
+func ids(page *inbox.Page) []string {
+	res := make([]string, len(page.Items))
+	for i, item := range page.Items {
+		res[i] = item.ID
+	}
+	return res
+}
+
+func TestInMemoryStore_List(t *testing.T) {
+	t.Parallel()
+
+	store := inbox.NewInMemoryStore()
+	for i := range 5 {
+		typ := event.Type("com.example.build")
+		if i%2 == 1 {
+			typ = "com.example.review"
+		}
+		require.NoError(t, store.Add(t.Context(), newItem("codell", i, typ)))
+	}
+	require.NoError(t, store.Add(t.Context(), newItem("rufus", 0, "com.example.build")))
+
+	require.NoError(t, store.MarkRead(t.Context(), "codell", "codell-03"))
+	require.NoError(t, store.Archive(t.Context(), "codell", "codell-04"))
+
+	tests := []struct {
+		name   string
+		filter inbox.Filter
+		want   []string
+	}{
+		{
+			name:   "newest first, excluding archived",
+			filter: inbox.Filter{},
+			want:   []string{"codell-03", "codell-02", "codell-01", "codell-00"},
+		},
+		{
+			name:   "unread",
+			filter: inbox.Filter{Unread: true},
+			want:   []string{"codell-02", "codell-01", "codell-00"},
+		},
+		{
+			name:   "archived",
+			filter: inbox.Filter{Archived: true},
+			want:   []string{"codell-04"},
+		},
+		{
+			name:   "by type",
+			filter: inbox.Filter{Type: "com.example.review"},
+			want:   []string{"codell-03", "codell-01"},
+		},
+		{
+			name:   "unread by type",
+			filter: inbox.Filter{Type: "com.example.review", Unread: true},
+			want:   []string{"codell-01"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			page, err := store.List(t.Context(), "codell", tc.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, ids(page))
+			assert.Empty(t, page.NextCursor)
+		})
+	}
+}
+
+func TestInMemoryStore_List_Pagination(t *testing.T) {
+	t.Parallel()
+
+	store := inbox.NewInMemoryStore()
+	for i := range 5 {
+		require.NoError(t, store.Add(t.Context(), newItem("codell", i, "com.example.test")))
+	}
+
+	page, err := store.List(t.Context(), "codell", inbox.Filter{Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"codell-04", "codell-03"}, ids(page))
+	assert.Equal(t, "codell-03", page.NextCursor)
+
+	page, err = store.List(t.Context(), "codell", inbox.Filter{Limit: 2, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"codell-02", "codell-01"}, ids(page))
+	assert.Equal(t, "codell-01", page.NextCursor)
+
+	page, err = store.List(t.Context(), "codell", inbox.Filter{Limit: 2, Cursor: page.NextCursor})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"codell-00"}, ids(page))
+	assert.Empty(t, page.NextCursor)
+
+	// Cursors stay valid even if the item they refer to no longer matches the filter
+	require.NoError(t, store.MarkRead(t.Context(), "codell", "codell-03"))
+	page, err = store.List(t.Context(), "codell", inbox.Filter{Unread: true, Cursor: "codell-03"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"codell-02", "codell-01", "codell-00"}, ids(page))
+
+	_, err = store.List(t.Context(), "codell", inbox.Filter{Cursor: "rufus-00"})
+	assert.ErrorIs(t, err, inbox.ErrInvalidCursor)
+}
+
+func TestInMemoryStore_ReadAndArchive(t *testing.T) {
+	t.Parallel()
+
+	store := inbox.NewInMemoryStore()
+	for i := range 3 {
+		require.NoError(t, store.Add(t.Context(), newItem("codell", i, "com.example.test")))
+	}
+	require.NoError(t, store.Add(t.Context(), newItem("rufus", 0, "com.example.test")))
+
+	count, err := store.UnreadCount(t.Context(), "codell")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	require.NoError(t, store.MarkRead(t.Context(), "codell", "codell-00"))
+	// Marking an item as read again is fine
+	require.NoError(t, store.MarkRead(t.Context(), "codell", "codell-00"))
+	// Users can't touch other users' items
+	assert.ErrorIs(t, store.MarkRead(t.Context(), "codell", "rufus-00"), inbox.ErrItemNotFound)
+
+	count, err = store.UnreadCount(t.Context(), "codell")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	// Archived items don't count as unread
+	require.NoError(t, store.Archive(t.Context(), "codell", "codell-01"))
+	assert.ErrorIs(t, store.Archive(t.Context(), "codell", "missing"), inbox.ErrItemNotFound)
+
+	count, err = store.UnreadCount(t.Context(), "codell")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	require.NoError(t, store.MarkAllRead(t.Context(), "codell"))
+
+	count, err = store.UnreadCount(t.Context(), "codell")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	count, err = store.UnreadCount(t.Context(), "rufus")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	page, err := store.List(t.Context(), "codell", inbox.Filter{Archived: true})
+	require.NoError(t, err)
+	if assert.Len(t, page.Items, 1) {
+		assert.NotNil(t, page.Items[0].ReadAt)
+		assert.NotNil(t, page.Items[0].ArchivedAt)
+	}
+}
@@ -5,12 +5,19 @@
 package notification
 
 import (
+	"log/slog"
 	"maps"
 	"slices"
+	"strings"
+	"time"
 
+	"github.com/seatgeek/mailroom/pkg/content"
 	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/i18n"
 	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification/extension"
 	slack2 "github.com/seatgeek/mailroom/pkg/notifier/slack"
+	"github.com/seatgeek/mailroom/pkg/template"
 	"github.com/slack-go/slack"
 )
 
@@ -20,6 +27,12 @@ type builderOpts struct {
 	fallbackMessage     string
 	messagePerTransport map[event.TransportKey]string
 	slackOpts           []slack.MsgOption
+	content             *content.Content
+	extensions          map[string]any
+	templates           *template.Registry
+	payload             event.Payload
+	locale              string
+	location            *time.Location
 }
 
 // Builder provides a fluent interface for constructing rich notification objects
@@ -34,6 +47,7 @@ func NewBuilder(context event.Context) *Builder {
 			context:             context,
 			recipients:          identifier.NewSet(),
 			messagePerTransport: make(map[event.TransportKey]string),
+			extensions:          make(map[string]any),
 		},
 	}
 }
@@ -70,12 +84,51 @@ func (b *Builder) WithSlackOptions(opts ...slack.MsgOption) *Builder {
 	return b
 }
 
+// WithContent sets the structured content of the notification, which transports render in their native format
+// Transports without native support use the plain-text version of the content, unless a message was set for them.
+func (b *Builder) WithContent(c content.Content) *Builder {
+	b.opts.content = &c
+	return b
+}
+
+// WithExtensions attaches rich data for specific transports, like an email subject (see extension.Key)
+func (b *Builder) WithExtensions(values ...extension.Value) *Builder {
+	for _, v := range values {
+		b.opts.extensions[v.Name] = v.Value
+	}
+	return b
+}
+
+// WithTemplates renders the templates for the notification's event type (see template.Registry) with the given payload,
+// using them for the default message, each transport's message, and extensions like the email subject (see extension.NewTemplateKey)
+// Templates which fail to render are logged and skipped, leaving anything set before in place; so call this after setting
+// the recipient, and after any fallback messages.
+// The templates are rendered again whenever the notification is localized (see WithLocale).
+func (b *Builder) WithTemplates(templates *template.Registry, payload event.Payload) *Builder {
+	b.opts.templates = templates
+	b.opts.payload = payload
+	b.opts.renderTemplates()
+	return b
+}
+
+// WithLocale renders the notification's templates in the given locale (like "fr-CA") and time zone
+// Usually this is left to user.LocalizationProcessor, which uses each recipient's own locale and time zone.
+func (b *Builder) WithLocale(locale string, location *time.Location) *Builder {
+	b.opts.Localize(locale, location)
+	return b
+}
+
 // Build constructs the rich notification object from the previously set options
 func (b *Builder) Build() slack2.RichNotification {
 	return &b.opts
 }
 
-var _ slack2.RichNotification = &builderOpts{}
+var (
+	_ slack2.RichNotification = &builderOpts{}
+	_ content.Notification    = &builderOpts{}
+	_ extension.Notification  = &builderOpts{}
+	_ i18n.Localizable        = &builderOpts{}
+)
 
 func (b *builderOpts) Context() event.Context {
 	return b.context
@@ -90,6 +143,10 @@ func (b *builderOpts) Render(key event.TransportKey) string {
 		return message
 	}
 
+	if b.fallbackMessage == "" {
+		return b.content.Text()
+	}
+
 	return b.fallbackMessage
 }
 
@@ -97,6 +154,54 @@ func (b *builderOpts) GetSlackOptions() []slack.MsgOption {
 	return b.slackOpts
 }
 
+func (b *builderOpts) GetContent() *content.Content {
+	return b.content
+}
+
+func (b *builderOpts) GetExtensions() map[string]any {
+	return b.extensions
+}
+
+func (b *builderOpts) Localize(locale string, location *time.Location) {
+	b.locale = locale
+	b.location = location
+	b.renderTemplates()
+}
+
+func (b *builderOpts) renderTemplates() {
+	if b.templates == nil {
+		return
+	}
+
+	eventType := b.context.Type
+	data := template.Data{
+		Context:   b.context,
+		Payload:   b.payload,
+		Recipient: b.recipients,
+		Locale:    b.locale,
+		Location:  b.location,
+	}
+
+	for _, name := range b.templates.Names(eventType) {
+		rendered, err := b.templates.Render(eventType, name, data)
+		if err != nil {
+			slog.Error("failed to render notification template", "event_type", eventType, "template", name, "locale", b.locale, "error", err)
+			continue
+		}
+
+		switch name {
+		case template.Default:
+			b.fallbackMessage = rendered
+		default:
+			if ext, ok := extension.ForTemplate(name); ok {
+				b.extensions[ext] = rendered
+			} else if key, ok := strings.CutSuffix(name, ".txt"); ok {
+				b.messagePerTransport[event.TransportKey(key)] = rendered
+			}
+		}
+	}
+}
+
 func (b *builderOpts) WithRecipient(recipient identifier.Set) event.Notification {
 	b.recipients = recipient
 	return b
@@ -109,5 +214,11 @@ func (b *builderOpts) Copy() event.Notification {
 		fallbackMessage:     b.fallbackMessage,
 		messagePerTransport: maps.Clone(b.messagePerTransport),
 		slackOpts:           slices.Clone(b.slackOpts),
+		content:             b.content.Copy(),
+		extensions:          maps.Clone(b.extensions),
+		templates:           b.templates,
+		payload:             b.payload,
+		locale:              b.locale,
+		location:            b.location,
 	}
 }
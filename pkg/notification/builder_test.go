@@ -6,13 +6,23 @@ package notification_test
 
 import (
 	"testing"
+	"testing/fstest"
+	"time"
 
+	"github.com/seatgeek/mailroom/pkg/content"
 	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/i18n"
 	"github.com/seatgeek/mailroom/pkg/identifier"
 	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notification/extension"
+	"github.com/seatgeek/mailroom/pkg/notifier/email"
+	"github.com/seatgeek/mailroom/pkg/notifier/push"
 	slack2 "github.com/seatgeek/mailroom/pkg/notifier/slack"
+	"github.com/seatgeek/mailroom/pkg/notifier/teams"
+	"github.com/seatgeek/mailroom/pkg/template"
 	"github.com/slack-go/slack"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewBuilder(t *testing.T) {
@@ -74,6 +84,127 @@ func TestNewBuilder(t *testing.T) {
 	assert.Len(t, withSlackOpts.GetSlackOptions(), 1)
 }
 
+func TestBuilder_WithTemplates(t *testing.T) {
+	t.Parallel()
+
+	templates := template.New(fstest.MapFS{
+		"com.example.test/default.txt":       {Data: []byte("Hello, {{ .Payload.Name }}!")},
+		"com.example.test/slack.txt":         {Data: []byte(":wave: Hello, {{ .Recipient.MustGet \"slack.com/id\" | printf \"<@%s>\" }}!")},
+		"com.example.test/email_subject.txt": {Data: []byte("About {{ .Context.Subject }}")},
+		"com.example.test/email.html":        {Data: []byte("<p>Hello, {{ .Payload.Name }}!</p>")},
+		"com.example.test/discord.txt":       {Data: []byte("{{ .Payload.Missing }}")},
+	})
+
+	n := notification.NewBuilder(event.Context{ID: "a1c11a53-c4be-488f-89b6-f83bf2d48dab", Type: "com.example.test", Subject: "build 42"}).
+		WithRecipientIdentifiers(identifier.New(slack2.ID, "U123")).
+		WithMessageForTransport("discord", "Hello!").
+		WithTemplates(templates, struct{ Name string }{Name: "<Codell>"}).
+		Build()
+
+	assert.Equal(t, "Hello, <Codell>!", n.Render("email"))
+	assert.Equal(t, ":wave: Hello, <@U123>!", n.Render("slack"))
+	subject, _ := email.Subject.Of(n)
+	html, _ := email.HTMLBody.Of(n)
+	assert.Equal(t, "About build 42", subject)
+	assert.Equal(t, "<p>Hello, &lt;Codell&gt;!</p>", html)
+
+	// Templates which fail to render are skipped
+	assert.Equal(t, "Hello!", n.Render("discord"))
+
+	// Event types without templates are left alone
+	other := notification.NewBuilder(event.Context{Type: "com.example.other"}).
+		WithDefaultMessage("Hello, world!").
+		WithTemplates(templates, nil).
+		Build()
+	assert.Equal(t, "Hello, world!", other.Render("email"))
+}
+
+var templatedExtension = extension.NewTemplateKey("test.builder", "builder_extension.txt")
+
+func TestBuilder_WithExtensions(t *testing.T) {
+	t.Parallel()
+
+	templates := template.New(fstest.MapFS{
+		"com.example.test/builder_extension.txt": {Data: []byte("Hello, {{ .Payload.Name }}!")},
+	})
+
+	n := notification.NewBuilder(event.Context{Type: "com.example.test"}).
+		WithDefaultMessage("Hello, world!").
+		WithExtensions(templatedExtension.Value("Hello, someone!")).
+		Build()
+
+	got, ok := templatedExtension.Of(n)
+	assert.True(t, ok)
+	assert.Equal(t, "Hello, someone!", got)
+
+	// Extensions may be rendered from templates, rather than being taken as a message for some transport
+	n = notification.NewBuilder(event.Context{Type: "com.example.test"}).
+		WithDefaultMessage("Hello, world!").
+		WithTemplates(templates, struct{ Name string }{Name: "Codell"}).
+		Build()
+
+	got, ok = templatedExtension.Of(n)
+	assert.True(t, ok)
+	assert.Equal(t, "Hello, Codell!", got)
+	assert.Equal(t, "Hello, world!", n.Render("builder_extension"))
+}
+
+func TestBuilder_WithLocale(t *testing.T) {
+	t.Parallel()
+
+	catalog := i18n.FromMap(map[string]map[string]string{
+		"en": {"greeting": "Hello, %s! Sent %s"},
+		"fr": {"greeting": "Bonjour, %s ! Envoyé le %s", i18n.TimeLayoutKey: "02/01/2006 15:04"},
+	}, "en")
+	templates := template.New(fstest.MapFS{
+		"com.example.test/default.txt": {Data: []byte(`{{ t "greeting" .Payload.Name (localtime .Context.Time) }}`)},
+	}, template.WithCatalog(catalog))
+
+	montreal, err := time.LoadLocation("America/Montreal")
+	require.NoError(t, err)
+
+	n := notification.NewBuilder(event.Context{Type: "com.example.test", Time: time.Date(2025, 3, 14, 15, 9, 0, 0, time.UTC)}).
+		WithTemplates(templates, struct{ Name string }{Name: "Codell"}).
+		Build()
+	assert.Equal(t, "Hello, Codell! Sent Mar 14, 2025 3:09 PM UTC", n.Render("email"))
+
+	// Localizing a copy re-renders the templates, leaving the original alone
+	localized := n.Copy()
+	localized.(i18n.Localizable).Localize("fr-CA", montreal)
+	assert.Equal(t, "Bonjour, Codell ! Envoyé le 14/03/2025 11:09", localized.Render("email"))
+	assert.Equal(t, "Hello, Codell! Sent Mar 14, 2025 3:09 PM UTC", n.Render("email"))
+
+	// The locale can also be set up front
+	n = notification.NewBuilder(event.Context{Type: "com.example.test", Time: time.Date(2025, 3, 14, 15, 9, 0, 0, time.UTC)}).
+		WithLocale("fr", nil).
+		WithTemplates(templates, struct{ Name string }{Name: "Codell"}).
+		Build()
+	assert.Equal(t, "Bonjour, Codell ! Envoyé le 14/03/2025 15:09", n.Render("email"))
+}
+
+func TestBuilder_WithContent(t *testing.T) {
+	t.Parallel()
+
+	c := content.Content{Title: "Pipeline failed", Body: "The build job failed"}
+
+	n := notification.NewBuilder(event.Context{Type: "com.example.test"}).
+		WithContent(c).
+		WithMessageForTransport("slack", "Slack message").
+		Build()
+
+	assert.Equal(t, &c, content.Of(n))
+
+	// The content is used as plain text unless there's a message
+	assert.Equal(t, "Pipeline failed\nThe build job failed", n.Render("email"))
+	assert.Equal(t, "Slack message", n.Render("slack"))
+
+	withDefault := notification.NewBuilder(event.Context{Type: "com.example.test"}).
+		WithContent(c).
+		WithDefaultMessage("Default message").
+		Build()
+	assert.Equal(t, "Default message", withDefault.Render("email"))
+}
+
 func TestNotificationWithRecipient(t *testing.T) {
 	t.Parallel()
 
@@ -107,6 +238,8 @@ func TestNotificationWithRecipient(t *testing.T) {
 func TestNotificationCopy(t *testing.T) {
 	t.Parallel()
 
+	badge := 3
+
 	originalNotification := notification.NewBuilder(event.Context{
 		ID:   "test-id",
 		Type: "test-type",
@@ -123,6 +256,11 @@ func TestNotificationCopy(t *testing.T) {
 			slack.MsgOptionText("slack text", false),
 			slack.MsgOptionAttachments(slack.Attachment{Title: "Test", Text: "Attachment"}),
 		).
+		WithExtensions(slack2.Destination.Value(slack2.Target{Channel: "C123", ThreadKey: "mr-1"})).
+		WithExtensions(email.Subject.Value("Some subject"), email.HTMLBody.Value("<p>Email message</p>")).
+		WithExtensions(teams.CustomCard.Value(teams.AdaptiveCard{"type": "AdaptiveCard"})).
+		WithExtensions(push.Details.Value(push.Message{Title: "Some title", Badge: &badge})).
+		WithContent(content.Content{Title: "Some title", Fields: []content.Field{{Name: "Project", Value: "mailroom"}}}).
 		Build()
 
 	clonedNotification := originalNotification.Copy()
@@ -142,6 +280,25 @@ func TestNotificationCopy(t *testing.T) {
 	assert.True(t, ok, "cloned notification should implement RichNotification")
 	assert.Len(t, richCloned.GetSlackOptions(), 2)
 
+	target, _ := slack2.Destination.Of(clonedNotification)
+	assert.Equal(t, slack2.Target{Channel: "C123", ThreadKey: "mr-1"}, target)
+
+	subject, _ := email.Subject.Of(clonedNotification)
+	html, _ := email.HTMLBody.Of(clonedNotification)
+	assert.Equal(t, "Some subject", subject)
+	assert.Equal(t, "<p>Email message</p>", html)
+
+	card, _ := teams.CustomCard.Of(clonedNotification)
+	assert.Equal(t, teams.AdaptiveCard{"type": "AdaptiveCard"}, card)
+
+	details, _ := push.Details.Of(clonedNotification)
+	assert.Equal(t, push.Message{Title: "Some title", Badge: &badge}, details)
+
+	contentCloned := content.Of(clonedNotification)
+	assert.Equal(t, content.Of(originalNotification), contentCloned)
+	contentCloned.Fields[0].Value = "other"
+	assert.Equal(t, "mailroom", content.Of(originalNotification).Fields[0].Value)
+
 	newRecipient := identifier.NewSet(identifier.New(identifier.GenericUsername, "modified-user"))
 	originalNotification.WithRecipient(newRecipient)
 
@@ -0,0 +1,142 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package notification
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/content"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification/extension"
+	slack2 "github.com/seatgeek/mailroom/pkg/notifier/slack"
+)
+
+// ErrUnserializable is returned by Seal for notifications carrying data which can't be serialized, like Slack options
+var ErrUnserializable = errors.New("notification can't be serialized")
+
+// Envelope is a serializable snapshot of an event.Notification
+//
+// Notifications are interfaces which may carry arbitrary (and often unserializable) data, so the envelope
+// captures everything needed to deliver them later: the context, the recipient, and the message as rendered
+// for each transport at the time the envelope was sealed, along with any rich content for specific transports.
+type Envelope struct {
+	Context   EnvelopeContext                        `json:"context"`
+	Recipient map[identifier.NamespaceAndKind]string `json:"recipient"`
+	// DefaultMessage is the message rendered for any transport not listed in Messages
+	DefaultMessage string                        `json:"default_message,omitempty"`
+	Messages       map[event.TransportKey]string `json:"messages,omitempty"`
+
+	Content *content.Content `json:"content,omitempty"`
+	// Extensions holds the rich data for specific transports (see extension.Key), by extension name
+	Extensions map[string]json.RawMessage `json:"extensions,omitempty"`
+}
+
+// EnvelopeContext is the serializable form of an event.Context
+type EnvelopeContext struct {
+	ID      event.ID          `json:"id"`
+	Source  string            `json:"source"`
+	Type    event.Type        `json:"type"`
+	Subject string            `json:"subject,omitempty"`
+	Time    time.Time         `json:"time"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+// NewEnvelopeContext converts an event.Context to its serializable form
+func NewEnvelopeContext(c event.Context) EnvelopeContext {
+	return EnvelopeContext{
+		ID:      c.ID,
+		Source:  c.Source.String(),
+		Type:    c.Type,
+		Subject: c.Subject,
+		Time:    c.Time,
+		Labels:  maps.Clone(c.Labels),
+	}
+}
+
+// Seal renders the given notification for each of the given transports and wraps the result in an Envelope
+// If the notification carries anything which can't be serialized (like Slack options), it returns ErrUnserializable
+// along with an Envelope of everything else, for callers which only need a record of the notification.
+func Seal(n event.Notification, transports []event.TransportKey) (Envelope, error) {
+	env := Envelope{
+		Context:        NewEnvelopeContext(n.Context()),
+		Recipient:      n.Recipient().ToMap(),
+		DefaultMessage: n.Render(""),
+		Messages:       make(map[event.TransportKey]string, len(transports)),
+	}
+
+	for _, key := range transports {
+		if message := n.Render(key); message != env.DefaultMessage {
+			env.Messages[key] = message
+		}
+	}
+
+	env.Content = content.Of(n).Copy()
+
+	var errs []error
+	if n, ok := n.(extension.Notification); ok && len(n.GetExtensions()) > 0 {
+		env.Extensions = make(map[string]json.RawMessage, len(n.GetExtensions()))
+		for name, value := range n.GetExtensions() {
+			data, err := json.Marshal(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%w: extension %q: %w", ErrUnserializable, name, err))
+				continue
+			}
+			env.Extensions[name] = data
+		}
+	}
+
+	if n, ok := n.(slack2.RichNotification); ok && len(n.GetSlackOptions()) > 0 {
+		errs = append(errs, fmt.Errorf("%w: slack options can't be preserved", ErrUnserializable))
+	}
+
+	return env, errors.Join(errs...)
+}
+
+// Open reconstructs a notification from the Envelope
+func (e Envelope) Open() event.Notification {
+	nctx := event.Context{
+		ID:      e.Context.ID,
+		Type:    e.Context.Type,
+		Subject: e.Context.Subject,
+		Time:    e.Context.Time,
+		Labels:  maps.Clone(e.Context.Labels),
+	}
+	if src := event.NewSource(e.Context.Source); src != nil {
+		nctx.Source = *src
+	}
+
+	b := NewBuilder(nctx).
+		WithRecipient(identifier.NewSetFromMap(e.Recipient)).
+		WithDefaultMessage(e.DefaultMessage)
+
+	if e.Content != nil {
+		b.WithContent(*e.Content)
+	}
+
+	for key, message := range e.Messages {
+		b.WithMessageForTransport(key, message)
+	}
+
+	for name, data := range e.Extensions {
+		value, ok, err := extension.Unmarshal(name, data)
+		if err != nil {
+			slog.Warn("failed to decode notification extension", "id", e.Context.ID, "extension", name, "error", err)
+			continue
+		}
+		if !ok {
+			// Keep extensions for transports this program doesn't have, so they aren't lost if the notification is sealed again
+			value = data
+		}
+		b.opts.extensions[name] = value
+	}
+
+	return b.Build()
+}
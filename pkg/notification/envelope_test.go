@@ -0,0 +1,310 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package notification_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/content"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notification/extension"
+	"github.com/seatgeek/mailroom/pkg/notifier/discord"
+	"github.com/seatgeek/mailroom/pkg/notifier/email"
+	"github.com/seatgeek/mailroom/pkg/notifier/mattermost"
+	"github.com/seatgeek/mailroom/pkg/notifier/push"
+	slack2 "github.com/seatgeek/mailroom/pkg/notifier/slack"
+	"github.com/seatgeek/mailroom/pkg/notifier/teams"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvelope_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original := notification.NewBuilder(event.Context{
+		ID:      "a1c11a53-c4be-488f-89b6-f83bf2d48dab",
+		Source:  event.MustSource("https://gitlab.com/seatgeek/mailroom"),
+		Type:    "com.example.test",
+		Subject: "some subject",
+		Time:    time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		Labels:  map[string]string{"foo": "bar"},
+	}).
+		WithRecipientIdentifiers(identifier.New(identifier.GenericUsername, "codell")).
+		WithDefaultMessage("Hello, world!").
+		WithMessageForTransport("email", "Hello, email!").
+		Build()
+
+	env, err := notification.Seal(original, []event.TransportKey{"email", "slack"})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[event.TransportKey]string{"email": "Hello, email!"}, env.Messages, "only messages which differ from the default should be stored")
+
+	serialized, err := json.Marshal(env)
+	require.NoError(t, err)
+
+	var deserialized notification.Envelope
+	require.NoError(t, json.Unmarshal(serialized, &deserialized))
+
+	opened := deserialized.Open()
+
+	assert.Equal(t, original.Context(), opened.Context())
+	assert.Equal(t, original.Recipient().ToMap(), opened.Recipient().ToMap())
+	assert.Equal(t, "Hello, email!", opened.Render("email"))
+	assert.Equal(t, "Hello, world!", opened.Render("slack"))
+	assert.Equal(t, "Hello, world!", opened.Render("writer"))
+}
+
+func TestEnvelope_RoundTrip_RichFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		build func(b *notification.Builder)
+		check func(t *testing.T, opened event.Notification)
+	}{
+		{
+			name: "email subject and HTML",
+			build: func(b *notification.Builder) {
+				b.WithExtensions(email.Subject.Value("Hello!"), email.HTMLBody.Value("<p>Hello, <b>email</b>!</p>"))
+			},
+			check: func(t *testing.T, opened event.Notification) {
+				t.Helper()
+
+				subject, _ := email.Subject.Of(opened)
+				html, _ := email.HTMLBody.Of(opened)
+				assert.Equal(t, "Hello!", subject)
+				assert.Equal(t, "<p>Hello, <b>email</b>!</p>", html)
+			},
+		},
+		{
+			name: "Teams card",
+			build: func(b *notification.Builder) {
+				b.WithExtensions(teams.CustomCard.Value(teamsCard()))
+			},
+			check: func(t *testing.T, opened event.Notification) {
+				t.Helper()
+
+				card, _ := teams.CustomCard.Of(opened)
+				assert.Equal(t, teamsCard(), card)
+			},
+		},
+		{
+			name: "Discord embeds",
+			build: func(b *notification.Builder) {
+				b.WithExtensions(discord.Embeds.Value([]discord.Embed{discordEmbed()}))
+			},
+			check: func(t *testing.T, opened event.Notification) {
+				t.Helper()
+
+				embeds, _ := discord.Embeds.Of(opened)
+				assert.Equal(t, []discord.Embed{discordEmbed()}, embeds)
+			},
+		},
+		{
+			name: "Mattermost attachments",
+			build: func(b *notification.Builder) {
+				b.WithExtensions(mattermost.Attachments.Value([]mattermost.Attachment{mattermostAttachment()}))
+			},
+			check: func(t *testing.T, opened event.Notification) {
+				t.Helper()
+
+				attachments, _ := mattermost.Attachments.Of(opened)
+				assert.Equal(t, []mattermost.Attachment{mattermostAttachment()}, attachments)
+			},
+		},
+		{
+			name: "push message",
+			build: func(b *notification.Builder) {
+				b.WithExtensions(push.Details.Value(pushMessage()))
+			},
+			check: func(t *testing.T, opened event.Notification) {
+				t.Helper()
+
+				details, _ := push.Details.Of(opened)
+				assert.Equal(t, pushMessage(), details)
+			},
+		},
+		{
+			name: "Slack target",
+			build: func(b *notification.Builder) {
+				b.WithExtensions(slack2.Destination.Value(slackTarget()))
+			},
+			check: func(t *testing.T, opened event.Notification) {
+				t.Helper()
+
+				target, _ := slack2.Destination.Of(opened)
+				assert.Equal(t, slackTarget(), target)
+			},
+		},
+		{
+			name: "content",
+			build: func(b *notification.Builder) {
+				b.WithContent(someContent())
+			},
+			check: func(t *testing.T, opened event.Notification) {
+				t.Helper()
+
+				assert.Equal(t, someContent(), *content.Of(opened))
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			b := notification.NewBuilder(event.Context{ID: "a1c11a53-c4be-488f-89b6-f83bf2d48dab"}).
+				WithRecipientIdentifiers(identifier.New(identifier.GenericUsername, "codell")).
+				WithDefaultMessage("Hello, world!")
+			tc.build(b)
+
+			env, err := notification.Seal(b.Build(), []event.TransportKey{"email"})
+			require.NoError(t, err)
+
+			serialized, err := json.Marshal(env)
+			require.NoError(t, err)
+
+			var deserialized notification.Envelope
+			require.NoError(t, json.Unmarshal(serialized, &deserialized))
+
+			tc.check(t, deserialized.Open())
+		})
+	}
+}
+
+func teamsCard() teams.AdaptiveCard {
+	return teams.AdaptiveCard{
+		"type":    "AdaptiveCard",
+		"version": "1.5",
+		"body": []any{
+			map[string]any{"type": "TextBlock", "text": "Hello, Teams!", "wrap": true},
+		},
+	}
+}
+
+func discordEmbed() discord.Embed {
+	return discord.Embed{
+		Title:     "Pipeline failed",
+		URL:       "https://gitlab.com/seatgeek/mailroom/-/pipelines/1234",
+		Color:     0xe74c3c,
+		Fields:    []discord.EmbedField{{Name: "Branch", Value: "main", Inline: true}},
+		Thumbnail: &discord.EmbedMedia{URL: "https://example.com/thumb.png"},
+		Footer:    &discord.EmbedFooter{Text: "GitLab"},
+	}
+}
+
+func mattermostAttachment() mattermost.Attachment {
+	return mattermost.Attachment{
+		Fallback:  "Pipeline failed",
+		Color:     "#e74c3c",
+		Title:     "Pipeline failed",
+		TitleLink: "https://gitlab.com/seatgeek/mailroom/-/pipelines/1234",
+		Fields:    []mattermost.AttachmentField{{Title: "Branch", Value: "main", Short: true}},
+	}
+}
+
+func pushMessage() push.Message {
+	badge := 3
+	return push.Message{
+		Title:       "Pipeline failed",
+		Body:        "Pipeline #1234 failed on main",
+		Badge:       &badge,
+		DeepLink:    "mailroom://pipelines/1234",
+		CollapseKey: "pipeline-1234",
+	}
+}
+
+func slackTarget() slack2.Target {
+	return slack2.Target{
+		Channel:    "C012AB3CD",
+		ThreadKey:  "mr-1",
+		Broadcast:  true,
+		MessageKey: "pipeline-1234",
+	}
+}
+
+func someContent() content.Content {
+	return content.Content{
+		Title:    "Pipeline failed",
+		Body:     "Pipeline #1234 failed on main",
+		Severity: content.SeverityCritical,
+		Fields:   []content.Field{{Name: "Branch", Value: "main", Short: true}},
+		Links:    []content.Link{{Text: "View pipeline", URL: "https://gitlab.com/seatgeek/mailroom/-/pipelines/1234"}},
+		Actions:  []content.Action{{ID: "retry", Text: "Retry", Value: "1234", Style: content.ActionStylePrimary}},
+		Image:    &content.Image{URL: "https://example.com/graph.png", AltText: "Graph"},
+	}
+}
+
+type someExtension struct {
+	Text  string `json:"text"`
+	Count int    `json:"count"`
+}
+
+var someExtensionKey = extension.NewKey[someExtension]("test.envelope")
+
+func TestEnvelope_RoundTrip_Extensions(t *testing.T) {
+	t.Parallel()
+
+	original := notification.NewBuilder(event.Context{ID: "a1c11a53-c4be-488f-89b6-f83bf2d48dab"}).
+		WithDefaultMessage("Hello, world!").
+		WithExtensions(
+			someExtensionKey.Value(someExtension{Text: "hello", Count: 2}),
+			extension.Value{Name: "test.unregistered", Value: map[string]any{"foo": "bar"}},
+		).
+		Build()
+
+	env, err := notification.Seal(original, []event.TransportKey{"email"})
+	require.NoError(t, err)
+
+	serialized, err := json.Marshal(env)
+	require.NoError(t, err)
+
+	var deserialized notification.Envelope
+	require.NoError(t, json.Unmarshal(serialized, &deserialized))
+
+	opened := deserialized.Open()
+
+	got, ok := someExtensionKey.Of(opened)
+	assert.True(t, ok)
+	assert.Equal(t, someExtension{Text: "hello", Count: 2}, got)
+
+	// Extensions nobody registered are kept as they were, so they survive being sealed again
+	resealed, err := notification.Seal(opened, []event.TransportKey{"email"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"foo": "bar"}`, string(resealed.Extensions["test.unregistered"]))
+}
+
+func TestSeal_Unserializable(t *testing.T) {
+	t.Parallel()
+
+	n := notification.NewBuilder(event.Context{ID: "a1c11a53-c4be-488f-89b6-f83bf2d48dab"}).
+		WithDefaultMessage("Hello, world!").
+		WithSlackOptions(slack.MsgOptionText("Hello, world!", false)).
+		Build()
+
+	env, err := notification.Seal(n, []event.TransportKey{"slack"})
+	assert.ErrorIs(t, err, notification.ErrUnserializable)
+
+	// Everything else is still there
+	assert.Equal(t, "Hello, world!", env.DefaultMessage)
+}
+
+func TestSeal_UnserializableExtension(t *testing.T) {
+	t.Parallel()
+
+	n := notification.NewBuilder(event.Context{ID: "a1c11a53-c4be-488f-89b6-f83bf2d48dab"}).
+		WithDefaultMessage("Hello, world!").
+		WithExtensions(extension.Value{Name: "test.unserializable", Value: func() {}}).
+		Build()
+
+	env, err := notification.Seal(n, []event.TransportKey{"email"})
+	assert.ErrorIs(t, err, notification.ErrUnserializable)
+	assert.Equal(t, "Hello, world!", env.DefaultMessage)
+}
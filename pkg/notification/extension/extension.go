@@ -0,0 +1,115 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package extension lets transports attach their own rich data (like an email subject or a Teams card) to notifications
+//
+// Each transport declares a Key for every kind of data it supports. Notifications carry the values by name, so the
+// notification package can build, copy and serialize them without knowing about every transport.
+package extension
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+)
+
+// Notification is implemented by notifications which carry extensions
+type Notification interface {
+	event.Notification
+	// GetExtensions returns the notification's extension values by name; they must not be modified
+	GetExtensions() map[string]any
+}
+
+// Value is the value of a single extension, as returned by Key.Value
+type Value struct {
+	Name  string
+	Value any
+}
+
+// Key identifies an extension whose values are of type T
+type Key[T any] struct {
+	name string
+}
+
+var (
+	registry  = map[string]func(json.RawMessage) (any, error){}
+	templates = map[string]string{}
+	mu        sync.RWMutex
+)
+
+// NewKey registers an extension with the given name, which must be unique (like "email.subject")
+// Values must be JSON-serializable, so that notifications can be queued and dead-lettered with them.
+func NewKey[T any](name string) Key[T] {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("extension %q is already registered", name))
+	}
+
+	registry[name] = func(data json.RawMessage) (any, error) {
+		var value T
+		err := json.Unmarshal(data, &value)
+		return value, err
+	}
+
+	return Key[T]{name: name}
+}
+
+// NewTemplateKey is like NewKey, but the extension can also be rendered from the named template (see template.Registry)
+func NewTemplateKey(name string, templateName string) Key[string] {
+	key := NewKey[string](name)
+
+	mu.Lock()
+	defer mu.Unlock()
+	templates[templateName] = name
+
+	return key
+}
+
+// Name returns the name the extension was registered with
+func (k Key[T]) Name() string {
+	return k.name
+}
+
+// Value returns the given value for the extension, to be attached to a notification
+func (k Key[T]) Value(value T) Value {
+	return Value{Name: k.name, Value: value}
+}
+
+// Of returns the notification's value for the extension, or false if it doesn't have one
+func (k Key[T]) Of(notification event.Notification) (T, bool) {
+	if n, ok := notification.(Notification); ok {
+		value, ok := n.GetExtensions()[k.name].(T)
+		return value, ok
+	}
+
+	var zero T
+	return zero, false
+}
+
+// Unmarshal decodes a value of the named extension, or returns false if no such extension is registered
+func Unmarshal(name string, data json.RawMessage) (any, bool, error) {
+	mu.RLock()
+	unmarshal, ok := registry[name]
+	mu.RUnlock()
+
+	if !ok {
+		return nil, false, nil
+	}
+
+	value, err := unmarshal(data)
+	return value, true, err
+}
+
+// ForTemplate returns the name of the extension rendered from the named template, if any (see NewTemplateKey)
+func ForTemplate(templateName string) (string, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	name, ok := templates[templateName]
+	return name, ok
+}
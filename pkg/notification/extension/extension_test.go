@@ -0,0 +1,85 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package extension_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notification/extension"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type someValue struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+var (
+	someKey     = extension.NewKey[someValue]("test.some_value")
+	templateKey = extension.NewTemplateKey("test.templated", "templated.txt")
+)
+
+func TestKey(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "test.some_value", someKey.Name())
+
+	value := someKey.Value(someValue{Name: "foo", Count: 2})
+	assert.Equal(t, extension.Value{Name: "test.some_value", Value: someValue{Name: "foo", Count: 2}}, value)
+
+	got, ok := someKey.Of(notification.NewBuilder(event.Context{}).WithExtensions(value).Build())
+	assert.True(t, ok)
+	assert.Equal(t, someValue{Name: "foo", Count: 2}, got)
+
+	// Missing values, or values of the wrong type, aren't returned
+	_, ok = someKey.Of(notification.NewBuilder(event.Context{}).Build())
+	assert.False(t, ok)
+
+	_, ok = someKey.Of(notification.NewBuilder(event.Context{}).WithExtensions(extension.Value{Name: someKey.Name(), Value: "foo"}).Build())
+	assert.False(t, ok)
+}
+
+func TestNewKey_Duplicate(t *testing.T) {
+	t.Parallel()
+
+	assert.Panics(t, func() {
+		extension.NewKey[string]("test.some_value")
+	})
+}
+
+func TestUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(someValue{Name: "foo", Count: 2})
+	require.NoError(t, err)
+
+	value, ok, err := extension.Unmarshal(someKey.Name(), data)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, someValue{Name: "foo", Count: 2}, value)
+
+	_, ok, err = extension.Unmarshal("test.unknown", data)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = extension.Unmarshal(someKey.Name(), json.RawMessage(`"not an object"`))
+	assert.Error(t, err)
+	assert.True(t, ok)
+}
+
+func TestForTemplate(t *testing.T) {
+	t.Parallel()
+
+	name, ok := extension.ForTemplate("templated.txt")
+	assert.True(t, ok)
+	assert.Equal(t, templateKey.Name(), name)
+
+	_, ok = extension.ForTemplate("default.txt")
+	assert.False(t, ok)
+}
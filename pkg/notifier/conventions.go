@@ -7,6 +7,7 @@ package notifier
 
 import (
 	"context"
+	"time"
 
 	"github.com/cenkalti/backoff/v5"
 	"github.com/seatgeek/mailroom/pkg/event"
@@ -17,6 +18,11 @@ func Permanent(err error) error {
 	return backoff.Permanent(err)
 }
 
+// RetryAfter returns an error which says that the notification should be retried, but not before the given delay
+func RetryAfter(delay time.Duration) error {
+	return &backoff.RetryAfterError{Duration: delay}
+}
+
 // Func is a function that sends a notification
 type Func func(context.Context, event.Notification) error
 
@@ -6,6 +6,7 @@ package notifier
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -62,10 +63,27 @@ type withRetry struct {
 	backoff  func() BackOff
 }
 
+// RetryError is returned by WithRetry when a notification could not be delivered.
+// It records how many attempts were made before giving up.
+type RetryError struct {
+	Attempts uint
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("gave up after %d attempt(s): %v", e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
 func (w *withRetry) Push(ctx context.Context, notification event.Notification) error {
+	var attempts uint
 	_, err := backoff.Retry(
 		ctx,
 		func() (bool, error) {
+			attempts++
 			return true, w.Transport.Push(ctx, notification)
 		},
 		backoff.WithMaxTries(w.maxTries),
@@ -74,8 +92,11 @@ func (w *withRetry) Push(ctx context.Context, notification event.Notification) e
 			slog.ErrorContext(ctx, "failed to push notification", "id", notification.Context().ID, "error", err, "next_retry_in", duration.String())
 		}),
 	)
+	if err != nil {
+		return &RetryError{Attempts: attempts, Err: err}
+	}
 
-	return err
+	return nil
 }
 
 func (w *withRetry) Validate(ctx context.Context) error {
@@ -62,7 +62,7 @@ func TestWithRetry(t *testing.T) {
 		name         string
 		maxTries     uint
 		givenErrs    []error
-		wantAttempts int
+		wantAttempts uint
 		wantErr      error
 	}{
 		{
@@ -9,6 +9,8 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"time"
 
 	"github.com/seatgeek/mailroom/pkg/event"
 	"github.com/seatgeek/mailroom/pkg/notifier/preference"
@@ -18,28 +20,99 @@ import (
 type DefaultNotifier struct {
 	transports  []Transport
 	preferences preference.Provider
+	observers   []Observer
+}
+
+// Attempt describes what happened when the DefaultNotifier routed a notification to a single transport
+type Attempt struct {
+	Notification event.Notification
+	Transport    event.TransportKey
+	// Wants is the recipient's preference for this transport, or nil if they had no explicit preference
+	Wants *bool
+	// Skipped is true if the notification was not sent because the recipient does not want it via this transport
+	Skipped bool
+	// Err is the error returned by the transport, if any
+	Err        error
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// Observer is notified about every Attempt made by the DefaultNotifier.
+// Implementations should not block for long, as they are called synchronously during delivery.
+type Observer interface {
+	Observe(ctx context.Context, attempt Attempt)
+}
+
+// TransportError is returned by DefaultNotifier.Push (joined with any others) for each transport which failed
+type TransportError struct {
+	Transport    event.TransportKey
+	Notification event.ID
+	Err          error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("transport %s failed for notification %s: %v", e.Transport, e.Notification, e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+type onlyTransportsKey struct{}
+
+// WithOnlyTransports returns a context which limits the DefaultNotifier to pushing via the given transports
+// This lets a notification be retried without sending it again via the transports which already succeeded.
+func WithOnlyTransports(ctx context.Context, transports ...event.TransportKey) context.Context {
+	return context.WithValue(ctx, onlyTransportsKey{}, transports)
+}
+
+// Option configures a DefaultNotifier
+type Option func(*DefaultNotifier)
+
+// WithObservers adds Observers which are notified about every delivery attempt
+func WithObservers(observers ...Observer) Option {
+	return func(d *DefaultNotifier) {
+		d.observers = append(d.observers, observers...)
+	}
 }
 
 func (d *DefaultNotifier) Push(ctx context.Context, notification event.Notification) error {
 	results := make([]error, 0, len(d.transports))
+	only, limited := ctx.Value(onlyTransportsKey{}).([]event.TransportKey)
 
 	for _, transport := range d.transports {
+		if limited && !slices.Contains(only, transport.Key()) {
+			continue
+		}
+
+		attempt := Attempt{
+			Notification: notification,
+			Transport:    transport.Key(),
+			StartedAt:    time.Now(),
+		}
+
 		wants := d.preferences.Wants(ctx, notification, transport.Key())
+		attempt.Wants = wants
 		if wants == nil {
 			slog.DebugContext(ctx, "no explicit preference for notification; sending anyway", "id", notification.Context().ID, "type", notification.Context().Type, "recipient", notification.Recipient().String(), "transport", transport.Key())
 			// No explicit preference, we assume the user wants it
 		} else if !*wants {
 			slog.DebugContext(ctx, "user does not want this notification via this transport", "id", notification.Context().ID, "type", notification.Context().Type, "recipient", notification.Recipient().String(), "transport", transport.Key())
+			attempt.Skipped = true
+			d.observe(ctx, attempt)
 			continue // User does not want this transport
 		}
 
 		slog.InfoContext(ctx, "pushing notification to transport", "id", notification.Context().ID, "type", notification.Context().Type, "recipient", notification.Recipient().String(), "transport", transport.Key())
 		if err := transport.Push(ctx, notification); err != nil {
 			slog.ErrorContext(ctx, "failed to push notification via transport", "id", notification.Context().ID, "recipient", notification.Recipient().String(), "transport", transport.Key(), "error", err)
-			results = append(results, fmt.Errorf("transport %s failed for notification %s: %w", transport.Key(), notification.Context().ID, err))
+			results = append(results, &TransportError{Transport: transport.Key(), Notification: notification.Context().ID, Err: err})
+			attempt.Err = err
 		} else {
 			results = append(results, nil)
 		}
+
+		d.observe(ctx, attempt)
 	}
 
 	if len(results) == 0 {
@@ -49,12 +122,25 @@ func (d *DefaultNotifier) Push(ctx context.Context, notification event.Notificat
 	return errors.Join(results...)
 }
 
+func (d *DefaultNotifier) observe(ctx context.Context, attempt Attempt) {
+	attempt.FinishedAt = time.Now()
+	for _, o := range d.observers {
+		o.Observe(ctx, attempt)
+	}
+}
+
 var _ Notifier = &DefaultNotifier{}
 
 // New creates a new DefaultNotifier
-func New(transports []Transport, preferences preference.Provider) *DefaultNotifier {
-	return &DefaultNotifier{
+func New(transports []Transport, preferences preference.Provider, opts ...Option) *DefaultNotifier {
+	d := &DefaultNotifier{
 		transports:  transports,
 		preferences: preferences,
 	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
 }
@@ -92,6 +92,75 @@ func TestDefaultNotifier_Push(t *testing.T) {
 	}
 }
 
+func TestDefaultNotifier_Observers(t *testing.T) {
+	t.Parallel()
+
+	prefs := preference.Map{
+		someEventType: {
+			"slack": false,
+		},
+	}
+
+	observer := &fakeObserver{}
+	n := notifier.New([]notifier.Transport{
+		&fakeTransport{key: "email"},
+		&fakeTransport{key: "slack"},
+		&fakeTransport{key: "sms", returns: errSomethingFailed},
+	}, prefs, notifier.WithObservers(observer))
+
+	_ = n.Push(t.Context(), notificationFor(someEventType, identifier.NewSet()))
+
+	if assert.Len(t, observer.attempts, 3) {
+		assert.Equal(t, event.TransportKey("email"), observer.attempts[0].Transport)
+		assert.Nil(t, observer.attempts[0].Wants)
+		assert.False(t, observer.attempts[0].Skipped)
+		assert.NoError(t, observer.attempts[0].Err)
+
+		assert.Equal(t, event.TransportKey("slack"), observer.attempts[1].Transport)
+		assert.False(t, *observer.attempts[1].Wants)
+		assert.True(t, observer.attempts[1].Skipped)
+
+		assert.Equal(t, event.TransportKey("sms"), observer.attempts[2].Transport)
+		assert.ErrorIs(t, observer.attempts[2].Err, errSomethingFailed)
+
+		for _, a := range observer.attempts {
+			assert.False(t, a.FinishedAt.Before(a.StartedAt))
+		}
+	}
+}
+
+func TestDefaultNotifier_Push_OnlyTransports(t *testing.T) {
+	t.Parallel()
+
+	email := &fakeTransport{key: "email"}
+	slack := &fakeTransport{key: "slack"}
+	sms := &fakeTransport{key: "sms", returns: errSomethingFailed}
+	n := notifier.New([]notifier.Transport{email, slack, sms}, preference.Default(true))
+
+	ctx := notifier.WithOnlyTransports(t.Context(), "email", "sms")
+	err := n.Push(ctx, notificationFor(someEventType, identifier.NewSet()))
+
+	// Only the given transports are used
+	assert.Equal(t, []event.Type{someEventType}, email.sent)
+	assert.Empty(t, slack.sent)
+
+	// Failures say which transport failed
+	var transportErr *notifier.TransportError
+	if assert.ErrorAs(t, err, &transportErr) {
+		assert.Equal(t, event.TransportKey("sms"), transportErr.Transport)
+		assert.Equal(t, event.ID(someEventType), transportErr.Notification)
+		assert.ErrorIs(t, transportErr, errSomethingFailed)
+	}
+}
+
+type fakeObserver struct {
+	attempts []notifier.Attempt
+}
+
+func (f *fakeObserver) Observe(_ context.Context, attempt notifier.Attempt) {
+	f.attempts = append(f.attempts, attempt)
+}
+
 func assertSent(t *testing.T, want []wantSent, transports []notifier.Transport) {
 	t.Helper()
 
@@ -0,0 +1,247 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package discord provides a notifier.Transport implementation for sending notifications to Discord
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification/extension"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/seatgeek/mailroom/pkg/validation"
+)
+
+// ID is the identifier for a Discord user ID (a "snowflake")
+var ID = identifier.NewNamespaceAndKind("discord.com", identifier.KindID)
+
+// DefaultBaseURL is the Discord REST API endpoint
+const DefaultBaseURL = "https://discord.com/api/v10"
+
+// Embed is a Discord rich embed
+// See https://discord.com/developers/docs/resources/message#embed-object
+type Embed struct {
+	Title       string       `json:"title,omitempty"`
+	Description string       `json:"description,omitempty"`
+	URL         string       `json:"url,omitempty"`
+	Color       int          `json:"color,omitempty"`
+	Timestamp   string       `json:"timestamp,omitempty"`
+	Fields      []EmbedField `json:"fields,omitempty"`
+	Image       *EmbedMedia  `json:"image,omitempty"`
+	Thumbnail   *EmbedMedia  `json:"thumbnail,omitempty"`
+	Footer      *EmbedFooter `json:"footer,omitempty"`
+}
+
+// EmbedField is a name/value pair displayed in an Embed
+type EmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+// EmbedMedia is an image or thumbnail displayed in an Embed
+type EmbedMedia struct {
+	URL string `json:"url"`
+}
+
+// EmbedFooter is the footer of an Embed
+type EmbedFooter struct {
+	Text string `json:"text"`
+}
+
+// Embeds sets the embeds to be sent along with the message (see notification.Builder.WithExtensions)
+var Embeds = extension.NewKey[[]Embed]("discord.embeds")
+
+// Transport supports sending messages to Discord as a bot
+type Transport struct {
+	key             event.TransportKey
+	token           string
+	baseURL         string
+	client          *http.Client
+	fallbackChannel string
+
+	mu         sync.Mutex
+	dmChannels map[string]string // user ID -> DM channel ID
+}
+
+var (
+	_ notifier.Transport   = &Transport{}
+	_ validation.Validator = &Transport{}
+)
+
+// Option configures a Transport
+type Option func(*Transport)
+
+// WithFallbackChannel posts to the given channel ID when a recipient can't be messaged directly,
+// either because they don't have a Discord ID or because they don't accept DMs from the bot
+func WithFallbackChannel(channelID string) Option {
+	return func(t *Transport) {
+		t.fallbackChannel = channelID
+	}
+}
+
+// WithBaseURL overrides the Discord API endpoint
+func WithBaseURL(baseURL string) Option {
+	return func(t *Transport) {
+		t.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient sets the http.Client used to talk to Discord
+func WithHTTPClient(client *http.Client) Option {
+	return func(t *Transport) {
+		t.client = client
+	}
+}
+
+// NewTransport creates a new Discord Transport
+// It requires a TransportKey, a bot token, and optionally some Options
+func NewTransport(key event.TransportKey, token string, opts ...Option) *Transport {
+	t := &Transport{
+		key:        key,
+		token:      token,
+		baseURL:    DefaultBaseURL,
+		client:     http.DefaultClient,
+		dmChannels: make(map[string]string),
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+type message struct {
+	Content string  `json:"content,omitempty"`
+	Embeds  []Embed `json:"embeds,omitempty"`
+}
+
+// Push sends a notification to a Discord user
+// In addition to supporting event.Notification, it also supports the Embeds extension.
+func (t *Transport) Push(ctx context.Context, notification event.Notification) error {
+	msg := message{Content: notification.Render(t.key)}
+	msg.Embeds, _ = Embeds.Of(notification)
+
+	id, ok := notification.Recipient().Get(ID)
+	if !ok {
+		if t.fallbackChannel == "" {
+			return notifier.Permanent(errors.New("recipient does not have a Discord ID"))
+		}
+
+		return t.post(ctx, t.fallbackChannel, msg)
+	}
+
+	err := t.sendDM(ctx, id, msg)
+	if t.fallbackChannel != "" && isForbidden(err) {
+		slog.InfoContext(ctx, "unable to DM Discord user, using fallback channel", "user", id, "error", err)
+		msg.Content = fmt.Sprintf("<@%s> %s", id, msg.Content)
+		return t.post(ctx, t.fallbackChannel, msg)
+	}
+
+	return err
+}
+
+func (t *Transport) Key() event.TransportKey {
+	return t.key
+}
+
+// Validate checks the bot token by fetching the bot's own user
+func (t *Transport) Validate(ctx context.Context) error {
+	var me struct {
+		ID       string `json:"id"`
+		Username string `json:"username"`
+	}
+	if err := t.do(ctx, http.MethodGet, "/users/@me", nil, &me); err != nil {
+		return notifier.Permanent(fmt.Errorf("authentication failed: %w", err))
+	}
+
+	slog.InfoContext(ctx, "Discord transport connected", "transport", t.key, "discord_user", me.Username)
+	return nil
+}
+
+func (t *Transport) sendDM(ctx context.Context, userID string, msg message) error {
+	channel, err := t.dmChannel(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to open DM channel: %w", err)
+	}
+
+	return t.post(ctx, channel, msg)
+}
+
+// dmChannel returns the ID of the DM channel with the given user, creating it if needed
+func (t *Transport) dmChannel(ctx context.Context, userID string) (string, error) {
+	t.mu.Lock()
+	channel, ok := t.dmChannels[userID]
+	t.mu.Unlock()
+	if ok {
+		return channel, nil
+	}
+
+	var resp struct {
+		ID string `json:"id"`
+	}
+	if err := t.do(ctx, http.MethodPost, "/users/@me/channels", map[string]string{"recipient_id": userID}, &resp); err != nil {
+		return "", err
+	}
+
+	t.mu.Lock()
+	t.dmChannels[userID] = resp.ID
+	t.mu.Unlock()
+
+	return resp.ID, nil
+}
+
+func (t *Transport) post(ctx context.Context, channelID string, msg message) error {
+	return t.do(ctx, http.MethodPost, "/channels/"+url.PathEscape(channelID)+"/messages", msg, nil)
+}
+
+func (t *Transport) do(ctx context.Context, method, path string, body any, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return notifier.Permanent(err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, t.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return notifier.Permanent(err)
+	}
+	req.Header.Set("Authorization", "Bot "+t.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := notifier.CheckHTTPResponse(resp); err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// isForbidden reports whether Discord refused to let the bot message a user (for example, because they disabled DMs)
+func isForbidden(err error) bool {
+	var statusErr *notifier.HTTPStatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusForbidden
+}
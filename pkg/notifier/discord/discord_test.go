@@ -0,0 +1,225 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package discord_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier/discord"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	token  = "some-token"
+	userID = "80351110224678912"
+)
+
+// fakeDiscord is a local stand-in for the Discord API
+type fakeDiscord struct {
+	*httptest.Server
+
+	// dmStatus is the status returned when opening a DM channel
+	dmStatus int
+
+	mu       sync.Mutex
+	messages map[string][]map[string]any // channel ID -> messages
+	dmsOpen  int
+}
+
+func newFakeDiscord(t *testing.T, dmStatus int) *fakeDiscord {
+	t.Helper()
+
+	f := &fakeDiscord{dmStatus: dmStatus, messages: make(map[string][]map[string]any)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/@me", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "1", "username": "mailroom"}`))
+	})
+	mux.HandleFunc("POST /users/@me/channels", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		f.mu.Lock()
+		f.dmsOpen++
+		f.mu.Unlock()
+
+		w.WriteHeader(f.dmStatus)
+		_, _ = w.Write([]byte(`{"id": "dm-` + body["recipient_id"] + `"}`))
+	})
+	mux.HandleFunc("POST /channels/{id}/messages", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		f.mu.Lock()
+		f.messages[r.PathValue("id")] = append(f.messages[r.PathValue("id")], body)
+		f.mu.Unlock()
+
+		_, _ = w.Write([]byte(`{"id": "some-message"}`))
+	})
+
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bot "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message": "401: Unauthorized", "code": 0}`))
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+func (f *fakeDiscord) received(channelID string) []map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.messages[channelID]
+}
+
+func notificationFor(ids ...identifier.Identifier) *notification.Builder {
+	return notification.NewBuilder(event.Context{Type: "com.example.test"}).
+		WithRecipientIdentifiers(ids...).
+		WithDefaultMessage("Hello, world!")
+}
+
+func TestTransport_Push(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sends a DM", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeDiscord(t, http.StatusOK)
+		transport := discord.NewTransport("discord", token, discord.WithBaseURL(server.URL))
+
+		require.NoError(t, transport.Push(t.Context(), notificationFor(identifier.New(discord.ID, userID)).Build()))
+		require.NoError(t, transport.Push(t.Context(), notificationFor(identifier.New(discord.ID, userID)).
+			WithExtensions(discord.Embeds.Value([]discord.Embed{{
+				Title:  "Review requested",
+				URL:    "https://example.com/pr/1",
+				Color:  0x5865F2,
+				Fields: []discord.EmbedField{{Name: "Author", Value: "rufus", Inline: true}},
+			}})).
+			Build()))
+
+		// The DM channel is only opened once
+		assert.Equal(t, 1, server.dmsOpen)
+
+		messages := server.received("dm-" + userID)
+		require.Len(t, messages, 2)
+		assert.Equal(t, map[string]any{"content": "Hello, world!"}, messages[0])
+
+		got, _ := json.Marshal(messages[1])
+		assert.JSONEq(t, `{
+			"content": "Hello, world!",
+			"embeds": [{
+				"title": "Review requested",
+				"url": "https://example.com/pr/1",
+				"color": 5793266,
+				"fields": [{"name": "Author", "value": "rufus", "inline": true}]
+			}]
+		}`, string(got))
+	})
+
+	t.Run("recipients without a Discord ID use the fallback channel", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeDiscord(t, http.StatusOK)
+		transport := discord.NewTransport("discord", token, discord.WithBaseURL(server.URL), discord.WithFallbackChannel("some-channel"))
+
+		require.NoError(t, transport.Push(t.Context(), notificationFor(identifier.New(identifier.GenericEmail, "rufus@example.com")).Build()))
+
+		assert.Equal(t, []map[string]any{{"content": "Hello, world!"}}, server.received("some-channel"))
+	})
+
+	t.Run("recipients who don't accept DMs are mentioned in the fallback channel", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeDiscord(t, http.StatusForbidden)
+		transport := discord.NewTransport("discord", token, discord.WithBaseURL(server.URL), discord.WithFallbackChannel("some-channel"))
+
+		require.NoError(t, transport.Push(t.Context(), notificationFor(identifier.New(discord.ID, userID)).Build()))
+
+		assert.Equal(t, []map[string]any{{"content": "<@" + userID + "> Hello, world!"}}, server.received("some-channel"))
+	})
+}
+
+func TestTransport_Push_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		token         string
+		dmStatus      int
+		recipient     identifier.Identifier
+		wantErr       string
+		wantPermanent bool
+	}{
+		{
+			name:          "recipient without Discord ID",
+			token:         token,
+			dmStatus:      http.StatusOK,
+			recipient:     identifier.New(identifier.GenericEmail, "rufus@example.com"),
+			wantErr:       "recipient does not have a Discord ID",
+			wantPermanent: true,
+		},
+		{
+			name:          "DMs disabled without a fallback channel",
+			token:         token,
+			dmStatus:      http.StatusForbidden,
+			recipient:     identifier.New(discord.ID, userID),
+			wantErr:       "failed to open DM channel: received HTTP status 403",
+			wantPermanent: true,
+		},
+		{
+			name:      "rate limited",
+			token:     token,
+			dmStatus:  http.StatusTooManyRequests,
+			recipient: identifier.New(discord.ID, userID),
+			wantErr:   "received HTTP status 429",
+		},
+		{
+			name:          "invalid token",
+			token:         "wrong",
+			recipient:     identifier.New(discord.ID, userID),
+			wantErr:       "received HTTP status 401",
+			wantPermanent: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newFakeDiscord(t, tc.dmStatus)
+			transport := discord.NewTransport("discord", tc.token, discord.WithBaseURL(server.URL))
+
+			err := transport.Push(t.Context(), notificationFor(tc.recipient).Build())
+			assert.ErrorContains(t, err, tc.wantErr)
+
+			var permanent *backoff.PermanentError
+			assert.Equal(t, tc.wantPermanent, errors.As(err, &permanent))
+		})
+	}
+}
+
+func TestTransport_Validate(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeDiscord(t, http.StatusOK)
+
+	assert.NoError(t, discord.NewTransport("discord", token, discord.WithBaseURL(server.URL)).Validate(t.Context()))
+	assert.ErrorContains(t, discord.NewTransport("discord", "wrong", discord.WithBaseURL(server.URL)).Validate(t.Context()), "authentication failed")
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package email
+
+import (
+	"bytes"
+	"html/template"
+	"strings"
+
+	"github.com/seatgeek/mailroom/pkg/content"
+)
+
+var severityColors = map[content.Severity]string{
+	content.SeverityInfo:     "#2563eb",
+	content.SeveritySuccess:  "#16a34a",
+	content.SeverityWarning:  "#d97706",
+	content.SeverityCritical: "#dc2626",
+}
+
+// Email clients ignore most CSS, so everything is styled inline
+var contentTemplate = template.Must(template.New("content").Funcs(template.FuncMap{
+	"lines": func(s string) []string { return strings.Split(s, "\n") },
+}).Parse(`<div style="font-family: sans-serif; font-size: 14px; color: #111827; border-left: 4px solid {{ .Color }}; padding-left: 12px">
+{{- with .Title }}
+<h2 style="font-size: 18px; margin: 0 0 8px">{{ . }}</h2>
+{{- end }}
+{{- with .Body }}
+<p style="margin: 0 0 12px">{{ range $i, $line := lines . }}{{ if $i }}<br>{{ end }}{{ $line }}{{ end }}</p>
+{{- end }}
+{{- with .Fields }}
+<table style="border-collapse: collapse; margin: 0 0 12px">
+{{- range . }}
+<tr><th style="text-align: left; padding: 2px 12px 2px 0">{{ .Name }}</th><td style="padding: 2px 0">{{ .Value }}</td></tr>
+{{- end }}
+</table>
+{{- end }}
+{{- with .Image }}
+<p style="margin: 0 0 12px"><img src="{{ .URL }}" alt="{{ .AltText }}" style="max-width: 100%"></p>
+{{- end }}
+{{- with .Links }}
+<p style="margin: 0 0 12px">
+{{- range $i, $link := . }}{{ if $i }} · {{ end }}<a href="{{ $link.URL }}">{{ or $link.Text $link.URL }}</a>{{ end -}}
+</p>
+{{- end }}
+{{- with .Buttons }}
+<p style="margin: 0">
+{{- range . }}
+<a href="{{ .URL }}" style="display: inline-block; padding: 8px 16px; margin-right: 8px; border-radius: 4px; text-decoration: none; {{ .Style }}">{{ .Text }}</a>
+{{- end }}
+</p>
+{{- end }}
+</div>`))
+
+type contentView struct {
+	*content.Content
+	Color   template.CSS
+	Buttons []button
+}
+
+type button struct {
+	Text  string
+	URL   string
+	Style template.CSS
+}
+
+// HTML renders structured content as the HTML body of an email
+// Interactive actions are left out, since they can't be handled by email; actions with a URL become links styled as buttons.
+func HTML(c *content.Content) string {
+	if c == nil {
+		return ""
+	}
+
+	view := contentView{Content: c, Color: "#d1d5db"}
+	if color, ok := severityColors[c.Severity]; ok {
+		view.Color = template.CSS(color)
+	}
+
+	for _, a := range c.Actions {
+		if a.URL == "" {
+			continue
+		}
+
+		style := template.CSS("background: #e5e7eb; color: #111827")
+		switch a.Style {
+		case content.ActionStylePrimary:
+			style = "background: #2563eb; color: #ffffff"
+		case content.ActionStyleDanger:
+			style = "background: #dc2626; color: #ffffff"
+		}
+		view.Buttons = append(view.Buttons, button{Text: a.Text, URL: a.URL, Style: style})
+	}
+
+	var buf bytes.Buffer
+	if err := contentTemplate.Execute(&buf, view); err != nil {
+		// The template is fixed, so this shouldn't happen; but if it does, a plain-text email is still sent
+		return ""
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package email_test
+
+import (
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/content"
+	"github.com/seatgeek/mailroom/pkg/notifier/email"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTML(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, email.HTML(nil))
+
+	got := email.HTML(&content.Content{
+		Title:    "Pipeline failed on <main>",
+		Body:     "The build job failed\nSee the logs for details",
+		Severity: content.SeverityCritical,
+		Fields: []content.Field{
+			{Name: "Project", Value: "seatgeek/mailroom"},
+		},
+		Links: []content.Link{
+			{Text: "Pipeline", URL: "https://gitlab.example.com/pipelines/1234"},
+			{URL: "https://gitlab.example.com/commits/abc123"},
+		},
+		Actions: []content.Action{
+			{Text: "Retry", URL: "https://gitlab.example.com/pipelines/1234/retry?force=1&all=1", Style: content.ActionStylePrimary},
+			{ID: "mute", Text: "Mute"},
+		},
+		Image: &content.Image{URL: "https://example.com/graph.png", AltText: "Build times"},
+	})
+
+	assert.Equal(t, `<div style="font-family: sans-serif; font-size: 14px; color: #111827; border-left: 4px solid #dc2626; padding-left: 12px">
+<h2 style="font-size: 18px; margin: 0 0 8px">Pipeline failed on &lt;main&gt;</h2>
+<p style="margin: 0 0 12px">The build job failed<br>See the logs for details</p>
+<table style="border-collapse: collapse; margin: 0 0 12px">
+<tr><th style="text-align: left; padding: 2px 12px 2px 0">Project</th><td style="padding: 2px 0">seatgeek/mailroom</td></tr>
+</table>
+<p style="margin: 0 0 12px"><img src="https://example.com/graph.png" alt="Build times" style="max-width: 100%"></p>
+<p style="margin: 0 0 12px"><a href="https://gitlab.example.com/pipelines/1234">Pipeline</a> · <a href="https://gitlab.example.com/commits/abc123">https://gitlab.example.com/commits/abc123</a></p>
+<p style="margin: 0">
+<a href="https://gitlab.example.com/pipelines/1234/retry?force=1&amp;all=1" style="display: inline-block; padding: 8px 16px; margin-right: 8px; border-radius: 4px; text-decoration: none; background: #2563eb; color: #ffffff">Retry</a>
+</p>
+</div>`, got)
+}
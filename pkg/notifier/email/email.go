@@ -0,0 +1,285 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package email provides a notifier.Transport implementation for sending notifications over SMTP
+package email
+
+import (
+	"cmp"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"slices"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification/extension"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/seatgeek/mailroom/pkg/template"
+	"github.com/seatgeek/mailroom/pkg/validation"
+)
+
+// Security determines how the connection to the SMTP server is secured
+type Security int
+
+const (
+	// SecurityNone sends mail over a plaintext connection
+	SecurityNone Security = iota
+	// SecurityStartTLS upgrades a plaintext connection using the STARTTLS command
+	SecurityStartTLS
+	// SecurityImplicitTLS connects using TLS from the start (typically port 465)
+	SecurityImplicitTLS
+)
+
+var (
+	// Subject sets the subject line of the email; it can also be rendered from the template.EmailSubject template
+	Subject = extension.NewTemplateKey("email.subject", template.EmailSubject)
+	// HTMLBody sets the HTML part of the email; it can also be rendered from the template.EmailHTML template
+	// The plain-text part of the email is always the result of Render.
+	HTMLBody = extension.NewTemplateKey("email.html", template.EmailHTML)
+)
+
+// Transport supports sending messages via SMTP
+type Transport struct {
+	key       event.TransportKey
+	addr      string
+	from      string
+	security  Security
+	tlsConfig *tls.Config
+	username  string
+	password  string
+}
+
+// Option configures a Transport
+type Option func(*Transport)
+
+// WithStartTLS upgrades the connection using STARTTLS before authenticating or sending mail
+func WithStartTLS() Option {
+	return func(t *Transport) {
+		t.security = SecurityStartTLS
+	}
+}
+
+// WithImplicitTLS connects to the server over TLS from the start
+func WithImplicitTLS() Option {
+	return func(t *Transport) {
+		t.security = SecurityImplicitTLS
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used for STARTTLS or implicit TLS
+// If the config has no ServerName, the host from the server address is used.
+func WithTLSConfig(config *tls.Config) Option {
+	return func(t *Transport) {
+		t.tlsConfig = config
+	}
+}
+
+// WithAuth authenticates to the server using PLAIN auth
+// Note that net/smtp refuses to send credentials over an unencrypted connection unless the server is on localhost.
+func WithAuth(username, password string) Option {
+	return func(t *Transport) {
+		t.username = username
+		t.password = password
+	}
+}
+
+// NewTransport creates a new email Transport
+// It requires a TransportKey, the SMTP server address (host:port), the sender address, and optionally some Options
+func NewTransport(key event.TransportKey, addr string, from string, opts ...Option) *Transport {
+	t := &Transport{
+		key:  key,
+		addr: addr,
+		from: from,
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+var (
+	_ notifier.Transport   = &Transport{}
+	_ validation.Validator = &Transport{}
+)
+
+// Push sends a notification as an email
+// In addition to supporting event.Notification, it also supports the Subject and HTMLBody extensions.
+func (t *Transport) Push(ctx context.Context, notification event.Notification) error {
+	to, ok := Address(notification.Recipient())
+	if !ok {
+		return notifier.Permanent(errors.New("recipient does not have an email address"))
+	}
+
+	msg, err := compose(t.from, to, notification, notification.Render(t.key))
+	if err != nil {
+		return notifier.Permanent(fmt.Errorf("failed to compose email: %w", err))
+	}
+
+	return t.send(ctx, to, msg)
+}
+
+func (t *Transport) Key() event.TransportKey {
+	return t.key
+}
+
+// Validate connects to the SMTP server (and authenticates, if configured) to verify the configuration
+func (t *Transport) Validate(ctx context.Context) error {
+	client, stop, err := t.connect(ctx)
+	if err != nil {
+		return notifier.Permanent(fmt.Errorf("failed to connect to SMTP server: %w", err))
+	}
+	defer stop()
+
+	if err := client.Quit(); err != nil {
+		return fmt.Errorf("failed to disconnect from SMTP server: %w", err)
+	}
+
+	slog.InfoContext(ctx, "email transport connected", "transport", t.key, "addr", t.addr)
+	return nil
+}
+
+// Address returns the email address to use for the given recipient
+// identifier.GenericEmail takes precedence; otherwise, any namespaced email (like "gitlab.com/email") is used.
+func Address(recipient identifier.Set) (string, bool) {
+	if addr, ok := recipient.Get(identifier.GenericEmail); ok && addr != "" {
+		return addr, true
+	}
+
+	// Sort the candidates so the choice is stable when multiple namespaces provide an email
+	var candidates []identifier.Identifier
+	for _, id := range recipient.ToList() {
+		if id.Kind() == identifier.KindEmail && id.Value != "" {
+			candidates = append(candidates, id)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	slices.SortFunc(candidates, func(a, b identifier.Identifier) int {
+		return cmp.Compare(a.NamespaceAndKind, b.NamespaceAndKind)
+	})
+
+	return candidates[0].Value, true
+}
+
+func (t *Transport) send(ctx context.Context, to string, msg []byte) error {
+	client, stop, err := t.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	// The envelope sender is the bare address; compose has already validated it
+	sender := t.from
+	if addr, err := mail.ParseAddress(t.from); err == nil {
+		sender = addr.Address
+	}
+
+	if err := client.Mail(sender); err != nil {
+		return classify(err)
+	}
+
+	if err := client.Rcpt(to); err != nil {
+		return classify(err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return classify(err)
+	}
+
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return classify(err)
+	}
+
+	return client.Quit()
+}
+
+// connect dials the server and performs the TLS and auth handshakes
+// The returned func must be called to release the connection.
+func (t *Transport) connect(ctx context.Context) (*smtp.Client, func(), error) {
+	host, _, err := net.SplitHostPort(t.addr)
+	if err != nil {
+		return nil, nil, notifier.Permanent(fmt.Errorf("invalid SMTP address %q: %w", t.addr, err))
+	}
+
+	var conn net.Conn
+	if t.security == SecurityImplicitTLS {
+		conn, err = (&tls.Dialer{Config: t.tlsConfigFor(host)}).DialContext(ctx, "tcp", t.addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", t.addr)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// net/smtp doesn't accept a context, so close the connection if the context is done first
+	stopAfter := context.AfterFunc(ctx, func() {
+		_ = conn.Close()
+	})
+	stop := func() {
+		stopAfter()
+		_ = conn.Close()
+	}
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		stop()
+		return nil, nil, err
+	}
+
+	if t.security == SecurityStartTLS {
+		if err := client.StartTLS(t.tlsConfigFor(host)); err != nil {
+			stop()
+			return nil, nil, fmt.Errorf("STARTTLS failed: %w", err)
+		}
+	}
+
+	if t.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", t.username, t.password, host)); err != nil {
+			stop()
+			return nil, nil, notifier.Permanent(fmt.Errorf("authentication failed: %w", err))
+		}
+	}
+
+	return client, stop, nil
+}
+
+func (t *Transport) tlsConfigFor(host string) *tls.Config {
+	if t.tlsConfig == nil {
+		return &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}
+	}
+
+	config := t.tlsConfig.Clone()
+	if config.ServerName == "" {
+		config.ServerName = host
+	}
+
+	return config
+}
+
+// classify marks permanent (5xx) SMTP replies as notifier.Permanent so they aren't retried
+func classify(err error) error {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) && protoErr.Code >= 500 {
+		return notifier.Permanent(err)
+	}
+
+	return err
+}
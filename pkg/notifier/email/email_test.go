@@ -0,0 +1,378 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package email_test
+
+import (
+	"crypto/tls"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/seatgeek/mailroom/pkg/content"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier/email"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const transportKey = event.TransportKey("email")
+
+// insecure trusts the fake server's self-signed certificate
+var insecure = email.WithTLSConfig(&tls.Config{InsecureSkipVerify: true}) //nolint:gosec // test server
+
+func TestAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		ids    []identifier.Identifier
+		want   string
+		wantOk bool
+	}{
+		{
+			name:   "generic email",
+			ids:    []identifier.Identifier{identifier.New(identifier.GenericEmail, "rufus@example.com")},
+			want:   "rufus@example.com",
+			wantOk: true,
+		},
+		{
+			name: "generic email takes precedence",
+			ids: []identifier.Identifier{
+				identifier.New("gitlab.com/email", "rufus@gitlab.example.com"),
+				identifier.New(identifier.GenericEmail, "rufus@example.com"),
+			},
+			want:   "rufus@example.com",
+			wantOk: true,
+		},
+		{
+			name: "namespaced email",
+			ids: []identifier.Identifier{
+				identifier.New("slack.com/id", "U123"),
+				identifier.New("gitlab.com/email", "rufus@gitlab.example.com"),
+				identifier.New("argocd/email", "rufus@argo.example.com"),
+			},
+			want:   "rufus@argo.example.com",
+			wantOk: true,
+		},
+		{
+			name: "no email",
+			ids:  []identifier.Identifier{identifier.New("slack.com/id", "U123")},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := email.Address(identifier.NewSet(tc.ids...))
+
+			assert.Equal(t, tc.wantOk, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestTransport_Push(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		serverOpts []serverOption
+		opts       []email.Option
+		to         string
+		wantTLS    bool
+		wantAuthed bool
+	}{
+		{
+			name: "plaintext",
+			to:   "rufus@example.com",
+		},
+		{
+			name:       "STARTTLS with auth",
+			serverOpts: []serverOption{withServerTLS(false), withServerAuth("mailroom", "hunter2")},
+			opts:       []email.Option{email.WithStartTLS(), insecure, email.WithAuth("mailroom", "hunter2")},
+			to:         "rufus@example.com",
+			wantTLS:    true,
+			wantAuthed: true,
+		},
+		{
+			name:       "implicit TLS",
+			serverOpts: []serverOption{withServerTLS(true)},
+			opts:       []email.Option{email.WithImplicitTLS(), insecure},
+			to:         "rufus@example.com",
+			wantTLS:    true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newFakeServer(t, tc.serverOpts...)
+			transport := email.NewTransport(transportKey, server.addr, "Mailroom <mailroom@example.com>", tc.opts...)
+
+			err := transport.Push(t.Context(), notification.NewBuilder(event.Context{Type: "com.example.test"}).
+				WithRecipientIdentifiers(identifier.New(identifier.GenericEmail, tc.to)).
+				WithDefaultMessage("Hello, world!").
+				Build())
+			require.NoError(t, err)
+
+			received := server.received()
+			require.Len(t, received, 1)
+			assert.Equal(t, "mailroom@example.com", received[0].from)
+			assert.Equal(t, tc.to, received[0].to)
+			assert.Equal(t, tc.wantTLS, received[0].tls)
+			assert.Equal(t, tc.wantAuthed, received[0].authed)
+		})
+	}
+}
+
+func TestTransport_Push_Content(t *testing.T) {
+	t.Parallel()
+
+	t.Run("plain text only", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeServer(t)
+		transport := email.NewTransport(transportKey, server.addr, "mailroom@example.com")
+
+		err := transport.Push(t.Context(), notification.NewBuilder(event.Context{Type: "com.example.test"}).
+			WithRecipientIdentifiers(identifier.New(identifier.GenericEmail, "rufus@example.com")).
+			WithDefaultMessage("Your pipeline failed\nSee the logs for details").
+			Build())
+		require.NoError(t, err)
+
+		msg := parse(t, server.received()[0].data)
+		assert.Equal(t, "Your pipeline failed", decodeHeader(t, msg.Header.Get("Subject")))
+		assert.Equal(t, "text/plain; charset=utf-8", msg.Header.Get("Content-Type"))
+		assert.NotEmpty(t, msg.Header.Get("Message-ID"))
+
+		body, err := io.ReadAll(quotedprintable.NewReader(msg.Body))
+		require.NoError(t, err)
+		assert.Equal(t, "Your pipeline failed\r\nSee the logs for details", strings.TrimSpace(string(body)))
+	})
+
+	t.Run("rich notification", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeServer(t)
+		transport := email.NewTransport(transportKey, server.addr, "mailroom@example.com")
+
+		err := transport.Push(t.Context(), notification.NewBuilder(event.Context{Type: "com.example.test"}).
+			WithRecipientIdentifiers(identifier.New("gitlab.com/email", "rufus@example.com")).
+			WithDefaultMessage("Fallback").
+			WithMessageForTransport(transportKey, "Plain text ✓").
+			WithExtensions(email.Subject.Value("Review requested: Fix the thing ✓"), email.HTMLBody.Value("<p>HTML <b>body</b></p>")).
+			Build())
+		require.NoError(t, err)
+
+		msg := parse(t, server.received()[0].data)
+		assert.Equal(t, "Review requested: Fix the thing ✓", decodeHeader(t, msg.Header.Get("Subject")))
+
+		assert.Equal(t, map[string]string{
+			"text/plain; charset=utf-8": "Plain text ✓",
+			"text/html; charset=utf-8":  "<p>HTML <b>body</b></p>",
+		}, readParts(t, msg))
+	})
+
+	t.Run("structured content", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeServer(t)
+		transport := email.NewTransport(transportKey, server.addr, "mailroom@example.com")
+
+		c := content.Content{Title: "Pipeline failed", Body: "The build job failed"}
+		err := transport.Push(t.Context(), notification.NewBuilder(event.Context{Type: "com.example.test"}).
+			WithRecipientIdentifiers(identifier.New(identifier.GenericEmail, "rufus@example.com")).
+			WithContent(c).
+			Build())
+		require.NoError(t, err)
+
+		msg := parse(t, server.received()[0].data)
+		assert.Equal(t, "Pipeline failed", decodeHeader(t, msg.Header.Get("Subject")))
+		assert.Equal(t, map[string]string{
+			"text/plain; charset=utf-8": "Pipeline failed\r\nThe build job failed",
+			"text/html; charset=utf-8":  strings.ReplaceAll(email.HTML(&c), "\n", "\r\n"),
+		}, readParts(t, msg))
+	})
+
+	t.Run("subject falls back to event type", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeServer(t)
+		transport := email.NewTransport(transportKey, server.addr, "mailroom@example.com")
+
+		err := transport.Push(t.Context(), notification.NewBuilder(event.Context{Type: "com.example.test"}).
+			WithRecipientIdentifiers(identifier.New(identifier.GenericEmail, "rufus@example.com")).
+			Build())
+		require.NoError(t, err)
+
+		msg := parse(t, server.received()[0].data)
+		assert.Equal(t, "com.example.test", decodeHeader(t, msg.Header.Get("Subject")))
+	})
+
+	t.Run("long first lines are truncated", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeServer(t)
+		transport := email.NewTransport(transportKey, server.addr, "mailroom@example.com")
+
+		err := transport.Push(t.Context(), notification.NewBuilder(event.Context{Type: "com.example.test"}).
+			WithRecipientIdentifiers(identifier.New(identifier.GenericEmail, "rufus@example.com")).
+			WithDefaultMessage(strings.Repeat("a", 100)).
+			Build())
+		require.NoError(t, err)
+
+		msg := parse(t, server.received()[0].data)
+		assert.Equal(t, strings.Repeat("a", 77)+"…", decodeHeader(t, msg.Header.Get("Subject")))
+	})
+}
+
+func TestTransport_Push_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		serverOpts    []serverOption
+		opts          []email.Option
+		recipient     identifier.Identifier
+		wantErr       string
+		wantPermanent bool
+	}{
+		{
+			name:          "recipient without email",
+			recipient:     identifier.New("slack.com/id", "U123"),
+			wantErr:       "recipient does not have an email address",
+			wantPermanent: true,
+		},
+		{
+			name:          "invalid address",
+			recipient:     identifier.New(identifier.GenericEmail, "not an email"),
+			wantErr:       "invalid recipient",
+			wantPermanent: true,
+		},
+		{
+			name:          "rejected recipient",
+			recipient:     identifier.New(identifier.GenericEmail, "unknown@example.com"),
+			wantErr:       "no such user",
+			wantPermanent: true,
+		},
+		{
+			name:      "temporary failure",
+			recipient: identifier.New(identifier.GenericEmail, "busy@example.com"),
+			wantErr:   "try again later",
+		},
+		{
+			name:          "bad credentials",
+			serverOpts:    []serverOption{withServerAuth("mailroom", "hunter2")},
+			opts:          []email.Option{email.WithAuth("mailroom", "wrong")},
+			recipient:     identifier.New(identifier.GenericEmail, "rufus@example.com"),
+			wantErr:       "authentication failed",
+			wantPermanent: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newFakeServer(t, tc.serverOpts...)
+			transport := email.NewTransport(transportKey, server.addr, "mailroom@example.com", tc.opts...)
+
+			err := transport.Push(t.Context(), notification.NewBuilder(event.Context{Type: "com.example.test"}).
+				WithRecipientIdentifiers(tc.recipient).
+				WithDefaultMessage("Hello").
+				Build())
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+
+			var permanent *backoff.PermanentError
+			assert.Equal(t, tc.wantPermanent, errors.As(err, &permanent))
+			assert.Empty(t, server.received())
+		})
+	}
+}
+
+func TestTransport_Validate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("connects and authenticates", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeServer(t, withServerTLS(false), withServerAuth("mailroom", "hunter2"))
+		transport := email.NewTransport(transportKey, server.addr, "mailroom@example.com", email.WithStartTLS(), insecure, email.WithAuth("mailroom", "hunter2"))
+
+		assert.NoError(t, transport.Validate(t.Context()))
+	})
+
+	t.Run("bad credentials", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeServer(t, withServerAuth("mailroom", "hunter2"))
+		transport := email.NewTransport(transportKey, server.addr, "mailroom@example.com", email.WithAuth("mailroom", "wrong"))
+
+		assert.ErrorContains(t, transport.Validate(t.Context()), "authentication failed")
+	})
+
+	t.Run("unreachable server", func(t *testing.T) {
+		t.Parallel()
+
+		transport := email.NewTransport(transportKey, "127.0.0.1:1", "mailroom@example.com")
+
+		assert.Error(t, transport.Validate(t.Context()))
+	})
+}
+
+func parse(t *testing.T, data string) *mail.Message {
+	t.Helper()
+
+	msg, err := mail.ReadMessage(strings.NewReader(data))
+	require.NoError(t, err)
+
+	return msg
+}
+
+// readParts returns the body of each part of a multipart/alternative message, by content type
+func readParts(t *testing.T, msg *mail.Message) map[string]string {
+	t.Helper()
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	parts := map[string]string{}
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart() // transparently decodes quoted-printable
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		parts[part.Header.Get("Content-Type")] = string(body)
+	}
+
+	return parts
+}
+
+func decodeHeader(t *testing.T, value string) string {
+	t.Helper()
+
+	decoded, err := new(mime.WordDecoder).DecodeHeader(value)
+	require.NoError(t, err)
+
+	return decoded
+}
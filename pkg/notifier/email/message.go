@@ -0,0 +1,136 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seatgeek/mailroom/pkg/content"
+	"github.com/seatgeek/mailroom/pkg/event"
+)
+
+// maxSubjectLength is the longest subject we'll derive from the message body
+const maxSubjectLength = 78
+
+// compose builds an RFC 5322 message for the given notification
+// Messages with an HTML part are sent as multipart/alternative; otherwise, a single text/plain part is used.
+func compose(from, to string, notification event.Notification, text string) ([]byte, error) {
+	fromAddr, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender %q: %w", from, err)
+	}
+
+	toAddr, err := mail.ParseAddress(to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient %q: %w", to, err)
+	}
+
+	subject, _ := Subject.Of(notification)
+	html, _ := HTMLBody.Of(notification)
+	if c := content.Of(notification); c != nil {
+		if subject == "" {
+			subject = c.Title
+		}
+		if html == "" {
+			html = HTML(c)
+		}
+	}
+	if subject == "" {
+		subject = defaultSubject(notification, text)
+	}
+
+	var buf bytes.Buffer
+	writeHeader(&buf, "From", fromAddr.String())
+	writeHeader(&buf, "To", toAddr.String())
+	writeHeader(&buf, "Subject", mime.QEncoding.Encode("utf-8", subject))
+	writeHeader(&buf, "Date", time.Now().Format(time.RFC1123Z))
+	writeHeader(&buf, "Message-ID", fmt.Sprintf("<%s@%s>", uuid.NewString(), domain(fromAddr.Address)))
+	writeHeader(&buf, "MIME-Version", "1.0")
+
+	if html == "" {
+		writeHeader(&buf, "Content-Type", "text/plain; charset=utf-8")
+		writeHeader(&buf, "Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeQuotedPrintable(&buf, text); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	}
+
+	mw := multipart.NewWriter(&buf)
+	writeHeader(&buf, "Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", mw.Boundary()))
+	buf.WriteString("\r\n")
+
+	// Clients prefer the last part they can display, so HTML goes last
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", text},
+		{"text/html; charset=utf-8", html},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if err := writeQuotedPrintable(w, part.body); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeHeader(buf *bytes.Buffer, key, value string) {
+	fmt.Fprintf(buf, "%s: %s\r\n", key, value)
+}
+
+func writeQuotedPrintable(w io.Writer, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return err
+	}
+
+	return qp.Close()
+}
+
+// defaultSubject uses the first line of the message, falling back to the event type
+func defaultSubject(notification event.Notification, text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return string(notification.Context().Type)
+	}
+
+	if runes := []rune(line); len(runes) > maxSubjectLength {
+		return string(runes[:maxSubjectLength-1]) + "…"
+	}
+
+	return line
+}
+
+func domain(address string) string {
+	if _, d, ok := strings.Cut(address, "@"); ok {
+		return d
+	}
+
+	return "localhost"
+}
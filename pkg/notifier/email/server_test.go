@@ -0,0 +1,231 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package email_test
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeServer is a minimal in-process SMTP server that records the messages it receives
+type fakeServer struct {
+	addr string
+
+	// tls enables STARTTLS (or implicit TLS, when implicit is set) using a self-signed certificate
+	tls      *tls.Config
+	implicit bool
+	// username and password, when set, are required via AUTH PLAIN
+	username, password string
+
+	mu       sync.Mutex
+	messages []receivedMessage
+}
+
+type receivedMessage struct {
+	from, to string
+	data     string
+	tls      bool
+	authed   bool
+}
+
+type serverOption func(*fakeServer)
+
+func withServerTLS(implicit bool) serverOption {
+	return func(s *fakeServer) {
+		s.tls = selfSignedConfig()
+		s.implicit = implicit
+	}
+}
+
+func withServerAuth(username, password string) serverOption {
+	return func(s *fakeServer) {
+		s.username = username
+		s.password = password
+	}
+}
+
+func newFakeServer(t *testing.T, opts ...serverOption) *fakeServer {
+	t.Helper()
+
+	s := &fakeServer{}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	var (
+		ln  net.Listener
+		err error
+	)
+	if s.implicit {
+		ln, err = tls.Listen("tcp", "127.0.0.1:0", s.tls)
+	} else {
+		ln, err = net.Listen("tcp", "127.0.0.1:0")
+	}
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	s.addr = ln.Addr().String()
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *fakeServer) received() []receivedMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]receivedMessage(nil), s.messages...)
+}
+
+func (s *fakeServer) serve(conn net.Conn) { //nolint:gocognit,cyclop // it's a protocol state machine
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	tp := textproto.NewConn(conn)
+	secure, authed := s.implicit, false
+	var msg receivedMessage
+
+	_ = tp.PrintfLine("220 fake ESMTP")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			exts := []string{"250-fake"}
+			if s.tls != nil && !secure {
+				exts = append(exts, "250-STARTTLS")
+			}
+			if s.username != "" {
+				exts = append(exts, "250-AUTH PLAIN")
+			}
+			exts = append(exts, "250 8BITMIME")
+			_ = tp.PrintfLine("%s", strings.Join(exts, "\r\n"))
+		case "STARTTLS":
+			_ = tp.PrintfLine("220 ready")
+			tlsConn := tls.Server(conn, s.tls)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn, secure = tlsConn, true
+			tp = textproto.NewConn(conn)
+		case "AUTH":
+			_, encoded, _ := strings.Cut(arg, " ")
+			decoded, _ := base64.StdEncoding.DecodeString(encoded)
+			if string(decoded) == "\x00"+s.username+"\x00"+s.password {
+				authed = true
+				_ = tp.PrintfLine("235 ok")
+			} else {
+				_ = tp.PrintfLine("535 bad credentials")
+			}
+		case "MAIL":
+			if s.username != "" && !authed {
+				_ = tp.PrintfLine("530 authentication required")
+				continue
+			}
+			msg = receivedMessage{from: trimAddress(arg), tls: secure, authed: authed}
+			_ = tp.PrintfLine("250 ok")
+		case "RCPT":
+			msg.to = trimAddress(arg)
+			if strings.HasPrefix(msg.to, "unknown@") {
+				_ = tp.PrintfLine("550 no such user")
+				continue
+			}
+			if strings.HasPrefix(msg.to, "busy@") {
+				_ = tp.PrintfLine("451 try again later")
+				continue
+			}
+			_ = tp.PrintfLine("250 ok")
+		case "DATA":
+			_ = tp.PrintfLine("354 go ahead")
+			data, err := readData(tp.Reader.R)
+			if err != nil {
+				return
+			}
+			msg.data = data
+			s.mu.Lock()
+			s.messages = append(s.messages, msg)
+			s.mu.Unlock()
+			_ = tp.PrintfLine("250 queued")
+		case "QUIT":
+			_ = tp.PrintfLine("221 bye")
+			return
+		default:
+			_ = tp.PrintfLine("250 ok")
+		}
+	}
+}
+
+func readData(r *bufio.Reader) (string, error) {
+	var sb strings.Builder
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		if line == ".\r\n" {
+			return sb.String(), nil
+		}
+		sb.WriteString(strings.TrimPrefix(line, "."))
+	}
+}
+
+func trimAddress(arg string) string {
+	_, addr, _ := strings.Cut(arg, ":")
+	addr, _, _ = strings.Cut(addr, " ")
+	return strings.Trim(addr, "<>")
+}
+
+func selfSignedConfig() *tls.Config {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		panic(err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		MinVersion:   tls.VersionTLS12,
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package notifier
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxErrorBody limits how much of an error response is included in an HTTPStatusError
+const maxErrorBody = 512
+
+// HTTPStatusError is returned by HTTP-based transports when a remote API responds with a non-2xx status code
+type HTTPStatusError struct {
+	StatusCode int
+	// Body holds (the start of) the response body, which often explains what went wrong
+	Body string
+}
+
+var _ error = &HTTPStatusError{}
+
+func (e *HTTPStatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("received HTTP status %d", e.StatusCode)
+	}
+
+	return fmt.Sprintf("received HTTP status %d: %s", e.StatusCode, e.Body)
+}
+
+// CheckHTTPResponse returns nil for 2xx responses, or an *HTTPStatusError otherwise
+// Client errors (other than 408 and 429) are wrapped by Permanent since retrying the same request won't help.
+func CheckHTTPResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	err := &HTTPStatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(body))}
+
+	switch {
+	case resp.StatusCode == http.StatusRequestTimeout, resp.StatusCode == http.StatusTooManyRequests:
+		return err
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		return Permanent(err)
+	default:
+		return err
+	}
+}
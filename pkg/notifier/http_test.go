@@ -0,0 +1,63 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package notifier_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckHTTPResponse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status        int
+		body          string
+		wantErr       string
+		wantPermanent bool
+	}{
+		{status: 200},
+		{status: 204},
+		{status: 400, body: "bad request\n", wantErr: "received HTTP status 400: bad request", wantPermanent: true},
+		{status: 404, wantErr: "received HTTP status 404", wantPermanent: true},
+		{status: 408, wantErr: "received HTTP status 408"},
+		{status: 429, wantErr: "received HTTP status 429"},
+		{status: 500, wantErr: "received HTTP status 500"},
+		{status: 503, wantErr: "received HTTP status 503"},
+	}
+
+	for _, tc := range tests {
+		t.Run(http.StatusText(tc.status), func(t *testing.T) {
+			t.Parallel()
+
+			err := notifier.CheckHTTPResponse(&http.Response{
+				StatusCode: tc.status,
+				Body:       io.NopCloser(strings.NewReader(tc.body)),
+			})
+
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.EqualError(t, err, tc.wantErr)
+
+			var statusErr *notifier.HTTPStatusError
+			require.ErrorAs(t, err, &statusErr)
+			assert.Equal(t, tc.status, statusErr.StatusCode)
+
+			var permanent *backoff.PermanentError
+			assert.Equal(t, tc.wantPermanent, errors.As(err, &permanent))
+		})
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package mattermost provides a notifier.Transport implementation for sending notifications to Mattermost
+package mattermost
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification/extension"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/seatgeek/mailroom/pkg/validation"
+)
+
+// ID is the identifier for a Mattermost user ID
+// Use WithIdentifier if you run several Mattermost servers and need to tell their IDs apart.
+var ID = identifier.NewNamespaceAndKind("mattermost.com", identifier.KindID)
+
+// Attachment is a Mattermost message attachment, which is compatible with Slack's legacy attachments
+// See https://developers.mattermost.com/integrate/reference/message-attachments/
+type Attachment struct {
+	Fallback   string            `json:"fallback,omitempty"`
+	Color      string            `json:"color,omitempty"`
+	Pretext    string            `json:"pretext,omitempty"`
+	AuthorName string            `json:"author_name,omitempty"`
+	Title      string            `json:"title,omitempty"`
+	TitleLink  string            `json:"title_link,omitempty"`
+	Text       string            `json:"text,omitempty"`
+	Fields     []AttachmentField `json:"fields,omitempty"`
+	ImageURL   string            `json:"image_url,omitempty"`
+	ThumbURL   string            `json:"thumb_url,omitempty"`
+	Footer     string            `json:"footer,omitempty"`
+}
+
+// AttachmentField is a title/value pair displayed in an Attachment
+type AttachmentField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short,omitempty"`
+}
+
+// Attachments sets the attachments to be sent along with the message (see notification.Builder.WithExtensions)
+var Attachments = extension.NewKey[[]Attachment]("mattermost.attachments")
+
+// Transport supports sending messages to Mattermost as a bot (or any user with a personal access token)
+type Transport struct {
+	key             event.TransportKey
+	serverURL       string
+	token           string
+	client          *http.Client
+	identifier      identifier.NamespaceAndKind
+	fallbackChannel string
+
+	mu         sync.Mutex
+	botUserID  string
+	dmChannels map[string]string // user ID -> DM channel ID
+}
+
+var (
+	_ notifier.Transport   = &Transport{}
+	_ validation.Validator = &Transport{}
+)
+
+// Option configures a Transport
+type Option func(*Transport)
+
+// WithFallbackChannel posts to the given channel ID when a recipient can't be messaged directly,
+// either because they don't have a Mattermost ID or because the DM channel can't be created (in which case the post
+// @-mentions them)
+func WithFallbackChannel(channelID string) Option {
+	return func(t *Transport) {
+		t.fallbackChannel = channelID
+	}
+}
+
+// WithIdentifier changes which identifier holds the recipient's Mattermost user ID
+func WithIdentifier(namespaceAndKind identifier.NamespaceAndKind) Option {
+	return func(t *Transport) {
+		t.identifier = namespaceAndKind
+	}
+}
+
+// WithHTTPClient sets the http.Client used to talk to Mattermost
+func WithHTTPClient(client *http.Client) Option {
+	return func(t *Transport) {
+		t.client = client
+	}
+}
+
+// NewTransport creates a new Mattermost Transport
+// It requires a TransportKey, the server URL (like "https://mattermost.example.com"), an access token,
+// and optionally some Options
+func NewTransport(key event.TransportKey, serverURL string, token string, opts ...Option) *Transport {
+	t := &Transport{
+		key:        key,
+		serverURL:  strings.TrimSuffix(serverURL, "/"),
+		token:      token,
+		client:     http.DefaultClient,
+		identifier: ID,
+		dmChannels: make(map[string]string),
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+type post struct {
+	ChannelID string         `json:"channel_id"`
+	Message   string         `json:"message"`
+	Props     map[string]any `json:"props,omitempty"`
+}
+
+// Push sends a notification to a Mattermost user
+// In addition to supporting event.Notification, it also supports the Attachments extension.
+func (t *Transport) Push(ctx context.Context, notification event.Notification) error {
+	p := post{Message: notification.Render(t.key)}
+	if attachments, _ := Attachments.Of(notification); len(attachments) > 0 {
+		p.Props = map[string]any{"attachments": attachments}
+	}
+
+	id, ok := notification.Recipient().Get(t.identifier)
+	if !ok {
+		if t.fallbackChannel == "" {
+			return notifier.Permanent(errors.New("recipient does not have a Mattermost ID"))
+		}
+
+		p.ChannelID = t.fallbackChannel
+		return t.do(ctx, http.MethodPost, "/posts", p, nil)
+	}
+
+	channel, err := t.dmChannel(ctx, id)
+	if err != nil {
+		if t.fallbackChannel == "" || !isClientError(err) {
+			return fmt.Errorf("failed to open DM channel: %w", err)
+		}
+
+		slog.InfoContext(ctx, "unable to DM Mattermost user, using fallback channel", "user", id, "error", err)
+		channel = t.fallbackChannel
+
+		// Mention the recipient, so that the channel knows who it's for
+		if u, err := t.user(ctx, id); err != nil {
+			slog.WarnContext(ctx, "unable to look up Mattermost user to mention in fallback channel", "user", id, "error", err)
+		} else {
+			p.Message = fmt.Sprintf("@%s %s", u.Username, p.Message)
+		}
+	}
+
+	p.ChannelID = channel
+	return t.do(ctx, http.MethodPost, "/posts", p, nil)
+}
+
+func (t *Transport) Key() event.TransportKey {
+	return t.key
+}
+
+// Validate checks the access token by fetching the bot's own user
+func (t *Transport) Validate(ctx context.Context) error {
+	me, err := t.me(ctx)
+	if err != nil {
+		return notifier.Permanent(fmt.Errorf("authentication failed: %w", err))
+	}
+
+	slog.InfoContext(ctx, "Mattermost transport connected", "transport", t.key, "mattermost_user", me.Username)
+	return nil
+}
+
+type user struct {
+	ID       string `json:"id"`
+	Username string `json:"username"`
+}
+
+func (t *Transport) me(ctx context.Context) (user, error) {
+	var me user
+	err := t.do(ctx, http.MethodGet, "/users/me", nil, &me)
+	return me, err
+}
+
+func (t *Transport) user(ctx context.Context, id string) (user, error) {
+	var u user
+	err := t.do(ctx, http.MethodGet, "/users/"+url.PathEscape(id), nil, &u)
+	return u, err
+}
+
+// dmChannel returns the ID of the direct channel between the bot and the given user, creating it if needed
+func (t *Transport) dmChannel(ctx context.Context, userID string) (string, error) {
+	t.mu.Lock()
+	channel, ok := t.dmChannels[userID]
+	botUserID := t.botUserID
+	t.mu.Unlock()
+	if ok {
+		return channel, nil
+	}
+
+	if botUserID == "" {
+		me, err := t.me(ctx)
+		if err != nil {
+			return "", err
+		}
+		botUserID = me.ID
+	}
+
+	var resp struct {
+		ID string `json:"id"`
+	}
+	if err := t.do(ctx, http.MethodPost, "/channels/direct", []string{botUserID, userID}, &resp); err != nil {
+		return "", err
+	}
+
+	t.mu.Lock()
+	t.botUserID = botUserID
+	t.dmChannels[userID] = resp.ID
+	t.mu.Unlock()
+
+	return resp.ID, nil
+}
+
+func (t *Transport) do(ctx context.Context, method, path string, body any, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return notifier.Permanent(err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, t.serverURL+"/api/v4"+path, bytes.NewReader(payload))
+	if err != nil {
+		return notifier.Permanent(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := notifier.CheckHTTPResponse(resp); err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// isClientError reports whether Mattermost rejected the request (like an unknown or deactivated user)
+func isClientError(err error) bool {
+	var statusErr *notifier.HTTPStatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode >= 400 && statusErr.StatusCode < 500 &&
+		statusErr.StatusCode != http.StatusTooManyRequests
+}
@@ -0,0 +1,253 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package mattermost_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier/mattermost"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	token     = "some-token"
+	botUserID = "bot-user-id"
+	userID    = "some-user-id"
+)
+
+// fakeMattermost is a local stand-in for the Mattermost API
+type fakeMattermost struct {
+	*httptest.Server
+
+	// directStatus is the status returned when creating a direct channel
+	directStatus int
+
+	mu      sync.Mutex
+	posts   []map[string]any
+	members [][]string
+}
+
+func newFakeMattermost(t *testing.T, directStatus int) *fakeMattermost {
+	t.Helper()
+
+	f := &fakeMattermost{directStatus: directStatus}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/users/me", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "` + botUserID + `", "username": "mailroom"}`))
+	})
+	mux.HandleFunc("GET /api/v4/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != userID {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "` + userID + `", "username": "rufus"}`))
+	})
+	mux.HandleFunc("POST /api/v4/channels/direct", func(w http.ResponseWriter, r *http.Request) {
+		var members []string
+		_ = json.NewDecoder(r.Body).Decode(&members)
+
+		f.mu.Lock()
+		f.members = append(f.members, members)
+		f.mu.Unlock()
+
+		w.WriteHeader(f.directStatus)
+		_, _ = w.Write([]byte(`{"id": "direct-channel"}`))
+	})
+	mux.HandleFunc("POST /api/v4/posts", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		f.mu.Lock()
+		f.posts = append(f.posts, body)
+		f.mu.Unlock()
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": "some-post"}`))
+	})
+
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"id": "api.context.session_expired.app_error"}`))
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+func notificationFor(ids ...identifier.Identifier) *notification.Builder {
+	return notification.NewBuilder(event.Context{Type: "com.example.test"}).
+		WithRecipientIdentifiers(ids...).
+		WithDefaultMessage("Hello, world!")
+}
+
+func TestTransport_Push(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sends a DM", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeMattermost(t, http.StatusCreated)
+		transport := mattermost.NewTransport("mattermost", server.URL+"/", token)
+
+		require.NoError(t, transport.Push(t.Context(), notificationFor(identifier.New(mattermost.ID, userID)).Build()))
+		require.NoError(t, transport.Push(t.Context(), notificationFor(identifier.New(mattermost.ID, userID)).
+			WithExtensions(mattermost.Attachments.Value([]mattermost.Attachment{{
+				Color:     "#FF8000",
+				Title:     "Review requested",
+				TitleLink: "https://example.com/pr/1",
+				Fields:    []mattermost.AttachmentField{{Title: "Author", Value: "rufus", Short: true}},
+			}})).
+			Build()))
+
+		// The direct channel is only created once
+		assert.Equal(t, [][]string{{botUserID, userID}}, server.members)
+
+		require.Len(t, server.posts, 2)
+		assert.Equal(t, map[string]any{"channel_id": "direct-channel", "message": "Hello, world!"}, server.posts[0])
+
+		got, _ := json.Marshal(server.posts[1])
+		assert.JSONEq(t, `{
+			"channel_id": "direct-channel",
+			"message": "Hello, world!",
+			"props": {
+				"attachments": [{
+					"color": "#FF8000",
+					"title": "Review requested",
+					"title_link": "https://example.com/pr/1",
+					"fields": [{"title": "Author", "value": "rufus", "short": true}]
+				}]
+			}
+		}`, string(got))
+	})
+
+	t.Run("custom identifier", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeMattermost(t, http.StatusCreated)
+		transport := mattermost.NewTransport("mattermost", server.URL, token, mattermost.WithIdentifier("chat.example.com/id"))
+
+		require.NoError(t, transport.Push(t.Context(), notificationFor(identifier.New("chat.example.com/id", userID)).Build()))
+
+		assert.Equal(t, [][]string{{botUserID, userID}}, server.members)
+	})
+
+	t.Run("recipients without a Mattermost ID use the fallback channel", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeMattermost(t, http.StatusCreated)
+		transport := mattermost.NewTransport("mattermost", server.URL, token, mattermost.WithFallbackChannel("town-square"))
+
+		require.NoError(t, transport.Push(t.Context(), notificationFor(identifier.New(identifier.GenericEmail, "rufus@example.com")).Build()))
+
+		assert.Empty(t, server.members)
+		assert.Equal(t, []map[string]any{{"channel_id": "town-square", "message": "Hello, world!"}}, server.posts)
+	})
+
+	t.Run("recipients who can't be messaged directly use the fallback channel", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeMattermost(t, http.StatusBadRequest)
+		transport := mattermost.NewTransport("mattermost", server.URL, token, mattermost.WithFallbackChannel("town-square"))
+
+		require.NoError(t, transport.Push(t.Context(), notificationFor(identifier.New(mattermost.ID, userID)).Build()))
+
+		// They're mentioned, so that the channel knows who it's for
+		assert.Equal(t, []map[string]any{{"channel_id": "town-square", "message": "@rufus Hello, world!"}}, server.posts)
+	})
+
+	t.Run("recipients who can't be looked up are still posted to the fallback channel", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeMattermost(t, http.StatusBadRequest)
+		transport := mattermost.NewTransport("mattermost", server.URL, token, mattermost.WithFallbackChannel("town-square"))
+
+		require.NoError(t, transport.Push(t.Context(), notificationFor(identifier.New(mattermost.ID, "deleted-user-id")).Build()))
+
+		assert.Equal(t, []map[string]any{{"channel_id": "town-square", "message": "Hello, world!"}}, server.posts)
+	})
+}
+
+func TestTransport_Push_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		token         string
+		directStatus  int
+		recipient     identifier.Identifier
+		wantErr       string
+		wantPermanent bool
+	}{
+		{
+			name:          "recipient without Mattermost ID",
+			token:         token,
+			directStatus:  http.StatusCreated,
+			recipient:     identifier.New(identifier.GenericEmail, "rufus@example.com"),
+			wantErr:       "recipient does not have a Mattermost ID",
+			wantPermanent: true,
+		},
+		{
+			name:          "unknown user without a fallback channel",
+			token:         token,
+			directStatus:  http.StatusBadRequest,
+			recipient:     identifier.New(mattermost.ID, userID),
+			wantErr:       "failed to open DM channel: received HTTP status 400",
+			wantPermanent: true,
+		},
+		{
+			name:         "server error",
+			token:        token,
+			directStatus: http.StatusInternalServerError,
+			recipient:    identifier.New(mattermost.ID, userID),
+			wantErr:      "received HTTP status 500",
+		},
+		{
+			name:          "invalid token",
+			token:         "wrong",
+			recipient:     identifier.New(mattermost.ID, userID),
+			wantErr:       "received HTTP status 401",
+			wantPermanent: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newFakeMattermost(t, tc.directStatus)
+			transport := mattermost.NewTransport("mattermost", server.URL, tc.token)
+
+			err := transport.Push(t.Context(), notificationFor(tc.recipient).Build())
+			assert.ErrorContains(t, err, tc.wantErr)
+
+			var permanent *backoff.PermanentError
+			assert.Equal(t, tc.wantPermanent, errors.As(err, &permanent))
+		})
+	}
+}
+
+func TestTransport_Validate(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeMattermost(t, http.StatusCreated)
+
+	assert.NoError(t, mattermost.NewTransport("mattermost", server.URL, token).Validate(t.Context()))
+	assert.ErrorContains(t, mattermost.NewTransport("mattermost", server.URL, "wrong").Validate(t.Context()), "authentication failed")
+}
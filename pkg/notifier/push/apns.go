@@ -0,0 +1,198 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+)
+
+const (
+	// DefaultAPNsURL is the production APNs endpoint
+	DefaultAPNsURL = "https://api.push.apple.com"
+	// APNsSandboxURL is the APNs endpoint for development builds of your app
+	APNsSandboxURL = "https://api.sandbox.push.apple.com"
+
+	// Apple rejects provider tokens older than an hour, but also throttles refreshing them more often than every 20 minutes
+	apnsTokenTTL = 50 * time.Minute
+)
+
+// APNsConfig holds the token-based authentication settings used by NewAPNsTransport
+type APNsConfig struct {
+	// TeamID is your Apple Developer team ID
+	TeamID string
+	// KeyID is the ID of the APNs authentication key
+	KeyID string
+	// PrivateKey is the contents of the authentication key's .p8 file
+	PrivateKey []byte
+	// Topic is your app's bundle ID
+	Topic string
+}
+
+// NewAPNsTransport creates a Transport which sends notifications to iOS devices via the Apple Push Notification service
+// Device tokens are read from the recipient's APNsToken identifier.
+func NewAPNsTransport(key event.TransportKey, config APNsConfig, opts ...Option) (*Transport, error) {
+	signer, err := parsePrivateKey(config.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid APNs key: %w", err)
+	}
+
+	o := newOptions(DefaultAPNsURL, opts)
+
+	return &Transport{
+		key:    key,
+		tokens: APNsToken,
+		pruner: o.pruner,
+		sender: &apnsSender{
+			config:  config,
+			signer:  signer,
+			baseURL: strings.TrimSuffix(o.baseURL, "/"),
+			client:  o.client,
+		},
+	}, nil
+}
+
+// apnsSender sends notifications over APNs' HTTP/2 API
+type apnsSender struct {
+	config  APNsConfig
+	signer  crypto.Signer
+	baseURL string
+	client  *http.Client
+
+	mu       sync.Mutex
+	token    string
+	issuedAt time.Time
+}
+
+type apnsPayload struct {
+	APS  apnsAPS `json:"aps"`
+	Link string  `json:"link,omitempty"`
+}
+
+type apnsAPS struct {
+	Alert apnsAlert `json:"alert"`
+	Badge *int      `json:"badge,omitempty"`
+	Sound string    `json:"sound,omitempty"`
+}
+
+type apnsAlert struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+}
+
+func (a *apnsSender) send(ctx context.Context, token string, msg Message) error {
+	body, err := json.Marshal(apnsPayload{
+		APS: apnsAPS{
+			Alert: apnsAlert{Title: msg.Title, Body: msg.Body},
+			Badge: msg.Badge,
+			Sound: "default",
+		},
+		Link: msg.DeepLink,
+	})
+	if err != nil {
+		return notifier.Permanent(err)
+	}
+
+	providerToken, err := a.providerToken()
+	if err != nil {
+		return notifier.Permanent(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.baseURL+"/3/device/"+url.PathEscape(token), bytes.NewReader(body))
+	if err != nil {
+		return notifier.Permanent(err)
+	}
+
+	req.Header.Set("Authorization", "bearer "+providerToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Apns-Topic", a.config.Topic)
+	req.Header.Set("Apns-Push-Type", "alert")
+	req.Header.Set("Apns-Priority", "10")
+	if msg.CollapseKey != "" {
+		req.Header.Set("Apns-Collapse-Id", msg.CollapseKey)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	err = notifier.CheckHTTPResponse(resp)
+
+	var statusErr *notifier.HTTPStatusError
+	if !errors.As(err, &statusErr) {
+		return err
+	}
+
+	// See https://developer.apple.com/documentation/usernotifications/handling-notification-responses-from-apns
+	var apnsErr struct {
+		Reason string `json:"reason"`
+	}
+	_ = json.Unmarshal([]byte(statusErr.Body), &apnsErr)
+
+	switch {
+	case resp.StatusCode == http.StatusGone || apnsErr.Reason == "Unregistered":
+		return fmt.Errorf("%w: %s", ErrUnregistered, apnsErr.Reason)
+	case apnsErr.Reason == "ExpiredProviderToken":
+		// Apple's clock disagrees with ours; start over with a fresh token
+		a.mu.Lock()
+		a.token = ""
+		a.mu.Unlock()
+		return fmt.Errorf("APNs rejected the provider token: %s", apnsErr.Reason)
+	case apnsErr.Reason != "":
+		return fmt.Errorf("%w (%s)", err, apnsErr.Reason)
+	}
+
+	return err
+}
+
+// validate checks that the key can sign provider tokens
+// APNs has no endpoint for checking credentials without sending a notification
+func (a *apnsSender) validate(ctx context.Context) error {
+	if _, err := a.providerToken(); err != nil {
+		return notifier.Permanent(fmt.Errorf("failed to sign APNs provider token: %w", err))
+	}
+
+	slog.InfoContext(ctx, "APNs transport configured", "team_id", a.config.TeamID, "key_id", a.config.KeyID, "topic", a.config.Topic)
+	return nil
+}
+
+// providerToken returns a cached JWT for authenticating with APNs, signing a new one when needed
+func (a *apnsSender) providerToken() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != "" && time.Since(a.issuedAt) < apnsTokenTTL {
+		return a.token, nil
+	}
+
+	now := time.Now()
+	token, err := signJWT(a.signer,
+		map[string]any{"kid": a.config.KeyID},
+		map[string]any{"iss": a.config.TeamID, "iat": now.Unix()},
+	)
+	if err != nil {
+		return "", err
+	}
+
+	a.token = token
+	a.issuedAt = now
+
+	return token, nil
+}
@@ -0,0 +1,276 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package push_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notifier/push"
+	"github.com/seatgeek/mailroom/pkg/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type apnsRequest struct {
+	token   string
+	header  http.Header
+	payload map[string]any
+}
+
+// fakeAPNs is a local stand-in for the APNs HTTP/2 API
+// Tokens starting with "gone", "bad" or "busy" are rejected like APNs would.
+type fakeAPNs struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []apnsRequest
+}
+
+func newFakeAPNs(t *testing.T, key *ecdsa.PrivateKey) *fakeAPNs {
+	t.Helper()
+
+	f := &fakeAPNs{}
+
+	f.Server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor != 2 {
+			w.WriteHeader(http.StatusHTTPVersionNotSupported)
+			return
+		}
+
+		if !verifyES256(t, &key.PublicKey, strings.TrimPrefix(r.Header.Get("Authorization"), "bearer ")) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"reason": "InvalidProviderToken"}`))
+			return
+		}
+
+		token := strings.TrimPrefix(r.URL.Path, "/3/device/")
+		switch {
+		case strings.HasPrefix(token, "gone"):
+			w.WriteHeader(http.StatusGone)
+			_, _ = w.Write([]byte(`{"reason": "Unregistered", "timestamp": 1700000000000}`))
+			return
+		case strings.HasPrefix(token, "bad"):
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"reason": "BadDeviceToken"}`))
+			return
+		case strings.HasPrefix(token, "busy"):
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"reason": "ServiceUnavailable"}`))
+			return
+		}
+
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+
+		f.mu.Lock()
+		f.requests = append(f.requests, apnsRequest{token: token, header: r.Header.Clone(), payload: payload})
+		f.mu.Unlock()
+
+		w.Header().Set("Apns-Id", "EC1BF194-B3B2-424A-89A9-5A918A6E6B5E")
+	}))
+	f.EnableHTTP2 = true
+	f.StartTLS()
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+func verifyES256(t *testing.T, key *ecdsa.PublicKey, token string) bool {
+	t.Helper()
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return false
+	}
+
+	header, claims, sig := decodeJWT(t, token)
+	if header["alg"] != "ES256" || header["kid"] != "KEY123" || claims["iss"] != "TEAM123" || len(sig) != 64 {
+		return false
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	return ecdsa.Verify(key, digest[:], new(big.Int).SetBytes(sig[:32]), new(big.Int).SetBytes(sig[32:]))
+}
+
+func newAPNsTransport(t *testing.T, opts ...push.Option) (*push.Transport, *fakeAPNs) {
+	t.Helper()
+
+	key, keyPEM := generateECKey(t)
+	server := newFakeAPNs(t, key)
+
+	transport, err := push.NewAPNsTransport("ios", push.APNsConfig{
+		TeamID:     "TEAM123",
+		KeyID:      "KEY123",
+		PrivateKey: keyPEM,
+		Topic:      "com.example.app",
+	}, append([]push.Option{push.WithBaseURL(server.URL), push.WithHTTPClient(server.Client())}, opts...)...)
+	require.NoError(t, err)
+
+	return transport, server
+}
+
+func TestAPNsTransport_Push(t *testing.T) {
+	t.Parallel()
+
+	t.Run("plain message to every device", func(t *testing.T) {
+		t.Parallel()
+
+		transport, server := newAPNsTransport(t)
+		recipient := identifier.NewSet(identifier.NewMulti(push.APNsToken, "device1", "device2"))
+
+		err := transport.Push(t.Context(), notificationFor(recipient, "Your pipeline failed").Build())
+		require.NoError(t, err)
+
+		require.Len(t, server.requests, 2)
+		assert.ElementsMatch(t, []string{"device1", "device2"}, []string{server.requests[0].token, server.requests[1].token})
+
+		req := server.requests[0]
+		assert.Equal(t, "com.example.app", req.header.Get("Apns-Topic"))
+		assert.Equal(t, "alert", req.header.Get("Apns-Push-Type"))
+		assert.Empty(t, req.header.Get("Apns-Collapse-Id"))
+		assert.Equal(t, map[string]any{
+			"aps": map[string]any{
+				"alert": map[string]any{"body": "Your pipeline failed"},
+				"sound": "default",
+			},
+		}, req.payload)
+
+		// The provider token is reused between requests
+		assert.Equal(t, server.requests[0].header.Get("Authorization"), server.requests[1].header.Get("Authorization"))
+	})
+
+	t.Run("rich message", func(t *testing.T) {
+		t.Parallel()
+
+		transport, server := newAPNsTransport(t)
+		recipient := identifier.NewSet(identifier.NewMulti(push.APNsToken, "device1"))
+		badge := 0
+
+		err := transport.Push(t.Context(), notificationFor(recipient, "Your pipeline failed").
+			WithExtensions(push.Details.Value(push.Message{
+				Title:       "Pipeline #42",
+				Badge:       &badge,
+				DeepLink:    "example://pipelines/42",
+				CollapseKey: "pipeline-42",
+			})).
+			Build())
+		require.NoError(t, err)
+
+		require.Len(t, server.requests, 1)
+		assert.Equal(t, "pipeline-42", server.requests[0].header.Get("Apns-Collapse-Id"))
+		assert.Equal(t, map[string]any{
+			"aps": map[string]any{
+				"alert": map[string]any{"title": "Pipeline #42", "body": "Your pipeline failed"},
+				"badge": float64(0),
+				"sound": "default",
+			},
+			"link": "example://pipelines/42",
+		}, server.requests[0].payload)
+	})
+
+	t.Run("prunes unregistered devices", func(t *testing.T) {
+		t.Parallel()
+
+		store := user.NewInMemoryStore(user.New("rufus", user.WithIdentifier(identifier.NewMulti(push.APNsToken, "device1", "gone1"))))
+		transport, server := newAPNsTransport(t, push.WithTokenPruning(store))
+
+		u, err := store.Get(t.Context(), "rufus")
+		require.NoError(t, err)
+
+		err = transport.Push(t.Context(), notificationFor(u.Identifiers, "Your pipeline failed").Build())
+		require.NoError(t, err)
+		assert.Len(t, server.requests, 1)
+
+		u, err = store.Get(t.Context(), "rufus")
+		require.NoError(t, err)
+		assert.Equal(t, "device1", u.Identifiers.MustGet(push.APNsToken))
+	})
+}
+
+func TestAPNsTransport_Push_Errors(t *testing.T) {
+	t.Parallel()
+
+	transport, _ := newAPNsTransport(t)
+
+	tests := []struct {
+		name          string
+		recipient     identifier.Set
+		message       string
+		wantErr       string
+		wantPermanent bool
+	}{
+		{
+			name:          "no device tokens",
+			recipient:     identifier.NewSet(identifier.New(identifier.GenericEmail, "rufus@example.com")),
+			message:       "hello",
+			wantErr:       "recipient does not have any device tokens",
+			wantPermanent: true,
+		},
+		{
+			name:          "empty message",
+			recipient:     identifier.NewSet(identifier.New(push.APNsToken, "device1")),
+			message:       "",
+			wantErr:       "message is empty",
+			wantPermanent: true,
+		},
+		{
+			name:          "all devices unregistered",
+			recipient:     identifier.NewSet(identifier.NewMulti(push.APNsToken, "gone1", "gone2")),
+			message:       "hello",
+			wantErr:       push.ErrUnregistered.Error(),
+			wantPermanent: true,
+		},
+		{
+			name:          "bad device token",
+			recipient:     identifier.NewSet(identifier.NewMulti(push.APNsToken, "device1", "bad1")),
+			message:       "hello",
+			wantErr:       "received HTTP status 400",
+			wantPermanent: true,
+		},
+		{
+			name:      "service unavailable",
+			recipient: identifier.NewSet(identifier.NewMulti(push.APNsToken, "bad1", "busy1")),
+			message:   "hello",
+			wantErr:   "received HTTP status 503",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := transport.Push(t.Context(), notificationFor(tc.recipient, tc.message).Build())
+			assert.ErrorContains(t, err, tc.wantErr)
+
+			var permanent *backoff.PermanentError
+			assert.Equal(t, tc.wantPermanent, errors.As(err, &permanent))
+		})
+	}
+}
+
+func TestNewAPNsTransport_InvalidKey(t *testing.T) {
+	t.Parallel()
+
+	_, err := push.NewAPNsTransport("ios", push.APNsConfig{PrivateKey: []byte("not a key")})
+	assert.ErrorContains(t, err, "invalid APNs key")
+}
+
+func TestAPNsTransport_Validate(t *testing.T) {
+	t.Parallel()
+
+	transport, _ := newAPNsTransport(t)
+	assert.NoError(t, transport.Validate(t.Context()))
+}
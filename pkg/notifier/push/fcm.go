@@ -0,0 +1,264 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package push
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+)
+
+const (
+	// DefaultFCMURL is the Firebase Cloud Messaging endpoint
+	DefaultFCMURL = "https://fcm.googleapis.com"
+
+	fcmScope = "https://www.googleapis.com/auth/firebase.messaging"
+)
+
+// serviceAccount holds the fields we need from a Google service account key file
+type serviceAccount struct {
+	ProjectID   string `json:"project_id"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// NewFCMTransport creates a Transport which sends notifications to Android (and iOS) devices via the FCM HTTP v1 API
+// It authenticates with a Google service account key file which has permission to send messages for the Firebase project.
+// Registration tokens are read from the recipient's FCMToken identifier.
+func NewFCMTransport(key event.TransportKey, serviceAccountJSON []byte, opts ...Option) (*Transport, error) {
+	var account serviceAccount
+	if err := json.Unmarshal(serviceAccountJSON, &account); err != nil {
+		return nil, fmt.Errorf("invalid service account: %w", err)
+	}
+
+	if account.ProjectID == "" || account.ClientEmail == "" || account.TokenURI == "" {
+		return nil, errors.New("invalid service account: project_id, client_email and token_uri are required")
+	}
+
+	signer, err := parsePrivateKey([]byte(account.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("invalid service account: %w", err)
+	}
+
+	o := newOptions(DefaultFCMURL, opts)
+
+	return &Transport{
+		key:    key,
+		tokens: FCMToken,
+		pruner: o.pruner,
+		sender: &fcmSender{
+			account: account,
+			signer:  signer,
+			baseURL: strings.TrimSuffix(o.baseURL, "/"),
+			client:  o.client,
+		},
+	}, nil
+}
+
+// fcmSender sends notifications with the FCM HTTP v1 API
+type fcmSender struct {
+	account serviceAccount
+	signer  crypto.Signer
+	baseURL string
+	client  *http.Client
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+type fcmRequest struct {
+	Message fcmMessage `json:"message"`
+}
+
+type fcmMessage struct {
+	Token        string            `json:"token"`
+	Notification fcmNotification   `json:"notification"`
+	Data         map[string]string `json:"data,omitempty"`
+	Android      *fcmAndroid       `json:"android,omitempty"`
+	APNs         *fcmAPNs          `json:"apns,omitempty"`
+}
+
+type fcmNotification struct {
+	Title string `json:"title,omitempty"`
+	Body  string `json:"body,omitempty"`
+}
+
+type fcmAndroid struct {
+	CollapseKey  string                  `json:"collapse_key,omitempty"`
+	Notification *fcmAndroidNotification `json:"notification,omitempty"`
+}
+
+type fcmAndroidNotification struct {
+	NotificationCount *int `json:"notification_count,omitempty"`
+}
+
+type fcmAPNs struct {
+	Headers map[string]string `json:"headers,omitempty"`
+	Payload map[string]any    `json:"payload,omitempty"`
+}
+
+func (f *fcmSender) send(ctx context.Context, token string, msg Message) error {
+	req := fcmRequest{Message: fcmMessage{
+		Token:        token,
+		Notification: fcmNotification{Title: msg.Title, Body: msg.Body},
+	}}
+
+	if msg.DeepLink != "" {
+		req.Message.Data = map[string]string{"link": msg.DeepLink}
+	}
+
+	// FCM has no cross-platform fields for these, so set them for each platform
+	if msg.CollapseKey != "" || msg.Badge != nil {
+		req.Message.Android = &fcmAndroid{CollapseKey: msg.CollapseKey}
+		req.Message.APNs = &fcmAPNs{}
+		if msg.CollapseKey != "" {
+			req.Message.APNs.Headers = map[string]string{"apns-collapse-id": msg.CollapseKey}
+		}
+		if msg.Badge != nil {
+			req.Message.Android.Notification = &fcmAndroidNotification{NotificationCount: msg.Badge}
+			req.Message.APNs.Payload = map[string]any{"aps": map[string]any{"badge": *msg.Badge}}
+		}
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return notifier.Permanent(err)
+	}
+
+	accessToken, err := f.accessToken(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to authenticate: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, f.baseURL+"/v1/projects/"+url.PathEscape(f.account.ProjectID)+"/messages:send", bytes.NewReader(body))
+	if err != nil {
+		return notifier.Permanent(err)
+	}
+
+	httpReq.Header.Set("Authorization", "Bearer "+accessToken)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	err = notifier.CheckHTTPResponse(resp)
+
+	var statusErr *notifier.HTTPStatusError
+	if !errors.As(err, &statusErr) {
+		return err
+	}
+
+	// See https://firebase.google.com/docs/reference/fcm/rest/v1/ErrorCode
+	var fcmErr struct {
+		Error struct {
+			Status  string `json:"status"`
+			Details []struct {
+				ErrorCode string `json:"errorCode"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	_ = json.Unmarshal([]byte(statusErr.Body), &fcmErr)
+
+	for _, detail := range fcmErr.Error.Details {
+		if detail.ErrorCode == "UNREGISTERED" {
+			return fmt.Errorf("%w: %s", ErrUnregistered, detail.ErrorCode)
+		}
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		// The access token may have been revoked early; fetch a new one on the next attempt
+		f.mu.Lock()
+		f.token = ""
+		f.mu.Unlock()
+		return fmt.Errorf("FCM rejected the access token: %s", fcmErr.Error.Status)
+	}
+
+	return err
+}
+
+// validate checks the service account by requesting an access token
+func (f *fcmSender) validate(ctx context.Context) error {
+	if _, err := f.accessToken(ctx); err != nil {
+		return notifier.Permanent(fmt.Errorf("authentication failed: %w", err))
+	}
+
+	slog.InfoContext(ctx, "FCM transport authenticated", "project_id", f.account.ProjectID, "client_email", f.account.ClientEmail)
+	return nil
+}
+
+// accessToken returns a cached OAuth access token, exchanging a signed JWT for a new one when needed
+// See https://developers.google.com/identity/protocols/oauth2/service-account#httprest
+func (f *fcmSender) accessToken(ctx context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.token != "" && time.Now().Before(f.tokenExpiry) {
+		return f.token, nil
+	}
+
+	now := time.Now()
+	assertion, err := signJWT(f.signer, map[string]any{}, map[string]any{
+		"iss":   f.account.ClientEmail,
+		"scope": fcmScope,
+		"aud":   f.account.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", notifier.Permanent(err)
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.account.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", notifier.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if err := notifier.CheckHTTPResponse(resp); err != nil {
+		return "", err
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+
+	f.token = token.AccessToken
+	// Refresh a minute early so in-flight requests don't race the expiry
+	f.tokenExpiry = now.Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+
+	return f.token, nil
+}
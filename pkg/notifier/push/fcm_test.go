@@ -0,0 +1,291 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package push_test
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notifier/push"
+	"github.com/seatgeek/mailroom/pkg/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFCM is a local stand-in for Google's OAuth token endpoint and the FCM HTTP v1 API
+// Tokens starting with "gone", "bad" or "expired" are rejected like FCM would.
+type fakeFCM struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	tokensIssued int
+	messages     []map[string]any
+}
+
+func newFakeFCM(t *testing.T, key *rsa.PrivateKey) *fakeFCM {
+	t.Helper()
+
+	f := &fakeFCM{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.PostForm.Get("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" || !verifyRS256(t, &key.PublicKey, r.PostForm.Get("assertion")) {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": "invalid_grant"}`))
+			return
+		}
+
+		f.mu.Lock()
+		f.tokensIssued++
+		f.mu.Unlock()
+
+		_, _ = w.Write([]byte(`{"access_token": "ya29.some-token", "expires_in": 3599, "token_type": "Bearer"}`))
+	})
+	mux.HandleFunc("POST /v1/projects/my-project/messages:send", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer ya29.some-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": {"code": 401, "status": "UNAUTHENTICATED"}}`))
+			return
+		}
+
+		var req struct {
+			Message map[string]any `json:"message"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		token, _ := req.Message["token"].(string)
+		switch {
+		case strings.HasPrefix(token, "gone"):
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error": {"code": 404, "message": "Requested entity was not found.", "status": "NOT_FOUND", "details": [{"@type": "type.googleapis.com/google.firebase.fcm.v1.FcmError", "errorCode": "UNREGISTERED"}]}}`))
+			return
+		case strings.HasPrefix(token, "bad"):
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error": {"code": 400, "message": "The registration token is not a valid FCM registration token", "status": "INVALID_ARGUMENT", "details": [{"@type": "type.googleapis.com/google.firebase.fcm.v1.FcmError", "errorCode": "INVALID_ARGUMENT"}]}}`))
+			return
+		case strings.HasPrefix(token, "expired"):
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error": {"code": 401, "status": "UNAUTHENTICATED"}}`))
+			return
+		}
+
+		f.mu.Lock()
+		f.messages = append(f.messages, req.Message)
+		f.mu.Unlock()
+
+		_, _ = w.Write([]byte(`{"name": "projects/my-project/messages/0:1500415314455276%31bd1c9631bd1c96"}`))
+	})
+
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+func verifyRS256(t *testing.T, key *rsa.PublicKey, token string) bool {
+	t.Helper()
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return false
+	}
+
+	header, claims, sig := decodeJWT(t, token)
+	if header["alg"] != "RS256" || claims["iss"] != "mailroom@my-project.iam.gserviceaccount.com" || claims["scope"] != "https://www.googleapis.com/auth/firebase.messaging" {
+		return false
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig) == nil
+}
+
+func serviceAccountJSON(t *testing.T, tokenURI string, keyPEM []byte) []byte {
+	t.Helper()
+
+	b, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"project_id":   "my-project",
+		"client_email": "mailroom@my-project.iam.gserviceaccount.com",
+		"private_key":  string(keyPEM),
+		"token_uri":    tokenURI,
+	})
+	require.NoError(t, err)
+
+	return b
+}
+
+func newFCMTransport(t *testing.T, opts ...push.Option) (*push.Transport, *fakeFCM) {
+	t.Helper()
+
+	key, keyPEM := generateRSAKey(t)
+	server := newFakeFCM(t, key)
+
+	transport, err := push.NewFCMTransport("android", serviceAccountJSON(t, server.URL+"/token", keyPEM), append([]push.Option{push.WithBaseURL(server.URL)}, opts...)...)
+	require.NoError(t, err)
+
+	return transport, server
+}
+
+func TestFCMTransport_Push(t *testing.T) {
+	t.Parallel()
+
+	t.Run("plain message to every device", func(t *testing.T) {
+		t.Parallel()
+
+		transport, server := newFCMTransport(t)
+		recipient := identifier.NewSet(identifier.NewMulti(push.FCMToken, "device1", "device2"))
+
+		err := transport.Push(t.Context(), notificationFor(recipient, "Your pipeline failed").Build())
+		require.NoError(t, err)
+
+		assert.Equal(t, []map[string]any{
+			{"token": "device1", "notification": map[string]any{"body": "Your pipeline failed"}},
+			{"token": "device2", "notification": map[string]any{"body": "Your pipeline failed"}},
+		}, server.messages)
+
+		// The access token is reused between requests
+		assert.Equal(t, 1, server.tokensIssued)
+	})
+
+	t.Run("rich message", func(t *testing.T) {
+		t.Parallel()
+
+		transport, server := newFCMTransport(t)
+		recipient := identifier.NewSet(identifier.NewMulti(push.FCMToken, "device1"))
+		badge := 2
+
+		err := transport.Push(t.Context(), notificationFor(recipient, "Your pipeline failed").
+			WithExtensions(push.Details.Value(push.Message{
+				Title:       "Pipeline #42",
+				Body:        "Pipeline #42 failed on main",
+				Badge:       &badge,
+				DeepLink:    "example://pipelines/42",
+				CollapseKey: "pipeline-42",
+			})).
+			Build())
+		require.NoError(t, err)
+
+		assert.Equal(t, []map[string]any{{
+			"token":        "device1",
+			"notification": map[string]any{"title": "Pipeline #42", "body": "Pipeline #42 failed on main"},
+			"data":         map[string]any{"link": "example://pipelines/42"},
+			"android": map[string]any{
+				"collapse_key": "pipeline-42",
+				"notification": map[string]any{"notification_count": float64(2)},
+			},
+			"apns": map[string]any{
+				"headers": map[string]any{"apns-collapse-id": "pipeline-42"},
+				"payload": map[string]any{"aps": map[string]any{"badge": float64(2)}},
+			},
+		}}, server.messages)
+	})
+
+	t.Run("prunes unregistered devices", func(t *testing.T) {
+		t.Parallel()
+
+		store := user.NewInMemoryStore(user.New("rufus", user.WithIdentifier(identifier.NewMulti(push.FCMToken, "gone1", "device1", "gone2"))))
+		transport, server := newFCMTransport(t, push.WithTokenPruning(store))
+
+		u, err := store.Get(t.Context(), "rufus")
+		require.NoError(t, err)
+
+		err = transport.Push(t.Context(), notificationFor(u.Identifiers, "Your pipeline failed").Build())
+		require.NoError(t, err)
+		assert.Len(t, server.messages, 1)
+
+		u, err = store.Get(t.Context(), "rufus")
+		require.NoError(t, err)
+		assert.Equal(t, "device1", u.Identifiers.MustGet(push.FCMToken))
+	})
+}
+
+func TestFCMTransport_Push_Errors(t *testing.T) {
+	t.Parallel()
+
+	transport, _ := newFCMTransport(t)
+
+	tests := []struct {
+		name          string
+		tokens        []string
+		wantErr       string
+		wantPermanent bool
+	}{
+		{
+			name:          "all devices unregistered",
+			tokens:        []string{"gone1"},
+			wantErr:       push.ErrUnregistered.Error(),
+			wantPermanent: true,
+		},
+		{
+			name:          "invalid token",
+			tokens:        []string{"bad1", "gone1"},
+			wantErr:       "received HTTP status 400",
+			wantPermanent: true,
+		},
+		{
+			name:    "access token rejected",
+			tokens:  []string{"expired1"},
+			wantErr: "FCM rejected the access token: UNAUTHENTICATED",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			recipient := identifier.NewSet(identifier.NewMulti(push.FCMToken, tc.tokens...))
+
+			err := transport.Push(t.Context(), notificationFor(recipient, "hello").Build())
+			assert.ErrorContains(t, err, tc.wantErr)
+
+			var permanent *backoff.PermanentError
+			assert.Equal(t, tc.wantPermanent, errors.As(err, &permanent))
+		})
+	}
+}
+
+func TestNewFCMTransport_InvalidServiceAccount(t *testing.T) {
+	t.Parallel()
+
+	_, keyPEM := generateRSAKey(t)
+
+	_, err := push.NewFCMTransport("android", []byte("{"))
+	assert.ErrorContains(t, err, "invalid service account")
+
+	_, err = push.NewFCMTransport("android", []byte(`{"project_id": "my-project"}`))
+	assert.ErrorContains(t, err, "project_id, client_email and token_uri are required")
+
+	_, err = push.NewFCMTransport("android", serviceAccountJSON(t, "https://oauth2.googleapis.com/token", []byte("not a key")))
+	assert.ErrorContains(t, err, "private key is not PEM-encoded")
+
+	_, err = push.NewFCMTransport("android", serviceAccountJSON(t, "https://oauth2.googleapis.com/token", keyPEM))
+	assert.NoError(t, err)
+}
+
+func TestFCMTransport_Validate(t *testing.T) {
+	t.Parallel()
+
+	transport, _ := newFCMTransport(t)
+	assert.NoError(t, transport.Validate(t.Context()))
+
+	// Sign the assertion with a key the server doesn't know about
+	serverKey, _ := generateRSAKey(t)
+	server := newFakeFCM(t, serverKey)
+	_, otherPEM := generateRSAKey(t)
+	transport, err := push.NewFCMTransport("android", serviceAccountJSON(t, server.URL+"/token", otherPEM), push.WithBaseURL(server.URL))
+	require.NoError(t, err)
+	assert.ErrorContains(t, transport.Validate(t.Context()), "authentication failed")
+}
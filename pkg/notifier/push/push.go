@@ -0,0 +1,205 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package push provides notifier.Transport implementations for sending mobile push notifications via APNs and FCM
+package push
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification/extension"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/seatgeek/mailroom/pkg/validation"
+)
+
+var (
+	// APNsToken is the identifier for a user's APNs device tokens
+	// Users may have several devices, so store them with identifier.NewMulti.
+	APNsToken = identifier.NewNamespaceAndKind("apple.com", identifier.KindDeviceToken)
+	// FCMToken is the identifier for a user's FCM registration tokens
+	// Users may have several devices, so store them with identifier.NewMulti.
+	FCMToken = identifier.NewNamespaceAndKind("firebase.google.com", identifier.KindDeviceToken)
+)
+
+// ErrUnregistered is returned when the push service reports that a device token is no longer valid,
+// typically because the app was uninstalled
+var ErrUnregistered = errors.New("device token is no longer registered")
+
+// Message is a push notification
+type Message struct {
+	// Title is shown in bold above the body
+	Title string `json:"title,omitempty"`
+	// Body is the main text of the notification (defaults to the rendered message)
+	Body string `json:"body,omitempty"`
+	// Badge sets the number on the app icon, if set (0 clears the badge)
+	Badge *int `json:"badge,omitempty"`
+	// DeepLink is a URL for the app to open when the notification is tapped
+	DeepLink string `json:"deep_link,omitempty"`
+	// CollapseKey groups notifications so that a newer one replaces older ones which haven't been delivered yet
+	CollapseKey string `json:"collapse_key,omitempty"`
+}
+
+// Details sets the title, badge, etc. of the push notification (see notification.Builder.WithExtensions)
+// If the message has no body, the rendered message is used instead.
+var Details = extension.NewKey[Message]("push.message")
+
+// TokenPruner removes device tokens which are no longer registered
+// It's implemented by user stores supporting user.IdentifierValueRemover.
+type TokenPruner interface {
+	RemoveIdentifierValue(ctx context.Context, namespaceAndKind identifier.NamespaceAndKind, value string) error
+}
+
+// sender delivers a push notification to a single device
+type sender interface {
+	// send should wrap ErrUnregistered if the token is no longer valid
+	send(ctx context.Context, token string, msg Message) error
+	validate(ctx context.Context) error
+}
+
+// Transport supports sending push notifications to all of a user's devices
+type Transport struct {
+	key    event.TransportKey
+	tokens identifier.NamespaceAndKind
+	sender sender
+	pruner TokenPruner
+}
+
+var (
+	_ notifier.Transport   = &Transport{}
+	_ validation.Validator = &Transport{}
+)
+
+// Option configures a Transport
+type Option func(*options)
+
+type options struct {
+	client  *http.Client
+	baseURL string
+	pruner  TokenPruner
+}
+
+// WithHTTPClient sets the http.Client used to talk to the push service
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+// WithBaseURL overrides the push service endpoint, like APNsSandboxURL for development builds of your app
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithTokenPruning removes device tokens from the user store once the push service reports them as unregistered
+func WithTokenPruning(pruner TokenPruner) Option {
+	return func(o *options) {
+		o.pruner = pruner
+	}
+}
+
+func newOptions(defaultBaseURL string, opts []Option) options {
+	o := options{
+		client:  http.DefaultClient,
+		baseURL: defaultBaseURL,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// Push sends a notification to each of the recipient's devices
+// In addition to supporting event.Notification, it also supports the Details extension for titles, badges, etc.
+func (t *Transport) Push(ctx context.Context, notification event.Notification) error {
+	value, _ := notification.Recipient().Get(t.tokens)
+	tokens := identifier.New(t.tokens, value).Values()
+	if len(tokens) == 0 {
+		return notifier.Permanent(errors.New("recipient does not have any device tokens"))
+	}
+
+	msg := t.message(notification)
+	if msg.Title == "" && msg.Body == "" {
+		return notifier.Permanent(errors.New("message is empty"))
+	}
+
+	var retryable, permanent []error
+	unregistered := 0
+	for _, token := range tokens {
+		err := t.sender.send(ctx, token, msg)
+		var permanentErr *backoff.PermanentError
+		switch {
+		case err == nil:
+		case errors.Is(err, ErrUnregistered):
+			unregistered++
+			t.prune(ctx, token)
+		case errors.As(err, &permanentErr):
+			permanent = append(permanent, permanentErr.Unwrap())
+		default:
+			retryable = append(retryable, err)
+		}
+	}
+
+	if len(retryable) > 0 {
+		// Retrying will also resend to the devices which succeeded, but that's better than dropping the notification
+		for _, err := range permanent {
+			slog.WarnContext(ctx, "failed to send push notification", "transport", t.key, "error", err)
+		}
+		return errors.Join(retryable...)
+	}
+
+	if len(permanent) > 0 {
+		return notifier.Permanent(errors.Join(permanent...))
+	}
+
+	if unregistered == len(tokens) {
+		return notifier.Permanent(ErrUnregistered)
+	}
+
+	return nil
+}
+
+func (t *Transport) message(notification event.Notification) Message {
+	msg := Message{Body: notification.Render(t.key)}
+
+	if details, ok := Details.Of(notification); ok {
+		body := msg.Body
+		msg = details
+		if msg.Body == "" {
+			msg.Body = body
+		}
+	}
+
+	return msg
+}
+
+func (t *Transport) prune(ctx context.Context, token string) {
+	if t.pruner == nil {
+		return
+	}
+
+	if err := t.pruner.RemoveIdentifierValue(ctx, t.tokens, token); err != nil {
+		slog.ErrorContext(ctx, "failed to prune unregistered device token", "transport", t.key, "error", err)
+		return
+	}
+
+	slog.InfoContext(ctx, "pruned unregistered device token", "transport", t.key)
+}
+
+func (t *Transport) Key() event.TransportKey {
+	return t.key
+}
+
+func (t *Transport) Validate(ctx context.Context) error {
+	return t.sender.validate(ctx)
+}
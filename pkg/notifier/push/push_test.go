@@ -0,0 +1,83 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package push_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier/push"
+	"github.com/seatgeek/mailroom/pkg/user"
+	"github.com/stretchr/testify/require"
+)
+
+var _ push.TokenPruner = &user.InMemoryStore{}
+
+// generateKey returns a new private key and its PEM-encoded PKCS #8 form
+func generateKey[K crypto.Signer](t *testing.T, generate func() (K, error)) (K, []byte) {
+	t.Helper()
+
+	key, err := generate()
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return key, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func generateECKey(t *testing.T) (*ecdsa.PrivateKey, []byte) {
+	t.Helper()
+
+	return generateKey(t, func() (*ecdsa.PrivateKey, error) {
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	})
+}
+
+func generateRSAKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+
+	return generateKey(t, func() (*rsa.PrivateKey, error) {
+		return rsa.GenerateKey(rand.Reader, 2048)
+	})
+}
+
+// decodeJWT returns the header, claims and signature of a JWT without verifying it
+func decodeJWT(t *testing.T, token string) (map[string]any, map[string]any, []byte) {
+	t.Helper()
+
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+
+	decode := func(s string) []byte {
+		b, err := base64.RawURLEncoding.DecodeString(s)
+		require.NoError(t, err)
+		return b
+	}
+
+	var header, claims map[string]any
+	require.NoError(t, json.Unmarshal(decode(parts[0]), &header))
+	require.NoError(t, json.Unmarshal(decode(parts[1]), &claims))
+
+	return header, claims, decode(parts[2])
+}
+
+func notificationFor(recipient identifier.Set, message string) *notification.Builder {
+	return notification.NewBuilder(event.Context{Type: "com.example.test"}).
+		WithRecipient(recipient).
+		WithDefaultMessage(message)
+}
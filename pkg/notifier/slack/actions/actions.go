@@ -0,0 +1,51 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package actions lets Slack notifications carry buttons (and other interactive elements) which call back into mailroom
+package actions
+
+import (
+	"context"
+
+	"github.com/slack-go/slack"
+)
+
+// Action is a single interaction with an element of a Slack message, such as a button being clicked
+type Action struct {
+	// ID is the action_id of the element
+	ID string
+	// Value is the value of the element, such as a button's value
+	Value string
+	// BlockID is the ID of the block containing the element
+	BlockID string
+	// UserID is the Slack ID of the user who interacted with the element
+	UserID string
+	// Channel is the ID of the channel containing the message
+	Channel string
+	// MessageTS is the timestamp of the message
+	MessageTS string
+	// Callback is the full payload sent by Slack
+	Callback *slack.InteractionCallback
+}
+
+// Response is an optional reply to the user who triggered an Action
+type Response struct {
+	// Text is shown only to the user who triggered the action
+	Text string
+	// ReplaceOriginal replaces the original message with the Text, rather than replying to it
+	ReplaceOriginal bool
+}
+
+// ActionHandler handles a type of Action
+type ActionHandler interface {
+	// HandleAction handles the action and optionally returns a Response for the user
+	HandleAction(ctx context.Context, action Action) (*Response, error)
+}
+
+// ActionHandlerFunc is a function that implements the ActionHandler interface
+type ActionHandlerFunc func(ctx context.Context, action Action) (*Response, error)
+
+func (f ActionHandlerFunc) HandleAction(ctx context.Context, action Action) (*Response, error) {
+	return f(ctx, action)
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/seatgeek/mailroom/pkg/verifier"
+	"github.com/slack-go/slack"
+)
+
+// maxBodySize limits how much of a request is read; Slack's payloads are much smaller than this
+const maxBodySize = 1 << 20
+
+// errorText is shown to users when an ActionHandler fails
+const errorText = "Sorry, that didn't work. Please try again later."
+
+// Handler receives Slack's interactivity requests and dispatches each action to the ActionHandler registered for its action_id
+// Point your Slack app's "Interactivity Request URL" at it.
+// Slack expects an acknowledgement within 3 seconds, so requests are acknowledged straight away and actions are
+// handled in the background, with any Response sent via the interaction's response_url.
+type Handler struct {
+	verifier   verifier.Verifier
+	handlers   map[string]ActionHandler
+	httpClient *http.Client
+	inFlight   sync.WaitGroup
+}
+
+var _ http.Handler = &Handler{}
+
+// Option configures a Handler
+type Option func(*Handler)
+
+// WithActionHandler registers the ActionHandler for actions with the given action_id
+// Registering another handler for the same action_id replaces the earlier one.
+func WithActionHandler(actionID string, handler ActionHandler) Option {
+	return func(h *Handler) {
+		h.handlers[actionID] = handler
+	}
+}
+
+// WithHTTPClient sets the client used to send Responses to Slack
+func WithHTTPClient(client *http.Client) Option {
+	return func(h *Handler) {
+		h.httpClient = client
+	}
+}
+
+// NewHandler creates a new Handler which verifies requests using your Slack app's signing secret
+func NewHandler(signingSecret []byte, opts ...Option) *Handler {
+	h := &Handler{
+		verifier:   verifier.Slack(signingSecret),
+		handlers:   make(map[string]ActionHandler),
+		httpClient: http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+func (h *Handler) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(writer, request.Body, maxBodySize))
+	if err != nil {
+		http.Error(writer, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	if err = h.verifier.Verify(request, body); err != nil {
+		slog.WarnContext(request.Context(), "rejected Slack interaction", "error", err)
+		http.Error(writer, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(writer, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	var callback slack.InteractionCallback
+	if err = json.Unmarshal([]byte(form.Get("payload")), &callback); err != nil {
+		http.Error(writer, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	writer.WriteHeader(http.StatusOK)
+
+	// Other interactions (like shortcuts and modals) aren't supported, but still need to be acknowledged
+	if callback.Type != slack.InteractionTypeBlockActions {
+		return
+	}
+
+	// The request is done once we return, but the actions shouldn't be canceled along with it
+	ctx := context.WithoutCancel(request.Context())
+	h.inFlight.Go(func() {
+		for _, blockAction := range callback.ActionCallback.BlockActions {
+			h.dispatch(ctx, &callback, blockAction)
+		}
+	})
+}
+
+// Wait blocks until all actions being handled in the background have finished, such as when shutting down
+func (h *Handler) Wait() {
+	h.inFlight.Wait()
+}
+
+func (h *Handler) dispatch(ctx context.Context, callback *slack.InteractionCallback, blockAction *slack.BlockAction) {
+	handler, ok := h.handlers[blockAction.ActionID]
+	if !ok {
+		// Probably a link button, which Slack also tells us about
+		slog.DebugContext(ctx, "no handler for Slack action", "action_id", blockAction.ActionID)
+		return
+	}
+
+	action := Action{
+		ID:        blockAction.ActionID,
+		Value:     blockAction.Value,
+		BlockID:   blockAction.BlockID,
+		UserID:    callback.User.ID,
+		Channel:   callback.Channel.ID,
+		MessageTS: callback.Container.MessageTs,
+		Callback:  callback,
+	}
+
+	resp, err := handler.HandleAction(ctx, action)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to handle Slack action", "action_id", action.ID, "user_id", action.UserID, "error", err)
+		resp = &Response{Text: errorText}
+	}
+
+	if resp != nil {
+		h.respond(ctx, callback.ResponseURL, resp)
+	}
+}
+
+// respond sends the response to the user via the interaction's response_url
+func (h *Handler) respond(ctx context.Context, responseURL string, resp *Response) {
+	if responseURL == "" {
+		return
+	}
+
+	msg := &slack.WebhookMessage{
+		Text:            resp.Text,
+		ResponseType:    slack.ResponseTypeEphemeral,
+		ReplaceOriginal: resp.ReplaceOriginal,
+	}
+
+	if err := slack.PostWebhookCustomHTTPContext(ctx, responseURL, h.httpClient, msg); err != nil {
+		slog.ErrorContext(ctx, "failed to respond to Slack action", "error", err)
+	}
+}
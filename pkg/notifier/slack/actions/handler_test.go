@@ -0,0 +1,265 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package actions_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/notifier/slack/actions"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var secret = []byte("8f742231b10e8888abcd99yyyzzz85a5")
+
+// responses records the messages sent to an interaction's response_url
+type responses struct {
+	*httptest.Server
+	mu       sync.Mutex
+	messages []slack.WebhookMessage
+}
+
+func newResponses(t *testing.T) *responses {
+	t.Helper()
+
+	r := &responses{}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var msg slack.WebhookMessage
+		_ = json.NewDecoder(request.Body).Decode(&msg)
+
+		r.mu.Lock()
+		r.messages = append(r.messages, msg)
+		r.mu.Unlock()
+	}))
+	t.Cleanup(r.Close)
+
+	return r
+}
+
+func (r *responses) Messages() []slack.WebhookMessage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.messages
+}
+
+// clicked returns the payload Slack sends when the user clicks a button
+func clicked(responseURL string, userID string, actionID string, value string) *slack.InteractionCallback {
+	return &slack.InteractionCallback{
+		Type:        slack.InteractionTypeBlockActions,
+		ResponseURL: responseURL,
+		User:        slack.User{ID: userID},
+		Container:   slack.Container{MessageTs: "1700000000.000001"},
+		ActionCallback: slack.ActionCallbacks{
+			BlockActions: []*slack.BlockAction{{ActionID: actionID, BlockID: "actions", Value: value}},
+		},
+	}
+}
+
+func signedRequest(t *testing.T, callback *slack.InteractionCallback, signingSecret []byte, timestamp time.Time) *http.Request {
+	t.Helper()
+
+	payload, err := json.Marshal(callback)
+	require.NoError(t, err)
+
+	body := url.Values{"payload": {string(payload)}}.Encode()
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, signingSecret)
+	mac.Write([]byte("v0:" + ts + ":" + body))
+
+	req := httptest.NewRequest(http.MethodPost, "/slack/actions", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", ts)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+
+	return req
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	var got []actions.Action
+	approve := actions.ActionHandlerFunc(func(_ context.Context, action actions.Action) (*actions.Response, error) {
+		got = append(got, action)
+		return &actions.Response{Text: "Approved!", ReplaceOriginal: true}, nil
+	})
+	failing := actions.ActionHandlerFunc(func(_ context.Context, _ actions.Action) (*actions.Response, error) {
+		return nil, errors.New("database is down")
+	})
+	silent := actions.ActionHandlerFunc(func(_ context.Context, _ actions.Action) (*actions.Response, error) {
+		return nil, nil
+	})
+
+	tests := []struct {
+		name          string
+		actionID      string
+		secret        []byte
+		timestamp     time.Time
+		wantStatus    int
+		wantActions   int
+		wantResponses []slack.WebhookMessage
+	}{
+		{
+			name:          "dispatches to the registered handler",
+			actionID:      "approve",
+			secret:        secret,
+			timestamp:     time.Now(),
+			wantStatus:    http.StatusOK,
+			wantActions:   1,
+			wantResponses: []slack.WebhookMessage{{Text: "Approved!", ResponseType: "ephemeral", ReplaceOriginal: true}},
+		},
+		{
+			name:          "tells the user when the handler fails",
+			actionID:      "failing",
+			secret:        secret,
+			timestamp:     time.Now(),
+			wantStatus:    http.StatusOK,
+			wantResponses: []slack.WebhookMessage{{Text: "Sorry, that didn't work. Please try again later.", ResponseType: "ephemeral"}},
+		},
+		{
+			name:       "handlers don't need to respond",
+			actionID:   "silent",
+			secret:     secret,
+			timestamp:  time.Now(),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "acknowledges unknown actions",
+			actionID:   "open_link",
+			secret:     secret,
+			timestamp:  time.Now(),
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "rejects invalid signatures",
+			actionID:   "approve",
+			secret:     []byte("wrong"),
+			timestamp:  time.Now(),
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "rejects replayed requests",
+			actionID:   "approve",
+			secret:     secret,
+			timestamp:  time.Now().Add(-time.Hour),
+			wantStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got = nil
+			resps := newResponses(t)
+
+			handler := actions.NewHandler(secret,
+				actions.WithActionHandler("approve", approve),
+				actions.WithActionHandler("failing", failing),
+				actions.WithActionHandler("silent", silent),
+			)
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, signedRequest(t, clicked(resps.URL, "U123", tc.actionID, "mr-42"), tc.secret, tc.timestamp))
+			handler.Wait()
+
+			assert.Equal(t, tc.wantStatus, rec.Code)
+			assert.Len(t, got, tc.wantActions)
+			assert.Equal(t, tc.wantResponses, resps.Messages())
+		})
+	}
+}
+
+func TestHandler_ServeHTTP_Action(t *testing.T) {
+	t.Parallel()
+
+	var got actions.Action
+	handler := actions.NewHandler(secret, actions.WithActionHandler("approve", actions.ActionHandlerFunc(func(_ context.Context, action actions.Action) (*actions.Response, error) {
+		got = action
+		return nil, nil
+	})))
+
+	callback := clicked("", "U123", "approve", "mr-42")
+	callback.Channel.ID = "C999"
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, signedRequest(t, callback, secret, time.Now()))
+	require.Equal(t, http.StatusOK, rec.Code)
+	handler.Wait()
+
+	assert.Equal(t, "approve", got.ID)
+	assert.Equal(t, "mr-42", got.Value)
+	assert.Equal(t, "actions", got.BlockID)
+	assert.Equal(t, "U123", got.UserID)
+	assert.Equal(t, "C999", got.Channel)
+	assert.Equal(t, "1700000000.000001", got.MessageTS)
+	assert.NotNil(t, got.Callback)
+}
+
+func TestHandler_ServeHTTP_AcknowledgesFirst(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	var (
+		handled atomic.Bool
+		ctxErr  error
+	)
+	handler := actions.NewHandler(secret, actions.WithActionHandler("approve", actions.ActionHandlerFunc(func(ctx context.Context, _ actions.Action) (*actions.Response, error) {
+		<-release
+		ctxErr = ctx.Err()
+		handled.Store(true)
+		return nil, nil
+	})))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	req := signedRequest(t, clicked("", "U123", "approve", "mr-42"), secret, time.Now()).WithContext(ctx)
+
+	// Slack gets its acknowledgement before the (slow) handler is done
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.False(t, handled.Load())
+
+	// The handler carries on once the request is over
+	cancel()
+	close(release)
+	handler.Wait()
+	assert.True(t, handled.Load())
+	assert.NoError(t, ctxErr)
+}
+
+func TestHandler_ServeHTTP_InvalidPayload(t *testing.T) {
+	t.Parallel()
+
+	handler := actions.NewHandler(secret)
+
+	body := "payload=not-json"
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte("v0:" + ts + ":" + body))
+
+	req := httptest.NewRequest(http.MethodPost, "/slack/actions", strings.NewReader(body))
+	req.Header.Set("X-Slack-Request-Timestamp", ts)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notifier/preference"
+	mailroomslack "github.com/seatgeek/mailroom/pkg/notifier/slack"
+	"github.com/seatgeek/mailroom/pkg/user"
+	"github.com/slack-go/slack"
+)
+
+// SetPreferenceActionID is the action_id of buttons which change the clicking user's preferences
+const SetPreferenceActionID = "mailroom.set_preference"
+
+// PreferenceChange is the value of a SetPreferenceActionID button
+type PreferenceChange struct {
+	Type      event.Type         `json:"type"`
+	Transport event.TransportKey `json:"transport"`
+	Wants     bool               `json:"wants"`
+}
+
+// PreferenceButton creates a button which sets whether the user wants to receive events of the given type via the given transport
+// For example, PreferenceButton("Mute merge requests", "com.gitlab.merge_request.opened", "slack", false)
+func PreferenceButton(text string, eventType event.Type, transport event.TransportKey, wants bool) *slack.ButtonBlockElement {
+	// Marshaling a struct of strings and a bool can't fail
+	value, _ := json.Marshal(PreferenceChange{Type: eventType, Transport: transport, Wants: wants})
+
+	return slack.NewButtonBlockElement(SetPreferenceActionID, string(value), slack.NewTextBlockObject(slack.PlainTextType, text, false, false))
+}
+
+// NewPreferenceHandler creates an ActionHandler for SetPreferenceActionID buttons,
+// which updates the preferences of the user with the clicking user's Slack ID
+func NewPreferenceHandler(store user.Store) ActionHandler {
+	return ActionHandlerFunc(func(ctx context.Context, action Action) (*Response, error) {
+		var change PreferenceChange
+		if err := json.Unmarshal([]byte(action.Value), &change); err != nil || change.Type == "" || change.Transport == "" {
+			return nil, fmt.Errorf("invalid preference change %q", action.Value)
+		}
+
+		u, err := store.GetByIdentifier(ctx, identifier.New(mailroomslack.ID, action.UserID))
+		if errors.Is(err, user.ErrUserNotFound) {
+			return &Response{Text: "Sorry, I don't know who you are, so I can't change your preferences."}, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find user: %w", err)
+		}
+
+		prefs := make(preference.Map, len(u.Preferences)+1)
+		for eventType, transports := range u.Preferences {
+			prefs[eventType] = maps.Clone(transports)
+		}
+		if prefs[change.Type] == nil {
+			prefs[change.Type] = make(map[event.TransportKey]bool)
+		}
+		prefs[change.Type][change.Transport] = change.Wants
+
+		if err = store.SetPreferences(ctx, u.Key, prefs); err != nil {
+			return nil, fmt.Errorf("failed to set preferences: %w", err)
+		}
+
+		if change.Wants {
+			return &Response{Text: fmt.Sprintf("Done! You'll receive %s notifications via %s.", change.Type, change.Transport)}, nil
+		}
+
+		return &Response{Text: fmt.Sprintf("Done! You won't receive %s notifications via %s anymore.", change.Type, change.Transport)}, nil
+	})
+}
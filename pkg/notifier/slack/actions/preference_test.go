@@ -0,0 +1,114 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package actions_test
+
+import (
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notifier/preference"
+	mailroomslack "github.com/seatgeek/mailroom/pkg/notifier/slack"
+	"github.com/seatgeek/mailroom/pkg/notifier/slack/actions"
+	"github.com/seatgeek/mailroom/pkg/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreferenceButton(t *testing.T) {
+	t.Parallel()
+
+	button := actions.PreferenceButton("Mute merge requests", "com.gitlab.merge_request.opened", "slack", false)
+
+	assert.Equal(t, actions.SetPreferenceActionID, button.ActionID)
+	assert.Equal(t, "Mute merge requests", button.Text.Text)
+	assert.JSONEq(t, `{"type":"com.gitlab.merge_request.opened","transport":"slack","wants":false}`, button.Value)
+}
+
+func TestPreferenceHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		userID    string
+		value     string
+		wantText  string
+		wantErr   string
+		wantPrefs preference.Map
+	}{
+		{
+			name:     "opts out",
+			userID:   "U123",
+			value:    `{"type":"com.gitlab.merge_request.opened","transport":"slack","wants":false}`,
+			wantText: "Done! You won't receive com.gitlab.merge_request.opened notifications via slack anymore.",
+			wantPrefs: preference.Map{
+				"com.gitlab.merge_request.opened": {"slack": false, "email": true},
+				"com.gitlab.pipeline.failed":      {"slack": true},
+			},
+		},
+		{
+			name:     "opts in",
+			userID:   "U123",
+			value:    `{"type":"com.gitlab.issue.opened","transport":"slack","wants":true}`,
+			wantText: "Done! You'll receive com.gitlab.issue.opened notifications via slack.",
+			wantPrefs: preference.Map{
+				"com.gitlab.merge_request.opened": {"slack": true, "email": true},
+				"com.gitlab.pipeline.failed":      {"slack": true},
+				"com.gitlab.issue.opened":         {"slack": true},
+			},
+		},
+		{
+			name:     "unknown user",
+			userID:   "U999",
+			value:    `{"type":"com.gitlab.issue.opened","transport":"slack","wants":true}`,
+			wantText: "Sorry, I don't know who you are, so I can't change your preferences.",
+		},
+		{
+			name:    "invalid value",
+			userID:  "U123",
+			value:   `{"wants":true}`,
+			wantErr: "invalid preference change",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			original := preference.Map{
+				"com.gitlab.merge_request.opened": {"slack": true, "email": true},
+				"com.gitlab.pipeline.failed":      {"slack": true},
+			}
+			store := user.NewInMemoryStore(user.New("codell",
+				user.WithIdentifier(identifier.New(mailroomslack.ID, "U123")),
+				user.WithPreferences(original),
+			))
+
+			handler := actions.NewPreferenceHandler(store)
+			resp, err := handler.HandleAction(t.Context(), actions.Action{
+				ID:     actions.SetPreferenceActionID,
+				Value:  tc.value,
+				UserID: tc.userID,
+			})
+
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantText, resp.Text)
+
+			if tc.wantPrefs != nil {
+				u, err := store.Get(t.Context(), "codell")
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantPrefs, u.Preferences)
+			}
+
+			// The user's existing preferences aren't modified in place
+			assert.Equal(t, map[event.TransportKey]bool{"slack": true, "email": true}, original["com.gitlab.merge_request.opened"])
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/seatgeek/mailroom/pkg/content"
+	"github.com/slack-go/slack"
+)
+
+// maxSectionFields is the most fields Slack allows in a single section block
+const maxSectionFields = 10
+
+var severityEmoji = map[content.Severity]string{
+	content.SeverityInfo:     ":information_source:",
+	content.SeveritySuccess:  ":white_check_mark:",
+	content.SeverityWarning:  ":warning:",
+	content.SeverityCritical: ":rotating_light:",
+}
+
+// Blocks renders structured content as Slack blocks
+func Blocks(c *content.Content) []slack.Block {
+	if c == nil {
+		return nil
+	}
+
+	var blocks []slack.Block
+
+	if c.Title != "" {
+		title := c.Title
+		if emoji, ok := severityEmoji[c.Severity]; ok {
+			title = emoji + " " + title
+		}
+		blocks = append(blocks, slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, title, true, false)))
+	}
+
+	if c.Body != "" {
+		blocks = append(blocks, slack.NewSectionBlock(markdown(c.Body), nil, nil))
+	}
+
+	blocks = append(blocks, fieldBlocks(c.Fields)...)
+
+	if c.Image != nil {
+		alt := c.Image.AltText
+		if alt == "" {
+			alt = c.Title
+		}
+		blocks = append(blocks, slack.NewImageBlock(c.Image.URL, alt, "", nil))
+	}
+
+	if len(c.Links) > 0 {
+		links := make([]string, len(c.Links))
+		for i, l := range c.Links {
+			links[i] = link(l.Text, l.URL)
+		}
+		blocks = append(blocks, slack.NewContextBlock("", markdown(strings.Join(links, " · "))))
+	}
+
+	if len(c.Actions) > 0 {
+		buttons := make([]slack.BlockElement, len(c.Actions))
+		for i, a := range c.Actions {
+			buttons[i] = button(i, a)
+		}
+		blocks = append(blocks, slack.NewActionBlock("", buttons...))
+	}
+
+	return blocks
+}
+
+// fieldBlocks puts short fields side-by-side in sections, and others in sections of their own
+func fieldBlocks(fields []content.Field) []slack.Block {
+	var blocks []slack.Block
+	var short []*slack.TextBlockObject
+
+	flush := func() {
+		if len(short) > 0 {
+			blocks = append(blocks, slack.NewSectionBlock(nil, short, nil))
+			short = nil
+		}
+	}
+
+	for _, f := range fields {
+		text := markdown(fmt.Sprintf("*%s*\n%s", f.Name, f.Value))
+		if !f.Short {
+			flush()
+			blocks = append(blocks, slack.NewSectionBlock(text, nil, nil))
+			continue
+		}
+
+		short = append(short, text)
+		if len(short) == maxSectionFields {
+			flush()
+		}
+	}
+	flush()
+
+	return blocks
+}
+
+func button(i int, a content.Action) *slack.ButtonBlockElement {
+	// Every button needs an action_id, even those which only open a URL
+	id := a.ID
+	if id == "" {
+		id = fmt.Sprintf("link_%d", i)
+	}
+
+	b := slack.NewButtonBlockElement(id, a.Value, slack.NewTextBlockObject(slack.PlainTextType, a.Text, true, false))
+	if a.URL != "" {
+		b.WithURL(a.URL)
+	}
+
+	switch a.Style {
+	case content.ActionStylePrimary:
+		b.WithStyle(slack.StylePrimary)
+	case content.ActionStyleDanger:
+		b.WithStyle(slack.StyleDanger)
+	}
+
+	return b
+}
+
+func markdown(text string) *slack.TextBlockObject {
+	return slack.NewTextBlockObject(slack.MarkdownType, text, false, false)
+}
+
+func link(text string, url string) string {
+	if text == "" {
+		return "<" + url + ">"
+	}
+
+	return "<" + url + "|" + text + ">"
+}
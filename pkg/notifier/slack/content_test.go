@@ -0,0 +1,120 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package slack_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/content"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier/slack"
+	slackgo "github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pipelineFailed = content.Content{
+	Title:    "Pipeline failed",
+	Body:     "The *build* job failed on `main`",
+	Severity: content.SeverityCritical,
+	Fields: []content.Field{
+		{Name: "Project", Value: "seatgeek/mailroom", Short: true},
+		{Name: "Pipeline", Value: "#1234", Short: true},
+		{Name: "Error", Value: "exit status 1"},
+	},
+	Links: []content.Link{
+		{Text: "Pipeline", URL: "https://gitlab.example.com/pipelines/1234"},
+		{URL: "https://gitlab.example.com/commits/abc123"},
+	},
+	Actions: []content.Action{
+		{Text: "Retry", URL: "https://gitlab.example.com/pipelines/1234/retry", Style: content.ActionStylePrimary},
+		{ID: "mailroom.set_preference", Text: "Mute", Value: "pipeline", Style: content.ActionStyleDanger},
+	},
+	Image: &content.Image{URL: "https://example.com/graph.png"},
+}
+
+const pipelineFailedBlocks = `[
+	{"type": "header", "text": {"type": "plain_text", "text": ":rotating_light: Pipeline failed", "emoji": true}},
+	{"type": "section", "text": {"type": "mrkdwn", "text": "The *build* job failed on ` + "`main`" + `"}},
+	{"type": "section", "fields": [
+		{"type": "mrkdwn", "text": "*Project*\nseatgeek/mailroom"},
+		{"type": "mrkdwn", "text": "*Pipeline*\n#1234"}
+	]},
+	{"type": "section", "text": {"type": "mrkdwn", "text": "*Error*\nexit status 1"}},
+	{"type": "image", "image_url": "https://example.com/graph.png", "alt_text": "Pipeline failed"},
+	{"type": "context", "elements": [
+		{"type": "mrkdwn", "text": "<https://gitlab.example.com/pipelines/1234|Pipeline> · <https://gitlab.example.com/commits/abc123>"}
+	]},
+	{"type": "actions", "elements": [
+		{"type": "button", "action_id": "link_0", "text": {"type": "plain_text", "text": "Retry", "emoji": true}, "url": "https://gitlab.example.com/pipelines/1234/retry", "style": "primary"},
+		{"type": "button", "action_id": "mailroom.set_preference", "text": {"type": "plain_text", "text": "Mute", "emoji": true}, "value": "pipeline", "style": "danger"}
+	]}
+]`
+
+func TestBlocks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		content *content.Content
+		want    string
+	}{
+		{
+			name:    "nil",
+			content: nil,
+			want:    `null`,
+		},
+		{
+			name:    "title without severity",
+			content: &content.Content{Title: "Pipeline passed"},
+			want:    `[{"type": "header", "text": {"type": "plain_text", "text": "Pipeline passed", "emoji": true}}]`,
+		},
+		{
+			name:    "everything",
+			content: &pipelineFailed,
+			want:    pipelineFailedBlocks,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := json.Marshal(slack.Blocks(tc.content))
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(got))
+		})
+	}
+}
+
+func TestTransport_Push_Content(t *testing.T) {
+	t.Parallel()
+
+	fake := newFakeSlack(t)
+	transport := fake.transport(slack.NewInMemoryMessageStore())
+
+	builder := func() *notification.Builder {
+		return notification.NewBuilder(event.Context{ID: "test-id", Type: "com.example.pipeline"}).
+			WithRecipientIdentifiers(identifier.New(slack.ID, "U123")).
+			WithContent(content.Content{Title: "Pipeline failed"})
+	}
+
+	require.NoError(t, transport.Push(t.Context(), builder().Build()))
+
+	// Slack options win over content
+	require.NoError(t, transport.Push(t.Context(), builder().
+		WithSlackOptions(slackgo.MsgOptionBlocks(slackgo.NewDividerBlock())).
+		Build()))
+
+	calls := fake.Calls()
+	require.Len(t, calls, 2)
+
+	assert.Equal(t, "Pipeline failed", calls[0].Text)
+	assert.JSONEq(t, `[{"type": "header", "text": {"type": "plain_text", "text": "Pipeline failed", "emoji": true}}]`, calls[0].Blocks)
+	assert.JSONEq(t, `[{"type": "divider"}]`, calls[1].Blocks)
+}
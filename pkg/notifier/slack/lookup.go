@@ -0,0 +1,266 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package slack
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/validation"
+	"github.com/slack-go/slack"
+)
+
+const (
+	// DefaultLookupTTL is how long a Slack ID found by email is cached
+	DefaultLookupTTL = 24 * time.Hour
+	// DefaultNotFoundTTL is how long an email without a Slack user is cached
+	DefaultNotFoundTTL = time.Hour
+
+	// maxRateLimitRetries is how many times a lookup is retried after being rate limited
+	maxRateLimitRetries = 3
+)
+
+// Email is the identifier for the email address a user has in Slack, which is preferred when looking them up
+var Email = identifier.NewNamespaceAndKind("slack.com", identifier.KindEmail)
+
+// IdentifierSaver saves the Slack IDs found by the EmailLookupProcessor
+// It's implemented by user stores supporting user.IdentifierAdder.
+type IdentifierSaver interface {
+	AddIdentifier(ctx context.Context, possibleIdentifiers identifier.Set, id identifier.Identifier) error
+}
+
+// EmailLookupProcessor is an event.Processor which finds the Slack ID of recipients who don't have one,
+// using the email addresses they're known by
+// It should run after notifications are generated (and enriched from the user.Store), but before they're sent.
+type EmailLookupProcessor struct {
+	client      *slack.Client
+	ttl         time.Duration
+	notFoundTTL time.Duration
+	saver       IdentifierSaver
+
+	mu          sync.Mutex
+	cache       map[string]cachedLookup
+	pausedUntil time.Time
+}
+
+type cachedLookup struct {
+	// id is empty if there's no Slack user with the email
+	id        string
+	expiresAt time.Time
+}
+
+var (
+	_ event.Processor      = &EmailLookupProcessor{}
+	_ validation.Validator = &EmailLookupProcessor{}
+)
+
+// NewEmailLookupProcessor creates a new EmailLookupProcessor
+// The token needs the users:read.email scope.
+func NewEmailLookupProcessor(token string, opts ...slack.Option) *EmailLookupProcessor {
+	return &EmailLookupProcessor{
+		client:      slack.New(token, opts...),
+		ttl:         DefaultLookupTTL,
+		notFoundTTL: DefaultNotFoundTTL,
+		cache:       make(map[string]cachedLookup),
+	}
+}
+
+// WithCacheTTL sets how long lookups are cached, both when a Slack user is found and when there isn't one
+func (p *EmailLookupProcessor) WithCacheTTL(found time.Duration, notFound time.Duration) *EmailLookupProcessor {
+	p.ttl = found
+	p.notFoundTTL = notFound
+	return p
+}
+
+// WithIdentifierSaver saves the Slack IDs which are found, such as in the user.Store, so they don't need to be looked up again
+func (p *EmailLookupProcessor) WithIdentifierSaver(saver IdentifierSaver) *EmailLookupProcessor {
+	p.saver = saver
+	return p
+}
+
+// Process adds a Slack ID to each recipient who has an email address but no Slack ID
+// Lookup failures are logged rather than returned, so that notifications can still be sent by other transports.
+func (p *EmailLookupProcessor) Process(ctx context.Context, evt event.Event, notifications []event.Notification) ([]event.Notification, error) {
+	for _, n := range notifications {
+		recipient := n.Recipient()
+		if recipient == nil {
+			continue
+		}
+		if _, ok := recipient.Get(ID); ok {
+			continue
+		}
+
+		for _, email := range emailsOf(recipient) {
+			id, err := p.lookup(ctx, email)
+			if err != nil {
+				slog.WarnContext(ctx, "failed to look up Slack user by email", "eventID", evt.ID, "email", email, "error", err)
+				continue
+			}
+			if id == "" {
+				continue
+			}
+
+			slackID := identifier.New(ID, id)
+			p.save(ctx, recipient.Copy(), slackID)
+			recipient.Add(slackID)
+			break
+		}
+	}
+
+	return notifications, nil
+}
+
+func (p *EmailLookupProcessor) save(ctx context.Context, recipient identifier.Set, slackID identifier.Identifier) {
+	if p.saver == nil {
+		return
+	}
+
+	// Recipients don't need to be in the store, so failing to find them is expected
+	if err := p.saver.AddIdentifier(ctx, recipient, slackID); err != nil {
+		slog.DebugContext(ctx, "did not save Slack ID", "recipient", recipient.String(), "slack_id", slackID.Value, "error", err)
+	}
+}
+
+// lookup returns the ID of the Slack user with the given email, or an empty string if there isn't one
+func (p *EmailLookupProcessor) lookup(ctx context.Context, email string) (string, error) {
+	if id, ok := p.cached(email); ok {
+		return id, nil
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := p.waitForRateLimit(ctx); err != nil {
+			return "", err
+		}
+
+		u, err := p.client.GetUserByEmailContext(ctx, email)
+
+		var rateLimited *slack.RateLimitedError
+		switch {
+		case err == nil && !u.Deleted:
+			p.remember(email, u.ID, p.ttl)
+			return u.ID, nil
+		case err == nil || isUsersNotFound(err):
+			p.remember(email, "", p.notFoundTTL)
+			return "", nil
+		case errors.As(err, &rateLimited) && attempt < maxRateLimitRetries:
+			p.pause(rateLimited.RetryAfter)
+		default:
+			return "", err
+		}
+	}
+}
+
+func (p *EmailLookupProcessor) cached(email string) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	lookup, ok := p.cache[email]
+	if !ok {
+		return "", false
+	}
+
+	if time.Now().After(lookup.expiresAt) {
+		delete(p.cache, email)
+		return "", false
+	}
+
+	return lookup.id, true
+}
+
+func (p *EmailLookupProcessor) remember(email string, id string, ttl time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.cache[email] = cachedLookup{id: id, expiresAt: time.Now().Add(ttl)}
+}
+
+// pause stops all lookups until Slack's rate limit has reset
+func (p *EmailLookupProcessor) pause(retryAfter time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if until := time.Now().Add(retryAfter); until.After(p.pausedUntil) {
+		p.pausedUntil = until
+	}
+}
+
+func (p *EmailLookupProcessor) waitForRateLimit(ctx context.Context) error {
+	p.mu.Lock()
+	wait := time.Until(p.pausedUntil)
+	p.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Validate checks that the token is valid
+func (p *EmailLookupProcessor) Validate(ctx context.Context) error {
+	if _, err := p.client.AuthTestContext(ctx); err != nil {
+		return fmt.Errorf("authentication failed: %w", err)
+	}
+
+	return nil
+}
+
+// emailsOf returns the recipient's distinct email addresses, starting with the one they use in Slack (if known)
+func emailsOf(recipient identifier.Set) []string {
+	var ids []identifier.Identifier
+	for _, id := range recipient.ToList() {
+		if id.Kind() == identifier.KindEmail {
+			ids = append(ids, id)
+		}
+	}
+
+	slices.SortFunc(ids, func(a, b identifier.Identifier) int {
+		return cmp.Or(cmp.Compare(emailRank(a), emailRank(b)), cmp.Compare(a.NamespaceAndKind, b.NamespaceAndKind))
+	})
+
+	var emails []string
+	for _, id := range ids {
+		email := strings.ToLower(id.Value)
+		if !slices.Contains(emails, email) {
+			emails = append(emails, email)
+		}
+	}
+
+	return emails
+}
+
+// emailRank prefers the Slack email, then the generic one, then any others
+func emailRank(id identifier.Identifier) int {
+	switch {
+	case id.NamespaceAndKind == Email:
+		return 0
+	case id.Namespace() == "":
+		return 1
+	default:
+		return 2
+	}
+}
+
+func isUsersNotFound(err error) bool {
+	var slackErr slack.SlackErrorResponse
+	return errors.As(err, &slackErr) && slackErr.Err == "users_not_found"
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package postgres provides a postgresql-backed implementation of the slack.MessageStore interface
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/notifier/slack"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MessageModel is the gorm model for a Slack message sent with a thread or message key
+type MessageModel struct {
+	Key       string `gorm:"primarykey"`
+	Channel   string
+	Timestamp string
+	UpdatedAt time.Time
+}
+
+func (m *MessageModel) TableName() string {
+	return "slack_messages"
+}
+
+type Store struct {
+	db *gorm.DB
+}
+
+var _ slack.MessageStore = &Store{}
+
+// NewPostgresStore creates a new postgres store
+func NewPostgresStore(db *gorm.DB) *Store {
+	return &Store{db: db}
+}
+
+// Get implements slack.MessageStore.
+func (s *Store) Get(ctx context.Context, key string) (*slack.MessageRef, error) {
+	var model MessageModel
+	if err := s.db.WithContext(ctx).Where("key = ?", key).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, slack.ErrMessageNotFound
+		}
+		return nil, err
+	}
+
+	return &slack.MessageRef{Channel: model.Channel, Timestamp: model.Timestamp}, nil
+}
+
+// Put implements slack.MessageStore.
+func (s *Store) Put(ctx context.Context, key string, ref slack.MessageRef) error {
+	return s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"channel", "timestamp", "updated_at"}),
+	}).Create(&MessageModel{
+		Key:       key,
+		Channel:   ref.Channel,
+		Timestamp: ref.Timestamp,
+	}).Error
+}
+
+// Delete implements slack.MessageStore.
+func (s *Store) Delete(ctx context.Context, key string) error {
+	return s.db.WithContext(ctx).Delete(&MessageModel{Key: key}).Error
+}
+
+// Prune deletes messages which haven't been sent or updated since the given time.
+// Threads older than that will be started anew, and older messages can no longer be updated.
+func (s *Store) Prune(ctx context.Context, before time.Time) error {
+	return s.db.WithContext(ctx).Where("updated_at < ?", before).Delete(&MessageModel{}).Error
+}
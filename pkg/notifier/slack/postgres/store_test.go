@@ -0,0 +1,83 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/notifier/slack"
+	"github.com/seatgeek/mailroom/pkg/notifier/slack/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+	pgtc "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	pg "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestPostgresStore(t *testing.T) {
+	t.Parallel()
+
+	store := createDatastore(t)
+
+	_, err := store.Get(t.Context(), "U123/mr-1")
+	assert.ErrorIs(t, err, slack.ErrMessageNotFound)
+
+	assert.NoError(t, store.Put(t.Context(), "U123/mr-1", slack.MessageRef{Channel: "D123", Timestamp: "1700000000.000100"}))
+	assert.NoError(t, store.Put(t.Context(), "C123/mr-1", slack.MessageRef{Channel: "C123", Timestamp: "1700000000.000200"}))
+
+	ref, err := store.Get(t.Context(), "U123/mr-1")
+	assert.NoError(t, err)
+	assert.Equal(t, &slack.MessageRef{Channel: "D123", Timestamp: "1700000000.000100"}, ref)
+
+	// Putting again replaces the message
+	assert.NoError(t, store.Put(t.Context(), "U123/mr-1", slack.MessageRef{Channel: "D123", Timestamp: "1700000000.000300"}))
+	ref, err = store.Get(t.Context(), "U123/mr-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "1700000000.000300", ref.Timestamp)
+
+	assert.NoError(t, store.Delete(t.Context(), "U123/mr-1"))
+	_, err = store.Get(t.Context(), "U123/mr-1")
+	assert.ErrorIs(t, err, slack.ErrMessageNotFound)
+
+	// Pruning only removes messages older than the given time
+	assert.NoError(t, store.Prune(t.Context(), time.Now().Add(-time.Hour)))
+	_, err = store.Get(t.Context(), "C123/mr-1")
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.Prune(t.Context(), time.Now().Add(time.Hour)))
+	_, err = store.Get(t.Context(), "C123/mr-1")
+	assert.ErrorIs(t, err, slack.ErrMessageNotFound)
+}
+
+func createDatastore(t *testing.T) *postgres.Store {
+	t.Helper()
+
+	ctx := context.Background()
+
+	container, err := pgtc.Run(ctx, "postgres:16.2",
+		pgtc.WithInitScripts("../../../../test/initdb/init.sql"),
+		pgtc.WithDatabase("mailroom"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second)),
+	)
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, container.Terminate(ctx))
+	})
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable", "application_name=test")
+	assert.NoError(t, err)
+
+	db, err := gorm.Open(pg.Open(dsn), &gorm.Config{})
+	assert.NoError(t, err)
+
+	return postgres.NewPostgresStore(db)
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/seatgeek/mailroom/pkg/content"
 	"github.com/seatgeek/mailroom/pkg/event"
 	"github.com/seatgeek/mailroom/pkg/identifier"
 	"github.com/seatgeek/mailroom/pkg/notifier"
@@ -22,8 +23,9 @@ var ID = identifier.NewNamespaceAndKind("slack.com", identifier.KindID)
 
 // Transport supports sending messages to Slack
 type Transport struct {
-	key    event.TransportKey
-	client *slack.Client
+	key      event.TransportKey
+	client   *slack.Client
+	messages MessageStore
 }
 
 // RichNotification is an optional interface that can be implemented by any notification supporting Slack formatting
@@ -35,18 +37,128 @@ type RichNotification interface {
 
 // Push sends a notification to a Slack user
 // In addition to supporting common.Notification, it also supports RichNotification for more complex messages
-// that might include attachments, blocks, etc.
+// that might include attachments, blocks, etc., content.Notification (which is rendered as blocks), and
+// the Destination extension for posting to channels, threading and updating earlier messages.
 func (s *Transport) Push(ctx context.Context, notification event.Notification) error {
-	id, ok := notification.Recipient().Get(ID)
-	if !ok {
-		return notifier.Permanent(errors.New("recipient does not have a Slack ID"))
+	target, _ := Destination.Of(notification)
+
+	destination := target.Channel
+	if destination == "" {
+		id, ok := notification.Recipient().Get(ID)
+		if !ok {
+			return notifier.Permanent(errors.New("recipient does not have a Slack ID"))
+		}
+		destination = id
+	}
+
+	if target.Delete {
+		return s.delete(ctx, destination, target.MessageKey)
 	}
 
 	options := s.getMessageOptions(notification)
 
-	_, _, err := s.client.PostMessageContext(ctx, id, options...)
+	if target.MessageKey != "" {
+		updated, err := s.update(ctx, destination, target.MessageKey, options)
+		if updated || err != nil {
+			return err
+		}
+	}
 
-	return err
+	return s.post(ctx, destination, &target, options)
+}
+
+// post sends a new message, replying in the target's thread if it has already been started
+// Thread and message keys are scoped to the destination (a channel, or the recipient's Slack ID).
+func (s *Transport) post(ctx context.Context, destination string, target *Target, options []slack.MsgOption) error {
+	channel := destination
+
+	var parent *MessageRef
+	if target.ThreadKey != "" {
+		var err error
+		parent, err = s.messages.Get(ctx, messageKey(destination, target.ThreadKey))
+		if err != nil && !errors.Is(err, ErrMessageNotFound) {
+			return fmt.Errorf("failed to look up thread: %w", err)
+		}
+	}
+
+	if parent != nil {
+		// Replies must go to the DM channel the thread was started in, rather than the user ID
+		channel = parent.Channel
+		options = append(options, slack.MsgOptionTS(parent.Timestamp))
+		if target.Broadcast {
+			options = append(options, slack.MsgOptionBroadcast())
+		}
+	}
+
+	channel, timestamp, err := s.client.PostMessageContext(ctx, channel, options...)
+	if err != nil {
+		return err
+	}
+
+	// The message was sent, so failing to remember it shouldn't cause it to be sent again
+	sent := MessageRef{Channel: channel, Timestamp: timestamp}
+	if target.ThreadKey != "" && parent == nil {
+		s.remember(ctx, destination, target.ThreadKey, sent)
+	}
+	if target.MessageKey != "" {
+		s.remember(ctx, destination, target.MessageKey, sent)
+	}
+
+	return nil
+}
+
+// update replaces the message previously sent with the given key, returning false if there isn't one
+func (s *Transport) update(ctx context.Context, destination string, key string, options []slack.MsgOption) (bool, error) {
+	ref, err := s.messages.Get(ctx, messageKey(destination, key))
+	if errors.Is(err, ErrMessageNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to look up message: %w", err)
+	}
+
+	_, _, _, err = s.client.UpdateMessageContext(ctx, ref.Channel, ref.Timestamp, options...)
+	if isMessageNotFound(err) {
+		// It was deleted in Slack, so post a new one instead
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+// delete removes the message previously sent with the given key, if there is one
+func (s *Transport) delete(ctx context.Context, destination string, key string) error {
+	if key == "" {
+		return notifier.Permanent(errors.New("deleting a message requires a message key"))
+	}
+
+	ref, err := s.messages.Get(ctx, messageKey(destination, key))
+	if errors.Is(err, ErrMessageNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to look up message: %w", err)
+	}
+
+	if _, _, err := s.client.DeleteMessageContext(ctx, ref.Channel, ref.Timestamp); err != nil && !isMessageNotFound(err) {
+		return err
+	}
+
+	return s.messages.Delete(ctx, messageKey(destination, key))
+}
+
+func (s *Transport) remember(ctx context.Context, destination string, key string, ref MessageRef) {
+	if err := s.messages.Put(ctx, messageKey(destination, key), ref); err != nil {
+		slog.ErrorContext(ctx, "failed to remember Slack message", "transport", s.key, "key", key, "error", err)
+	}
+}
+
+// messageKey scopes a thread or message key to where it was sent, since each recipient gets their own copy
+func messageKey(destination string, key string) string {
+	return destination + "/" + key
+}
+
+func isMessageNotFound(err error) bool {
+	var slackErr slack.SlackErrorResponse
+	return errors.As(err, &slackErr) && slackErr.Err == "message_not_found"
 }
 
 func (s *Transport) getMessageOptions(notification event.Notification) []slack.MsgOption {
@@ -57,11 +169,17 @@ func (s *Transport) getMessageOptions(notification event.Notification) []slack.M
 		opts = append(opts, slack.MsgOptionText(message, false))
 	}
 
+	var rich []slack.MsgOption
 	if n, ok := notification.(RichNotification); ok {
-		opts = append(opts, n.GetSlackOptions()...)
+		rich = n.GetSlackOptions()
+	}
+
+	// Slack options take priority over structured content, since they were made just for Slack
+	if blocks := Blocks(content.Of(notification)); len(rich) == 0 && len(blocks) > 0 {
+		rich = []slack.MsgOption{slack.MsgOptionBlocks(blocks...)}
 	}
 
-	return opts
+	return append(opts, rich...)
 }
 
 func (s *Transport) Key() event.TransportKey {
@@ -85,9 +203,17 @@ var (
 
 // NewTransport creates a new Slack Transport
 // It requires a TransportID, a Slack API token, and optionally some slack.Options
+// Threads and updatable messages are remembered in memory for DefaultMessageTTL unless WithMessageStore is used.
 func NewTransport(key event.TransportKey, token string, opts ...slack.Option) *Transport {
 	return &Transport{
-		key:    key,
-		client: slack.New(token, opts...),
+		key:      key,
+		client:   slack.New(token, opts...),
+		messages: NewInMemoryMessageStore(),
 	}
 }
+
+// WithMessageStore sets the MessageStore used to remember threads and updatable messages, such as one that persists them
+func (s *Transport) WithMessageStore(store MessageStore) *Transport {
+	s.messages = store
+	return s
+}
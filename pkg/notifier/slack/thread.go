@@ -0,0 +1,154 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package slack
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/notification/extension"
+)
+
+// ErrMessageNotFound is returned by a MessageStore when no message was sent with the given key
+var ErrMessageNotFound = errors.New("slack message not found")
+
+// Target controls where a notification is posted in Slack, and how it relates to previously sent messages
+type Target struct {
+	// Channel is the ID (or name) of a channel to post in, instead of sending a direct message to the recipient
+	// Notifications are still pushed once per recipient, so if an event notifies several recipients, each of them
+	// posts to the channel. Set a MessageKey (like the event ID) to post once and update that message instead.
+	Channel string `json:"channel,omitempty"`
+	// ThreadKey groups related notifications (like all pipelines for one merge request) into a single thread:
+	// the first notification is posted as usual, and later ones reply to it
+	ThreadKey string `json:"thread_key,omitempty"`
+	// Broadcast also shows a thread reply in the channel (or DM) itself
+	Broadcast bool `json:"broadcast,omitempty"`
+	// MessageKey identifies a message which later notifications with the same key update in place,
+	// like "pipeline running" becoming "pipeline passed"
+	MessageKey string `json:"message_key,omitempty"`
+	// Delete removes the message previously sent with the same MessageKey, instead of posting anything
+	Delete bool `json:"delete,omitempty"`
+}
+
+// Destination posts the notification to a channel, in a thread, or over an earlier message (see
+// notification.Builder.WithExtensions); notifications without one are sent to the recipient as usual
+var Destination = extension.NewKey[Target]("slack.target")
+
+// MessageRef points to a message that was sent to Slack
+type MessageRef struct {
+	Channel   string
+	Timestamp string
+}
+
+// MessageStore remembers which Slack message was sent for each thread or message key.
+// Implementations may be backed by a SQL database, an in-memory store, or something else.
+type MessageStore interface {
+	// Get returns the message sent with the given key, or ErrMessageNotFound
+	Get(ctx context.Context, key string) (*MessageRef, error)
+	// Put records the message sent with the given key, replacing any previous one
+	Put(ctx context.Context, key string, ref MessageRef) error
+	// Delete forgets the message sent with the given key
+	Delete(ctx context.Context, key string) error
+}
+
+// DefaultMessageTTL is how long an InMemoryMessageStore remembers messages by default
+const DefaultMessageTTL = 7 * 24 * time.Hour
+
+// InMemoryMessageStore is a simple in-memory implementation of the MessageStore interface
+// This is especially useful for testing, but threads are forgotten on restart.
+// Messages are also forgotten once they're older than the TTL, so that the store doesn't grow forever.
+type InMemoryMessageStore struct {
+	refs       map[string]storedRef
+	ttl        time.Duration
+	lastPruned time.Time
+	mu         sync.RWMutex
+}
+
+type storedRef struct {
+	MessageRef
+	updatedAt time.Time
+}
+
+var _ MessageStore = &InMemoryMessageStore{}
+
+// NewInMemoryMessageStore creates a new, empty in-memory store which remembers messages for DefaultMessageTTL
+func NewInMemoryMessageStore() *InMemoryMessageStore {
+	return &InMemoryMessageStore{
+		refs:       make(map[string]storedRef),
+		ttl:        DefaultMessageTTL,
+		lastPruned: time.Now(),
+	}
+}
+
+// WithTTL sets how long messages are remembered after they were last sent or updated
+func (s *InMemoryMessageStore) WithTTL(ttl time.Duration) *InMemoryMessageStore {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ttl = ttl
+	return s
+}
+
+func (s *InMemoryMessageStore) Get(_ context.Context, key string) (*MessageRef, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	ref, ok := s.refs[key]
+	if !ok || time.Since(ref.updatedAt) > s.ttl {
+		return nil, ErrMessageNotFound
+	}
+
+	return &ref.MessageRef, nil
+}
+
+func (s *InMemoryMessageStore) Put(_ context.Context, key string, ref MessageRef) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.refs[key] = storedRef{MessageRef: ref, updatedAt: now}
+
+	// Expired messages are pruned as new ones come in, at most once per TTL
+	if now.Sub(s.lastPruned) > s.ttl {
+		s.prune(now.Add(-s.ttl))
+		s.lastPruned = now
+	}
+
+	return nil
+}
+
+func (s *InMemoryMessageStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.refs, key)
+	return nil
+}
+
+// Prune deletes messages which haven't been sent or updated since the given time
+func (s *InMemoryMessageStore) Prune(_ context.Context, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prune(before)
+	return nil
+}
+
+func (s *InMemoryMessageStore) prune(before time.Time) {
+	maps.DeleteFunc(s.refs, func(_ string, ref storedRef) bool {
+		return ref.updatedAt.Before(before)
+	})
+}
+
+// Len returns the number of messages in the store, including any which have expired but haven't been pruned yet
+func (s *InMemoryMessageStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return len(s.refs)
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package sms
+
+import (
+	"strings"
+	"unicode/utf16"
+)
+
+// gsmBasic is the GSM 03.38 basic character set; each of these takes one septet
+const gsmBasic = "@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?" +
+	"¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà"
+
+// gsmExtended characters are sent using an escape sequence, so each takes two septets
+const gsmExtended = "^{}\\[~]|€\f"
+
+const (
+	gsmSingle   = 160 // septets in a single GSM-7 message
+	gsmConcat   = 153 // septets per part of a concatenated GSM-7 message
+	ucs2Single  = 70  // UTF-16 code units in a single UCS-2 message
+	ucs2Concat  = 67  // UTF-16 code units per part of a concatenated UCS-2 message
+	ellipsis    = "…"
+	gsmEllipsis = "..."
+)
+
+// encoding describes how a message's characters count toward the length of each segment
+type encoding struct {
+	single, concat int
+	width          func(rune) int
+}
+
+func encodingFor(text string) encoding {
+	if isGSM(text) {
+		return encoding{single: gsmSingle, concat: gsmConcat, width: gsmWidth}
+	}
+
+	return encoding{single: ucs2Single, concat: ucs2Concat, width: utf16.RuneLen}
+}
+
+func isGSM(text string) bool {
+	for _, r := range text {
+		if gsmWidth(r) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func gsmWidth(r rune) int {
+	switch {
+	case strings.ContainsRune(gsmBasic, r):
+		return 1
+	case strings.ContainsRune(gsmExtended, r):
+		return 2
+	default:
+		return 0
+	}
+}
+
+func (e encoding) length(text string) int {
+	n := 0
+	for _, r := range text {
+		n += e.width(r)
+	}
+
+	return n
+}
+
+// Segments returns the number of SMS segments needed to send the given text
+// Messages using only the GSM-7 alphabet fit 160 characters in a single segment (153 per segment when split);
+// any other character forces UCS-2 encoding, which fits 70 (or 67) characters instead.
+func Segments(text string) int {
+	enc := encodingFor(text)
+	length := enc.length(text)
+
+	if length <= enc.single {
+		return 1
+	}
+
+	return (length + enc.concat - 1) / enc.concat
+}
+
+// Truncate shortens the text so it fits within maxSegments, ending it with an ellipsis if anything was removed
+func Truncate(text string, maxSegments int) string {
+	if maxSegments < 1 || Segments(text) <= maxSegments {
+		return text
+	}
+
+	enc := encodingFor(text)
+	suffix := ellipsis
+	if isGSM(text) {
+		// "…" isn't part of the GSM alphabet, and using it would switch the whole message to UCS-2
+		suffix = gsmEllipsis
+	}
+
+	limit := enc.single
+	if maxSegments > 1 {
+		limit = enc.concat * maxSegments
+	}
+	limit -= enc.length(suffix)
+
+	var sb strings.Builder
+	n := 0
+	for _, r := range text {
+		w := enc.width(r)
+		if n+w > limit {
+			break
+		}
+		sb.WriteRune(r)
+		n += w
+	}
+
+	return strings.TrimRight(sb.String(), " \n") + suffix
+}
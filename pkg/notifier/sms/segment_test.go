@@ -0,0 +1,104 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package sms_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/notifier/sms"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSegments(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		text string
+		want int
+	}{
+		{name: "empty", text: "", want: 1},
+		{name: "short", text: "Your pipeline failed", want: 1},
+		{name: "full GSM-7 segment", text: strings.Repeat("a", 160), want: 1},
+		{name: "two GSM-7 segments", text: strings.Repeat("a", 161), want: 2},
+		{name: "three GSM-7 segments", text: strings.Repeat("a", 307), want: 3},
+		{name: "extended characters count twice", text: strings.Repeat("€", 80), want: 1},
+		{name: "extended characters overflow", text: strings.Repeat("€", 81), want: 2},
+		{name: "full UCS-2 segment", text: strings.Repeat("✓", 70), want: 1},
+		{name: "two UCS-2 segments", text: strings.Repeat("✓", 71), want: 2},
+		{name: "one non-GSM character switches to UCS-2", text: strings.Repeat("a", 70) + "✓", want: 2},
+		{name: "emoji use two UTF-16 code units", text: strings.Repeat("🔥", 35), want: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, sms.Segments(tc.text))
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		text        string
+		maxSegments int
+		want        string
+	}{
+		{
+			name:        "fits",
+			text:        "Your pipeline failed",
+			maxSegments: 1,
+			want:        "Your pipeline failed",
+		},
+		{
+			name:        "unlimited",
+			text:        strings.Repeat("a", 1000),
+			maxSegments: 0,
+			want:        strings.Repeat("a", 1000),
+		},
+		{
+			name:        "GSM-7 to one segment",
+			text:        strings.Repeat("a", 200),
+			maxSegments: 1,
+			want:        strings.Repeat("a", 157) + "...",
+		},
+		{
+			name:        "GSM-7 to two segments",
+			text:        strings.Repeat("a", 400),
+			maxSegments: 2,
+			want:        strings.Repeat("a", 303) + "...",
+		},
+		{
+			name:        "UCS-2 to one segment",
+			text:        strings.Repeat("✓", 100),
+			maxSegments: 1,
+			want:        strings.Repeat("✓", 69) + "…",
+		},
+		{
+			name:        "trailing whitespace is trimmed",
+			text:        strings.Repeat("a", 156) + " " + strings.Repeat("b", 10),
+			maxSegments: 1,
+			want:        strings.Repeat("a", 156) + "...",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := sms.Truncate(tc.text, tc.maxSegments)
+
+			assert.Equal(t, tc.want, got)
+			if tc.maxSegments > 0 {
+				assert.LessOrEqual(t, sms.Segments(got), tc.maxSegments)
+			}
+		})
+	}
+}
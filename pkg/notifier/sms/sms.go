@@ -0,0 +1,141 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package sms provides a notifier.Transport implementation for sending notifications as text messages
+package sms
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/seatgeek/mailroom/pkg/validation"
+)
+
+var (
+	// ErrInvalidNumber should be returned (or wrapped) by a Provider when the recipient's number can't receive messages
+	ErrInvalidNumber = errors.New("invalid phone number")
+	// ErrOptedOut should be returned (or wrapped) by a Provider when the recipient has unsubscribed from messages
+	ErrOptedOut = errors.New("recipient has opted out of messages")
+)
+
+// DefaultMaxSegments is the default number of segments a message may use before it's truncated
+const DefaultMaxSegments = 3
+
+// Provider sends text messages through some SMS gateway
+type Provider interface {
+	// Send sends the body to the given E.164 phone number
+	// It should wrap ErrInvalidNumber or ErrOptedOut when applicable, so the Transport won't retry those messages
+	Send(ctx context.Context, to string, body string) error
+}
+
+// Transport supports sending messages via SMS
+type Transport struct {
+	key         event.TransportKey
+	provider    Provider
+	maxSegments int
+}
+
+var (
+	_ notifier.Transport   = &Transport{}
+	_ validation.Validator = &Transport{}
+)
+
+// Option configures a Transport
+type Option func(*Transport)
+
+// WithMaxSegments sets the number of segments a message may use before it's truncated
+// Carriers bill each segment separately, so keep this low. Use 0 to disable truncation.
+func WithMaxSegments(maxSegments int) Option {
+	return func(t *Transport) {
+		t.maxSegments = maxSegments
+	}
+}
+
+// NewTransport creates a new SMS Transport
+// It requires a TransportKey, a Provider, and optionally some Options
+func NewTransport(key event.TransportKey, provider Provider, opts ...Option) *Transport {
+	t := &Transport{
+		key:         key,
+		provider:    provider,
+		maxSegments: DefaultMaxSegments,
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// Push sends a notification as a text message
+func (t *Transport) Push(ctx context.Context, notification event.Notification) error {
+	raw, ok := Number(notification.Recipient())
+	if !ok {
+		return notifier.Permanent(errors.New("recipient does not have a phone number"))
+	}
+
+	to, err := identifier.NormalizePhone(raw)
+	if err != nil {
+		return notifier.Permanent(err)
+	}
+
+	body := notification.Render(t.key)
+	if body == "" {
+		return notifier.Permanent(errors.New("message is empty"))
+	}
+
+	err = t.provider.Send(ctx, to, Truncate(body, t.maxSegments))
+	if errors.Is(err, ErrInvalidNumber) || errors.Is(err, ErrOptedOut) {
+		return notifier.Permanent(err)
+	}
+
+	return err
+}
+
+func (t *Transport) Key() event.TransportKey {
+	return t.key
+}
+
+// Validate validates the Provider, if it supports validation
+func (t *Transport) Validate(ctx context.Context) error {
+	if v, ok := t.provider.(validation.Validator); ok {
+		if err := v.Validate(ctx); err != nil {
+			return fmt.Errorf("SMS provider is invalid: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Number returns the phone number to use for the given recipient
+// identifier.GenericPhone takes precedence; otherwise, any namespaced phone number (like "pagerduty.com/phone") is used.
+func Number(recipient identifier.Set) (string, bool) {
+	if number, ok := recipient.Get(identifier.GenericPhone); ok && number != "" {
+		return number, true
+	}
+
+	// Sort the candidates so the choice is stable when multiple namespaces provide a number
+	var candidates []identifier.Identifier
+	for _, id := range recipient.ToList() {
+		if id.Kind() == identifier.KindPhone && id.Value != "" {
+			candidates = append(candidates, id)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	slices.SortFunc(candidates, func(a, b identifier.Identifier) int {
+		return cmp.Compare(a.NamespaceAndKind, b.NamespaceAndKind)
+	})
+
+	return candidates[0].Value, true
+}
@@ -0,0 +1,174 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package sms_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier/sms"
+	"github.com/stretchr/testify/assert"
+)
+
+type sent struct {
+	to, body string
+}
+
+type fakeProvider struct {
+	sent    []sent
+	returns error
+}
+
+func (f *fakeProvider) Send(_ context.Context, to string, body string) error {
+	if f.returns != nil {
+		return f.returns
+	}
+
+	f.sent = append(f.sent, sent{to: to, body: body})
+	return nil
+}
+
+func notificationFor(message string, ids ...identifier.Identifier) event.Notification {
+	return notification.NewBuilder(event.Context{Type: "com.example.test"}).
+		WithRecipientIdentifiers(ids...).
+		WithDefaultMessage(message).
+		Build()
+}
+
+func TestNumber(t *testing.T) {
+	t.Parallel()
+
+	number, ok := sms.Number(identifier.NewSet(
+		identifier.New("pagerduty.com/phone", "+14155550100"),
+		identifier.New(identifier.GenericPhone, "+14155552671"),
+	))
+	assert.True(t, ok)
+	assert.Equal(t, "+14155552671", number)
+
+	number, ok = sms.Number(identifier.NewSet(
+		identifier.New("pagerduty.com/phone", "+14155550100"),
+		identifier.New("opsgenie.com/phone", "+14155550199"),
+	))
+	assert.True(t, ok)
+	assert.Equal(t, "+14155550199", number)
+
+	_, ok = sms.Number(identifier.NewSet(identifier.New(identifier.GenericEmail, "rufus@example.com")))
+	assert.False(t, ok)
+}
+
+func TestTransport_Push(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		notification event.Notification
+		opts         []sms.Option
+		want         sent
+	}{
+		{
+			name:         "normalizes the number",
+			notification: notificationFor("Your pipeline failed", identifier.New(identifier.GenericPhone, "+1 (415) 555-2671")),
+			want:         sent{to: "+14155552671", body: "Your pipeline failed"},
+		},
+		{
+			name:         "truncates long messages",
+			notification: notificationFor(strings.Repeat("a", 1000), identifier.New(identifier.GenericPhone, "+14155552671")),
+			opts:         []sms.Option{sms.WithMaxSegments(1)},
+			want:         sent{to: "+14155552671", body: strings.Repeat("a", 157) + "..."},
+		},
+		{
+			name:         "truncation can be disabled",
+			notification: notificationFor(strings.Repeat("a", 1000), identifier.New(identifier.GenericPhone, "+14155552671")),
+			opts:         []sms.Option{sms.WithMaxSegments(0)},
+			want:         sent{to: "+14155552671", body: strings.Repeat("a", 1000)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			provider := &fakeProvider{}
+			transport := sms.NewTransport("sms", provider, tc.opts...)
+
+			assert.NoError(t, transport.Push(t.Context(), tc.notification))
+			assert.Equal(t, []sent{tc.want}, provider.sent)
+		})
+	}
+}
+
+func TestTransport_Push_Errors(t *testing.T) {
+	t.Parallel()
+
+	someNumber := identifier.New(identifier.GenericPhone, "+14155552671")
+
+	tests := []struct {
+		name          string
+		notification  event.Notification
+		providerErr   error
+		wantErr       string
+		wantPermanent bool
+	}{
+		{
+			name:          "recipient without phone number",
+			notification:  notificationFor("hello", identifier.New(identifier.GenericEmail, "rufus@example.com")),
+			wantErr:       "recipient does not have a phone number",
+			wantPermanent: true,
+		},
+		{
+			name:          "malformed phone number",
+			notification:  notificationFor("hello", identifier.New(identifier.GenericPhone, "555-2671")),
+			wantErr:       "invalid E.164 phone number",
+			wantPermanent: true,
+		},
+		{
+			name:          "empty message",
+			notification:  notificationFor("", someNumber),
+			wantErr:       "message is empty",
+			wantPermanent: true,
+		},
+		{
+			name:          "provider rejects the number",
+			notification:  notificationFor("hello", someNumber),
+			providerErr:   fmt.Errorf("%w: not a mobile number", sms.ErrInvalidNumber),
+			wantErr:       "invalid phone number: not a mobile number",
+			wantPermanent: true,
+		},
+		{
+			name:          "recipient opted out",
+			notification:  notificationFor("hello", someNumber),
+			providerErr:   sms.ErrOptedOut,
+			wantErr:       "recipient has opted out of messages",
+			wantPermanent: true,
+		},
+		{
+			name:         "other provider errors are retryable",
+			notification: notificationFor("hello", someNumber),
+			providerErr:  errors.New("gateway timeout"),
+			wantErr:      "gateway timeout",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			transport := sms.NewTransport("sms", &fakeProvider{returns: tc.providerErr})
+
+			err := transport.Push(t.Context(), tc.notification)
+			assert.ErrorContains(t, err, tc.wantErr)
+
+			var permanent *backoff.PermanentError
+			assert.Equal(t, tc.wantPermanent, errors.As(err, &permanent))
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/seatgeek/mailroom/pkg/validation"
+)
+
+// DefaultTwilioBaseURL is the Twilio REST API endpoint
+const DefaultTwilioBaseURL = "https://api.twilio.com"
+
+// Twilio error codes which mean the message will never be delivered
+// See https://www.twilio.com/docs/api/errors
+var twilioErrors = map[int]error{
+	21211: ErrInvalidNumber, // Invalid 'To' Phone Number
+	21214: ErrInvalidNumber, // 'To' phone number cannot be reached
+	21217: ErrInvalidNumber, // Phone number does not appear to be valid
+	21614: ErrInvalidNumber, // 'To' number is not a valid mobile number
+	21610: ErrOptedOut,      // Attempt to send to unsubscribed recipient
+}
+
+// TwilioProvider sends messages using Twilio's Programmable Messaging API
+// It also works with Twilio-compatible providers (see WithTwilioBaseURL).
+type TwilioProvider struct {
+	accountSID string
+	authToken  string
+	from       string
+	baseURL    string
+	client     *http.Client
+}
+
+var (
+	_ Provider             = &TwilioProvider{}
+	_ validation.Validator = &TwilioProvider{}
+)
+
+// TwilioOption configures a TwilioProvider
+type TwilioOption func(*TwilioProvider)
+
+// WithTwilioBaseURL overrides the API endpoint, for use with Twilio-compatible providers
+func WithTwilioBaseURL(baseURL string) TwilioOption {
+	return func(p *TwilioProvider) {
+		p.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithTwilioHTTPClient sets the http.Client used to talk to Twilio
+func WithTwilioHTTPClient(client *http.Client) TwilioOption {
+	return func(p *TwilioProvider) {
+		p.client = client
+	}
+}
+
+// NewTwilio creates a new TwilioProvider
+// The sender may be a phone number or the SID of a Messaging Service (starting with "MG").
+func NewTwilio(accountSID, authToken, from string, opts ...TwilioOption) *TwilioProvider {
+	p := &TwilioProvider{
+		accountSID: accountSID,
+		authToken:  authToken,
+		from:       from,
+		baseURL:    DefaultTwilioBaseURL,
+		client:     http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Send implements Provider
+func (p *TwilioProvider) Send(ctx context.Context, to string, body string) error {
+	form := url.Values{
+		"To":   {to},
+		"Body": {body},
+	}
+	if strings.HasPrefix(p.from, "MG") {
+		form.Set("MessagingServiceSid", p.from)
+	} else {
+		form.Set("From", p.from)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.accountURL("/Messages.json"), strings.NewReader(form.Encode()))
+	if err != nil {
+		return notifier.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return p.do(req)
+}
+
+// Validate checks the credentials by fetching the account
+func (p *TwilioProvider) Validate(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.accountURL(".json"), nil)
+	if err != nil {
+		return err
+	}
+
+	if err := p.do(req); err != nil {
+		return notifier.Permanent(fmt.Errorf("authentication failed: %w", err))
+	}
+
+	slog.InfoContext(ctx, "Twilio provider connected", "account", p.accountSID)
+	return nil
+}
+
+func (p *TwilioProvider) accountURL(suffix string) string {
+	return p.baseURL + "/2010-04-01/Accounts/" + url.PathEscape(p.accountSID) + suffix
+}
+
+func (p *TwilioProvider) do(req *http.Request) error {
+	req.SetBasicAuth(p.accountSID, p.authToken)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	err = notifier.CheckHTTPResponse(resp)
+
+	var statusErr *notifier.HTTPStatusError
+	if !errors.As(err, &statusErr) {
+		return err
+	}
+
+	var twilioErr struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal([]byte(statusErr.Body), &twilioErr) != nil {
+		return err
+	}
+
+	if sentinel, ok := twilioErrors[twilioErr.Code]; ok {
+		return fmt.Errorf("%w: %s (twilio error %d)", sentinel, twilioErr.Message, twilioErr.Code)
+	}
+
+	return err
+}
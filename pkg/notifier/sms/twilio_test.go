@@ -0,0 +1,180 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package sms_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/notifier/sms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	accountSID = "AC123"
+	authToken  = "some-token"
+)
+
+// fakeTwilio is a local stand-in for the Twilio Messaging API
+type fakeTwilio struct {
+	*httptest.Server
+
+	mu   sync.Mutex
+	sent []url.Values
+}
+
+func newFakeTwilio(t *testing.T) *fakeTwilio {
+	t.Helper()
+
+	f := &fakeTwilio{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /2010-04-01/Accounts/AC123.json", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"sid": "AC123", "status": "active"}`))
+	})
+	mux.HandleFunc("POST /2010-04-01/Accounts/AC123/Messages.json", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+
+		switch r.PostForm.Get("To") {
+		case "+15005550001":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code": 21211, "message": "The 'To' number +15005550001 is not a valid phone number.", "status": 400}`))
+		case "+15005550004":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"code": 21610, "message": "Attempt to send to unsubscribed recipient", "status": 400}`))
+		case "+15005550009":
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"code": 20503, "message": "Service unavailable", "status": 503}`))
+		default:
+			f.mu.Lock()
+			f.sent = append(f.sent, r.PostForm)
+			f.mu.Unlock()
+
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"sid": "SM123", "status": "queued"}`))
+		}
+	})
+
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != accountSID || pass != authToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"code": 20003, "message": "Authenticate", "status": 401}`))
+			return
+		}
+		mux.ServeHTTP(w, r)
+	}))
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+func TestTwilioProvider_Send(t *testing.T) {
+	t.Parallel()
+
+	t.Run("from a phone number", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeTwilio(t)
+		provider := sms.NewTwilio(accountSID, authToken, "+15005550006", sms.WithTwilioBaseURL(server.URL))
+
+		require.NoError(t, provider.Send(t.Context(), "+14155552671", "Your pipeline failed"))
+
+		assert.Equal(t, []url.Values{{
+			"To":   {"+14155552671"},
+			"From": {"+15005550006"},
+			"Body": {"Your pipeline failed"},
+		}}, server.sent)
+	})
+
+	t.Run("from a messaging service", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeTwilio(t)
+		provider := sms.NewTwilio(accountSID, authToken, "MG123", sms.WithTwilioBaseURL(server.URL))
+
+		require.NoError(t, provider.Send(t.Context(), "+14155552671", "Your pipeline failed"))
+
+		assert.Equal(t, []url.Values{{
+			"To":                  {"+14155552671"},
+			"MessagingServiceSid": {"MG123"},
+			"Body":                {"Your pipeline failed"},
+		}}, server.sent)
+	})
+}
+
+func TestTwilioProvider_Send_Errors(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeTwilio(t)
+
+	tests := []struct {
+		name      string
+		token     string
+		to        string
+		wantIs    error
+		wantErr   string
+		wantNotIs []error
+	}{
+		{
+			name:    "invalid number",
+			token:   authToken,
+			to:      "+15005550001",
+			wantIs:  sms.ErrInvalidNumber,
+			wantErr: "invalid phone number: The 'To' number +15005550001 is not a valid phone number. (twilio error 21211)",
+		},
+		{
+			name:    "opted out",
+			token:   authToken,
+			to:      "+15005550004",
+			wantIs:  sms.ErrOptedOut,
+			wantErr: "(twilio error 21610)",
+		},
+		{
+			name:      "unavailable",
+			token:     authToken,
+			to:        "+15005550009",
+			wantErr:   "received HTTP status 503",
+			wantNotIs: []error{sms.ErrInvalidNumber, sms.ErrOptedOut},
+		},
+		{
+			name:      "bad credentials",
+			token:     "wrong",
+			to:        "+14155552671",
+			wantErr:   "received HTTP status 401",
+			wantNotIs: []error{sms.ErrInvalidNumber, sms.ErrOptedOut},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			provider := sms.NewTwilio(accountSID, tc.token, "+15005550006", sms.WithTwilioBaseURL(server.URL))
+
+			err := provider.Send(t.Context(), tc.to, "hello")
+
+			assert.ErrorContains(t, err, tc.wantErr)
+			if tc.wantIs != nil {
+				assert.ErrorIs(t, err, tc.wantIs)
+			}
+			for _, notIs := range tc.wantNotIs {
+				assert.NotErrorIs(t, err, notIs)
+			}
+		})
+	}
+}
+
+func TestTwilioProvider_Validate(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeTwilio(t)
+
+	assert.NoError(t, sms.NewTransport("sms", sms.NewTwilio(accountSID, authToken, "MG123", sms.WithTwilioBaseURL(server.URL))).Validate(t.Context()))
+	assert.ErrorContains(t, sms.NewTransport("sms", sms.NewTwilio(accountSID, "wrong", "MG123", sms.WithTwilioBaseURL(server.URL))).Validate(t.Context()), "authentication failed")
+}
@@ -0,0 +1,213 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package teams
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/notifier"
+)
+
+const (
+	// DefaultServiceURL is the Bot Framework service URL for Teams in the public cloud
+	DefaultServiceURL = "https://smba.trafficmanager.net/teams/"
+
+	defaultTokenURL = "https://login.microsoftonline.com/%s/oauth2/v2.0/token"
+	botScope        = "https://api.botframework.com/.default"
+)
+
+// BotConfig holds the Bot Framework (Azure Bot) credentials used by NewBotTransport
+type BotConfig struct {
+	// AppID is the bot's Microsoft App ID
+	AppID string
+	// AppPassword is the client secret for the bot's app registration
+	AppPassword string
+	// TenantID is the Microsoft Entra tenant users belong to
+	TenantID string
+	// ServiceURL is the Bot Framework endpoint (defaults to DefaultServiceURL)
+	ServiceURL string
+	// SingleTenant should be set for single-tenant bots, which authenticate against TenantID instead of botframework.com
+	SingleTenant bool
+}
+
+// WithTokenURL overrides the OAuth token endpoint used by bots, such as for sovereign clouds
+// The URL may contain a single %s, which is replaced by the tenant.
+func WithTokenURL(tokenURL string) Option {
+	return func(o *options) {
+		o.tokenURL = tokenURL
+	}
+}
+
+// botSender sends proactive 1:1 messages as a Bot Framework bot
+type botSender struct {
+	config   BotConfig
+	client   apiClient
+	tokenURL string
+
+	mu            sync.Mutex
+	token         string
+	tokenExpiry   time.Time
+	conversations map[string]string // user ID -> conversation ID
+}
+
+func newBotSender(config BotConfig, o options) *botSender {
+	if config.ServiceURL == "" {
+		config.ServiceURL = DefaultServiceURL
+	}
+
+	tenant := "botframework.com"
+	if config.SingleTenant {
+		tenant = config.TenantID
+	}
+
+	tokenURL := o.tokenURL
+	if strings.Contains(tokenURL, "%s") {
+		tokenURL = fmt.Sprintf(tokenURL, tenant)
+	}
+
+	return &botSender{
+		config:        config,
+		client:        apiClient{o.client},
+		tokenURL:      tokenURL,
+		conversations: make(map[string]string),
+	}
+}
+
+func (b *botSender) send(ctx context.Context, to recipient, act activity) error {
+	header, err := b.authHeader(ctx)
+	if err != nil {
+		return err
+	}
+
+	conversation, err := b.conversation(ctx, header, to.id)
+	if err != nil {
+		return fmt.Errorf("failed to create conversation: %w", err)
+	}
+
+	// The plain-text message would be displayed above the card, so only send one or the other
+	if len(act.Attachments) > 0 {
+		act.Text = ""
+	}
+
+	return b.client.postJSON(ctx, b.endpoint("v3/conversations", conversation, "activities"), header, act, nil)
+}
+
+// validate checks the bot's credentials by requesting an access token
+func (b *botSender) validate(ctx context.Context) error {
+	if _, err := b.authHeader(ctx); err != nil {
+		return notifier.Permanent(fmt.Errorf("authentication failed: %w", err))
+	}
+
+	slog.InfoContext(ctx, "Teams bot authenticated", "app_id", b.config.AppID)
+	return nil
+}
+
+// conversation returns the ID of the 1:1 conversation between the bot and the user, creating it if needed
+func (b *botSender) conversation(ctx context.Context, header http.Header, userID string) (string, error) {
+	b.mu.Lock()
+	id, ok := b.conversations[userID]
+	b.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	req := map[string]any{
+		"bot":         map[string]string{"id": b.config.AppID},
+		"members":     []map[string]string{{"id": userID}},
+		"channelData": map[string]any{"tenant": map[string]string{"id": b.config.TenantID}},
+		"isGroup":     false,
+	}
+
+	var resp struct {
+		ID string `json:"id"`
+	}
+	if err := b.client.postJSON(ctx, b.endpoint("v3/conversations"), header, req, &resp); err != nil {
+		return "", err
+	}
+
+	b.mu.Lock()
+	b.conversations[userID] = resp.ID
+	b.mu.Unlock()
+
+	return resp.ID, nil
+}
+
+func (b *botSender) endpoint(parts ...string) string {
+	for i := range parts {
+		parts[i] = strings.Trim(parts[i], "/")
+		if i > 0 {
+			parts[i] = url.PathEscape(parts[i])
+		}
+	}
+
+	return strings.TrimSuffix(b.config.ServiceURL, "/") + "/" + strings.Join(parts, "/")
+}
+
+// authHeader returns an Authorization header with a (cached) access token
+func (b *botSender) authHeader(ctx context.Context) (http.Header, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.token == "" || time.Now().After(b.tokenExpiry) {
+		if err := b.refreshToken(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return http.Header{"Authorization": {"Bearer " + b.token}}, nil
+}
+
+// refreshToken fetches a new access token using the client credentials flow
+func (b *botSender) refreshToken(ctx context.Context) error {
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {b.config.AppID},
+		"client_secret": {b.config.AppPassword},
+		"scope":         {botScope},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return notifier.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("token request failed with status %d", resp.StatusCode)
+		if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+			// Bad credentials won't fix themselves
+			return notifier.Permanent(err)
+		}
+		return err
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return fmt.Errorf("failed to decode token response: %w", err)
+	}
+
+	// Refresh a little early so the token doesn't expire mid-request
+	b.token = token.AccessToken
+	b.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+
+	return nil
+}
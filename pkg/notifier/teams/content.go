@@ -0,0 +1,88 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package teams
+
+import (
+	"github.com/seatgeek/mailroom/pkg/content"
+)
+
+// severityColors maps severities onto Adaptive Card colors
+var severityColors = map[content.Severity]string{
+	content.SeverityInfo:     "Accent",
+	content.SeveritySuccess:  "Good",
+	content.SeverityWarning:  "Warning",
+	content.SeverityCritical: "Attention",
+}
+
+var actionStyles = map[content.ActionStyle]string{
+	content.ActionStylePrimary: "positive",
+	content.ActionStyleDanger:  "destructive",
+}
+
+// Card renders structured content as an Adaptive Card
+// Interactive actions are left out, since they can't be handled by Teams; actions with a URL become buttons, as do links.
+func Card(c *content.Content) AdaptiveCard {
+	if c == nil {
+		return nil
+	}
+
+	var body, actions []any
+
+	if c.Title != "" {
+		title := map[string]any{"type": "TextBlock", "text": c.Title, "size": "Medium", "weight": "Bolder", "wrap": true}
+		if color, ok := severityColors[c.Severity]; ok {
+			title["color"] = color
+		}
+		body = append(body, title)
+	}
+
+	if c.Body != "" {
+		body = append(body, map[string]any{"type": "TextBlock", "text": c.Body, "wrap": true})
+	}
+
+	if len(c.Fields) > 0 {
+		facts := make([]any, len(c.Fields))
+		for i, f := range c.Fields {
+			facts[i] = map[string]any{"title": f.Name, "value": f.Value}
+		}
+		body = append(body, map[string]any{"type": "FactSet", "facts": facts})
+	}
+
+	if c.Image != nil {
+		body = append(body, map[string]any{"type": "Image", "url": c.Image.URL, "altText": c.Image.AltText})
+	}
+
+	for _, a := range c.Actions {
+		if a.URL == "" {
+			continue
+		}
+
+		action := map[string]any{"type": "Action.OpenUrl", "title": a.Text, "url": a.URL}
+		if style, ok := actionStyles[a.Style]; ok {
+			action["style"] = style
+		}
+		actions = append(actions, action)
+	}
+
+	for _, l := range c.Links {
+		title := l.Text
+		if title == "" {
+			title = l.URL
+		}
+		actions = append(actions, map[string]any{"type": "Action.OpenUrl", "title": title, "url": l.URL})
+	}
+
+	card := AdaptiveCard{
+		"type":    "AdaptiveCard",
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"version": "1.4",
+		"body":    body,
+	}
+	if len(actions) > 0 {
+		card["actions"] = actions
+	}
+
+	return card
+}
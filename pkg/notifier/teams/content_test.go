@@ -0,0 +1,80 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package teams_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/content"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier/teams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCard(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, teams.Card(nil))
+
+	got, err := json.Marshal(teams.Card(&content.Content{
+		Title:    "Pipeline failed",
+		Body:     "The build job failed",
+		Severity: content.SeverityCritical,
+		Fields: []content.Field{
+			{Name: "Project", Value: "seatgeek/mailroom", Short: true},
+		},
+		Links: []content.Link{
+			{URL: "https://gitlab.example.com/commits/abc123"},
+		},
+		Actions: []content.Action{
+			{Text: "Retry", URL: "https://gitlab.example.com/pipelines/1234/retry", Style: content.ActionStylePrimary},
+			{ID: "mute", Text: "Mute"},
+		},
+		Image: &content.Image{URL: "https://example.com/graph.png", AltText: "Build times"},
+	}))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"type": "AdaptiveCard",
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"version": "1.4",
+		"body": [
+			{"type": "TextBlock", "text": "Pipeline failed", "size": "Medium", "weight": "Bolder", "color": "Attention", "wrap": true},
+			{"type": "TextBlock", "text": "The build job failed", "wrap": true},
+			{"type": "FactSet", "facts": [{"title": "Project", "value": "seatgeek/mailroom"}]},
+			{"type": "Image", "url": "https://example.com/graph.png", "altText": "Build times"}
+		],
+		"actions": [
+			{"type": "Action.OpenUrl", "title": "Retry", "url": "https://gitlab.example.com/pipelines/1234/retry", "style": "positive"},
+			{"type": "Action.OpenUrl", "title": "https://gitlab.example.com/commits/abc123", "url": "https://gitlab.example.com/commits/abc123"}
+		]
+	}`, string(got))
+}
+
+func TestWebhookTransport_Push_Content(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeTeams(t, http.StatusOK)
+	transport := teams.NewWebhookTransport("teams", server.URL+"/webhook")
+
+	require.NoError(t, transport.Push(t.Context(), notification.NewBuilder(event.Context{Type: "com.example.test"}).
+		WithRecipientIdentifiers(identifier.New(teams.ID, userID)).
+		WithContent(content.Content{Title: "Pipeline failed"}).
+		Build()))
+
+	received := server.received("/webhook")
+	require.Len(t, received, 1)
+
+	card := received[0]["attachments"].([]any)[0].(map[string]any)["content"].(map[string]any)
+	body := card["body"].([]any)
+	require.Len(t, body, 2)
+	assert.Equal(t, "Pipeline failed", body[1].(map[string]any)["text"])
+	assert.Equal(t, "Bolder", body[1].(map[string]any)["weight"])
+}
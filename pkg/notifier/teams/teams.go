@@ -0,0 +1,195 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package teams provides a notifier.Transport implementation for sending notifications to Microsoft Teams
+package teams
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/seatgeek/mailroom/pkg/content"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification/extension"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/seatgeek/mailroom/pkg/validation"
+)
+
+// ID is the identifier for a user's Microsoft Entra (AAD) object ID, as used by Teams
+var ID = identifier.NewNamespaceAndKind("teams.microsoft.com", identifier.KindID)
+
+// AdaptiveCardContentType is the attachment content type for Adaptive Cards
+const AdaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+
+// AdaptiveCard is the JSON representation of an Adaptive Card
+// See https://adaptivecards.io/explorer/ for the schema
+type AdaptiveCard map[string]any
+
+// CustomCard sets the Adaptive Card to send instead of the plain-text message (see notification.Builder.WithExtensions)
+var CustomCard = extension.NewKey[AdaptiveCard]("teams.card")
+
+// sender delivers an activity to a specific Teams user
+type sender interface {
+	send(ctx context.Context, user recipient, activity activity) error
+	validate(ctx context.Context) error
+}
+
+type recipient struct {
+	id   string
+	name string
+}
+
+// activity is a (minimal) Bot Framework activity, which incoming webhooks also accept
+type activity struct {
+	Type        string       `json:"type"`
+	Text        string       `json:"text,omitempty"`
+	Attachments []attachment `json:"attachments,omitempty"`
+}
+
+type attachment struct {
+	ContentType string       `json:"contentType"`
+	Content     AdaptiveCard `json:"content"`
+}
+
+// Transport supports sending messages to Microsoft Teams via an incoming webhook or the Bot Framework
+type Transport struct {
+	key    event.TransportKey
+	sender sender
+}
+
+var (
+	_ notifier.Transport   = &Transport{}
+	_ validation.Validator = &Transport{}
+)
+
+// Option configures a Transport
+type Option func(*options)
+
+type options struct {
+	client   *http.Client
+	tokenURL string
+}
+
+// WithHTTPClient sets the http.Client used to talk to Teams
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		client:   http.DefaultClient,
+		tokenURL: defaultTokenURL,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// NewWebhookTransport creates a Transport which posts to a channel using an incoming webhook (or Workflows) URL
+// Since webhooks can't send direct messages, each notification @mentions its recipient in the channel.
+func NewWebhookTransport(key event.TransportKey, webhookURL string, opts ...Option) *Transport {
+	o := newOptions(opts)
+
+	return &Transport{
+		key:    key,
+		sender: &webhookSender{url: webhookURL, client: apiClient{o.client}},
+	}
+}
+
+// NewBotTransport creates a Transport which sends direct messages to users as a Bot Framework bot
+// The bot must be installed for the recipient (or their team) for Teams to allow proactive messages.
+func NewBotTransport(key event.TransportKey, config BotConfig, opts ...Option) *Transport {
+	o := newOptions(opts)
+
+	return &Transport{
+		key:    key,
+		sender: newBotSender(config, o),
+	}
+}
+
+// Push sends a notification to a Teams user
+// In addition to supporting event.Notification, it also supports the CustomCard extension,
+// and content.Notification (which is rendered as an Adaptive Card).
+func (t *Transport) Push(ctx context.Context, notification event.Notification) error {
+	id, ok := notification.Recipient().Get(ID)
+	if !ok {
+		return notifier.Permanent(errors.New("recipient does not have a Teams ID"))
+	}
+
+	to := recipient{id: id, name: id}
+	if username, ok := notification.Recipient().Get(identifier.GenericUsername); ok {
+		to.name = username
+	}
+
+	card, _ := CustomCard.Of(notification)
+	if card == nil {
+		card = Card(content.Of(notification))
+	}
+
+	act := activity{Type: "message", Text: notification.Render(t.key)}
+	if card != nil {
+		act.Attachments = []attachment{{ContentType: AdaptiveCardContentType, Content: card}}
+	}
+
+	return t.sender.send(ctx, to, act)
+}
+
+func (t *Transport) Key() event.TransportKey {
+	return t.key
+}
+
+func (t *Transport) Validate(ctx context.Context) error {
+	return t.sender.validate(ctx)
+}
+
+// StatusError is returned when Teams responds with a non-2xx status code
+type StatusError = notifier.HTTPStatusError
+
+// apiClient is a thin wrapper around http.Client for the JSON APIs used by Teams
+type apiClient struct {
+	*http.Client
+}
+
+// postJSON sends a JSON request and decodes the (optional) JSON response
+func (c apiClient) postJSON(ctx context.Context, url string, header http.Header, body any, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return notifier.Permanent(err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return notifier.Permanent(err)
+	}
+
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := notifier.CheckHTTPResponse(resp); err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
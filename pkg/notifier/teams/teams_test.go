@@ -0,0 +1,299 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package teams_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier/teams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const userID = "29:1abc-aad-object-id"
+
+var someCard = teams.AdaptiveCard{
+	"type":    "AdaptiveCard",
+	"version": "1.4",
+	"body":    []any{map[string]any{"type": "TextBlock", "text": "Review requested"}},
+}
+
+func plainNotification() event.Notification {
+	return notification.NewBuilder(event.Context{Type: "com.example.test"}).
+		WithRecipientIdentifiers(
+			identifier.New(teams.ID, userID),
+			identifier.New(identifier.GenericUsername, "rufus"),
+		).
+		WithDefaultMessage("Hello, world!").
+		Build()
+}
+
+func richNotification() event.Notification {
+	return notification.NewBuilder(event.Context{Type: "com.example.test"}).
+		WithRecipientIdentifiers(identifier.New(teams.ID, userID)).
+		WithDefaultMessage("Hello, world!").
+		WithExtensions(teams.CustomCard.Value(someCard)).
+		Build()
+}
+
+// fakeTeams records every request body it receives, keyed by path
+type fakeTeams struct {
+	*httptest.Server
+
+	status int
+
+	mu       sync.Mutex
+	requests map[string][]map[string]any
+	auth     []string
+}
+
+func newFakeTeams(t *testing.T, status int) *fakeTeams {
+	t.Helper()
+
+	f := &fakeTeams{status: status, requests: make(map[string][]map[string]any)}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		if r.URL.Path == "/token" {
+			_ = r.ParseForm()
+			if r.PostForm.Get("client_secret") != "some-password" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token": "some-token", "expires_in": 3600}`))
+			return
+		}
+
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.requests[r.URL.Path] = append(f.requests[r.URL.Path], body)
+		f.auth = append(f.auth, r.Header.Get("Authorization"))
+
+		w.WriteHeader(f.status)
+		if r.URL.Path == "/v3/conversations" {
+			_, _ = w.Write([]byte(`{"id": "a:conversation-id"}`))
+		}
+	}))
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+func (f *fakeTeams) received(path string) []map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.requests[path]
+}
+
+func TestWebhookTransport_Push(t *testing.T) {
+	t.Parallel()
+
+	t.Run("plain text is wrapped in a card which mentions the recipient", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeTeams(t, http.StatusOK)
+		transport := teams.NewWebhookTransport("teams", server.URL+"/webhook")
+
+		require.NoError(t, transport.Push(t.Context(), plainNotification()))
+
+		received := server.received("/webhook")
+		require.Len(t, received, 1)
+
+		got, _ := json.Marshal(received[0])
+		assert.JSONEq(t, `{
+			"type": "message",
+			"attachments": [{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": {
+					"type": "AdaptiveCard",
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"version": "1.4",
+					"body": [
+						{"type": "TextBlock", "text": "<at>rufus</at>", "wrap": true},
+						{"type": "TextBlock", "text": "Hello, world!", "wrap": true}
+					],
+					"msteams": {
+						"entities": [{
+							"type": "mention",
+							"text": "<at>rufus</at>",
+							"mentioned": {"id": "29:1abc-aad-object-id", "name": "rufus"}
+						}]
+					}
+				}
+			}]
+		}`, string(got))
+	})
+
+	t.Run("rich notifications send their card", func(t *testing.T) {
+		t.Parallel()
+
+		server := newFakeTeams(t, http.StatusOK)
+		transport := teams.NewWebhookTransport("teams", server.URL+"/webhook")
+
+		require.NoError(t, transport.Push(t.Context(), richNotification()))
+
+		received := server.received("/webhook")
+		require.Len(t, received, 1)
+
+		content := received[0]["attachments"].([]any)[0].(map[string]any)["content"].(map[string]any)
+		body := content["body"].([]any)
+		require.Len(t, body, 2)
+		assert.Equal(t, "<at>29:1abc-aad-object-id</at>", body[0].(map[string]any)["text"])
+		assert.Equal(t, "Review requested", body[1].(map[string]any)["text"])
+
+		// The original card isn't modified
+		assert.Len(t, someCard["body"], 1)
+		assert.NotContains(t, someCard, "msteams")
+	})
+}
+
+func TestBotTransport_Push(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeTeams(t, http.StatusCreated)
+	transport := teams.NewBotTransport("teams", teams.BotConfig{
+		AppID:       "some-app",
+		AppPassword: "some-password",
+		TenantID:    "some-tenant",
+		ServiceURL:  server.URL,
+	}, teams.WithTokenURL(server.URL+"/token"))
+
+	require.NoError(t, transport.Push(t.Context(), plainNotification()))
+	require.NoError(t, transport.Push(t.Context(), richNotification()))
+
+	// The conversation is created once and reused
+	conversations := server.received("/v3/conversations")
+	require.Len(t, conversations, 1)
+	got, _ := json.Marshal(conversations[0])
+	assert.JSONEq(t, `{
+		"bot": {"id": "some-app"},
+		"members": [{"id": "29:1abc-aad-object-id"}],
+		"channelData": {"tenant": {"id": "some-tenant"}},
+		"isGroup": false
+	}`, string(got))
+
+	activities := server.received("/v3/conversations/a:conversation-id/activities")
+	require.Len(t, activities, 2)
+	assert.Equal(t, map[string]any{"type": "message", "text": "Hello, world!"}, activities[0])
+	assert.NotContains(t, activities[1], "text")
+	assert.Equal(t, "application/vnd.microsoft.card.adaptive", activities[1]["attachments"].([]any)[0].(map[string]any)["contentType"])
+
+	for _, auth := range server.auth {
+		assert.Equal(t, "Bearer some-token", auth)
+	}
+}
+
+func TestTransport_Push_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		status        int
+		notification  event.Notification
+		wantErr       string
+		wantPermanent bool
+	}{
+		{
+			name: "recipient without Teams ID",
+			notification: notification.NewBuilder(event.Context{Type: "com.example.test"}).
+				WithRecipientIdentifiers(identifier.New(identifier.GenericEmail, "rufus@example.com")).
+				Build(),
+			status:        http.StatusOK,
+			wantErr:       "recipient does not have a Teams ID",
+			wantPermanent: true,
+		},
+		{
+			name:          "client error",
+			notification:  plainNotification(),
+			status:        http.StatusBadRequest,
+			wantErr:       "received HTTP status 400",
+			wantPermanent: true,
+		},
+		{
+			name:         "throttled",
+			notification: plainNotification(),
+			status:       http.StatusTooManyRequests,
+			wantErr:      "received HTTP status 429",
+		},
+		{
+			name:         "server error",
+			notification: plainNotification(),
+			status:       http.StatusServiceUnavailable,
+			wantErr:      "received HTTP status 503",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := newFakeTeams(t, tc.status)
+			transport := teams.NewWebhookTransport("teams", server.URL+"/webhook")
+
+			err := transport.Push(t.Context(), tc.notification)
+			assert.ErrorContains(t, err, tc.wantErr)
+
+			var permanent *backoff.PermanentError
+			assert.Equal(t, tc.wantPermanent, errors.As(err, &permanent))
+
+			var statusErr *teams.StatusError
+			if tc.status != http.StatusOK && assert.ErrorAs(t, err, &statusErr) {
+				assert.Equal(t, tc.status, statusErr.StatusCode)
+			}
+		})
+	}
+}
+
+func TestTransport_Validate(t *testing.T) {
+	t.Parallel()
+
+	server := newFakeTeams(t, http.StatusOK)
+
+	tests := []struct {
+		name      string
+		transport *teams.Transport
+		wantErr   string
+	}{
+		{
+			name:      "webhooks can't be validated",
+			transport: teams.NewWebhookTransport("teams", server.URL+"/webhook"),
+		},
+		{
+			name: "valid bot credentials",
+			transport: teams.NewBotTransport("teams", teams.BotConfig{AppID: "some-app", AppPassword: "some-password"},
+				teams.WithTokenURL(server.URL+"/token")),
+		},
+		{
+			name: "invalid bot credentials",
+			transport: teams.NewBotTransport("teams", teams.BotConfig{AppID: "some-app", AppPassword: "wrong"},
+				teams.WithTokenURL(server.URL+"/token")),
+			wantErr: "authentication failed: token request failed with status 401",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.transport.Validate(t.Context())
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package teams
+
+import (
+	"context"
+	"fmt"
+	"maps"
+)
+
+// webhookSender posts Adaptive Cards to a channel's incoming webhook, mentioning the recipient
+type webhookSender struct {
+	url    string
+	client apiClient
+}
+
+func (w *webhookSender) send(ctx context.Context, to recipient, act activity) error {
+	var card AdaptiveCard
+	if len(act.Attachments) > 0 {
+		card = act.Attachments[0].Content
+	} else {
+		card = textCard(act.Text)
+	}
+
+	msg := activity{
+		Type:        "message",
+		Attachments: []attachment{{ContentType: AdaptiveCardContentType, Content: withMention(card, to)}},
+	}
+
+	return w.client.postJSON(ctx, w.url, nil, msg, nil)
+}
+
+// validate is a no-op: incoming webhooks can't be checked without posting a message
+func (w *webhookSender) validate(_ context.Context) error {
+	return nil
+}
+
+// textCard wraps a plain-text message in a minimal Adaptive Card
+func textCard(text string) AdaptiveCard {
+	return AdaptiveCard{
+		"type":    "AdaptiveCard",
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"version": "1.4",
+		"body": []any{
+			map[string]any{"type": "TextBlock", "text": text, "wrap": true},
+		},
+	}
+}
+
+// withMention returns a copy of the card with an @mention of the recipient prepended to its body
+func withMention(card AdaptiveCard, to recipient) AdaptiveCard {
+	mention := fmt.Sprintf("<at>%s</at>", to.name)
+
+	res := maps.Clone(card)
+	body, _ := card["body"].([]any)
+	res["body"] = append([]any{map[string]any{"type": "TextBlock", "text": mention, "wrap": true}}, body...)
+
+	msteams, _ := card["msteams"].(map[string]any)
+	msteams = maps.Clone(msteams)
+	if msteams == nil {
+		msteams = map[string]any{}
+	}
+	entities, _ := msteams["entities"].([]any)
+	msteams["entities"] = append(append([]any(nil), entities...), map[string]any{
+		"type":      "mention",
+		"text":      mention,
+		"mentioned": map[string]any{"id": to.id, "name": to.name},
+	})
+	res["msteams"] = msteams
+
+	return res
+}
@@ -0,0 +1,228 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package webhook provides a notifier.Transport implementation which delivers notifications as HTTP callbacks
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+)
+
+// SignatureHeader is the header containing the "sha256=" prefixed, hex-encoded HMAC-SHA256 signature of the body
+// Receivers can check it with verifier.NewHMAC(webhook.SignatureHeader, secret, verifier.WithPrefix("sha256="))
+const SignatureHeader = "X-Mailroom-Signature-256"
+
+// Payload is the default JSON body sent to the webhook
+// It's also the data available to URL and body templates.
+type Payload struct {
+	Context   notification.EnvelopeContext           `json:"context"`
+	Recipient map[identifier.NamespaceAndKind]string `json:"recipient"`
+	Transport event.TransportKey                     `json:"transport"`
+	Message   string                                 `json:"message"`
+}
+
+// Identifier returns the recipient's identifier for the given namespace and kind, failing if it's missing
+// It's intended for use in templates, like {{ .Identifier "slack.com/id" }}
+func (p Payload) Identifier(namespaceAndKind identifier.NamespaceAndKind) (string, error) {
+	if value, ok := p.Recipient[namespaceAndKind]; ok {
+		return value, nil
+	}
+
+	return "", fmt.Errorf("recipient does not have a %q identifier", namespaceAndKind)
+}
+
+// Label returns the value of the given event label, failing if it's missing
+// It's intended for use in templates, like {{ .Label "team" }}
+func (p Payload) Label(name string) (string, error) {
+	if value, ok := p.Context.Labels[name]; ok {
+		return value, nil
+	}
+
+	return "", fmt.Errorf("event does not have a %q label", name)
+}
+
+// Transport delivers notifications by sending an HTTP request to a (templated) URL
+type Transport struct {
+	key     event.TransportKey
+	url     *template.Template
+	body    *template.Template
+	method  string
+	headers http.Header
+	secret  []byte
+	client  *http.Client
+
+	bodyTemplate string
+}
+
+// Option configures a Transport
+type Option func(*Transport)
+
+// WithMethod sets the HTTP method used to deliver notifications (POST by default)
+func WithMethod(method string) Option {
+	return func(t *Transport) {
+		t.method = method
+	}
+}
+
+// WithHeader adds a static header to every request, such as an Authorization header
+func WithHeader(key, value string) Option {
+	return func(t *Transport) {
+		t.headers.Add(key, value)
+	}
+}
+
+// WithSigning signs each request body using HMAC-SHA256 and the given secret
+// The signature is sent in the SignatureHeader header.
+func WithSigning(secret []byte) Option {
+	return func(t *Transport) {
+		t.secret = secret
+	}
+}
+
+// WithBodyTemplate replaces the default JSON Payload with the output of a text/template
+// The template receives a Payload; use the `json` function to safely embed values, like {"text": {{ json .Message }}}
+func WithBodyTemplate(tmpl string) Option {
+	return func(t *Transport) {
+		t.bodyTemplate = tmpl
+	}
+}
+
+// WithHTTPClient sets the http.Client used to send requests
+func WithHTTPClient(client *http.Client) Option {
+	return func(t *Transport) {
+		t.client = client
+	}
+}
+
+// NewTransport creates a new webhook Transport
+// The URL is a text/template which receives a Payload, so it can be resolved from the recipient's identifiers
+// or the event's labels, like "https://example.com/users/{{ .Identifier \"email\" | pathEscape }}".
+// Referencing an identifier or label which doesn't exist causes delivery to fail permanently.
+func NewTransport(key event.TransportKey, urlTemplate string, opts ...Option) (*Transport, error) {
+	t := &Transport{
+		key:     key,
+		method:  http.MethodPost,
+		headers: make(http.Header),
+		client:  http.DefaultClient,
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	var err error
+	if t.url, err = parse("url", urlTemplate); err != nil {
+		return nil, err
+	}
+
+	if t.bodyTemplate != "" {
+		if t.body, err = parse("body", t.bodyTemplate); err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+var _ notifier.Transport = &Transport{}
+
+var funcs = template.FuncMap{
+	"pathEscape":  url.PathEscape,
+	"queryEscape": url.QueryEscape,
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+func parse(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s template: %w", name, err)
+	}
+
+	return tmpl, nil
+}
+
+// Push sends the notification to the webhook
+// 4xx responses (other than 408 and 429) are treated as permanent failures; anything else is retryable.
+func (t *Transport) Push(ctx context.Context, n event.Notification) error {
+	payload := Payload{
+		Context:   notification.NewEnvelopeContext(n.Context()),
+		Recipient: n.Recipient().ToMap(),
+		Transport: t.key,
+		Message:   n.Render(t.key),
+	}
+
+	target, err := execute(t.url, payload)
+	if err != nil {
+		return notifier.Permanent(fmt.Errorf("failed to resolve URL: %w", err))
+	}
+
+	body, err := t.render(payload)
+	if err != nil {
+		return notifier.Permanent(fmt.Errorf("failed to render body: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, t.method, strings.TrimSpace(target), bytes.NewReader(body))
+	if err != nil {
+		return notifier.Permanent(fmt.Errorf("failed to create request: %w", err))
+	}
+
+	req.Header = t.headers.Clone()
+	req.Header.Set("Content-Type", "application/json")
+	if t.secret != nil {
+		mac := hmac.New(sha256.New, t.secret)
+		mac.Write(body)
+		req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return notifier.CheckHTTPResponse(resp)
+}
+
+func (t *Transport) Key() event.TransportKey {
+	return t.key
+}
+
+func (t *Transport) render(payload Payload) ([]byte, error) {
+	if t.body == nil {
+		return json.Marshal(payload)
+	}
+
+	body, err := execute(t.body, payload)
+	return []byte(body), err
+}
+
+func execute(tmpl *template.Template, payload Payload) (string, error) {
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, payload); err != nil {
+		return "", err
+	}
+
+	return sb.String(), nil
+}
+
+// StatusError is returned when the webhook responds with a non-2xx status code
+type StatusError = notifier.HTTPStatusError
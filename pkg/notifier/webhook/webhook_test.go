@@ -0,0 +1,249 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package webhook_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier/webhook"
+	"github.com/seatgeek/mailroom/pkg/verifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var someNotification = notification.NewBuilder(event.Context{
+	ID:     "a1c11a53-c4be-488f-89b6-f83bf2d48dab",
+	Source: event.MustSource("https://gitlab.example.com"),
+	Type:   "com.gitlab.merge_request.opened",
+	Time:   time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	Labels: map[string]string{"team": "platform"},
+}).
+	WithRecipientIdentifiers(
+		identifier.New(identifier.GenericEmail, "rufus@example.com"),
+		identifier.New("slack.com/id", "U123"),
+	).
+	WithDefaultMessage("Hello, world!").
+	WithMessageForTransport("callback", "Hello, callback!").
+	Build()
+
+type request struct {
+	method string
+	path   string
+	header http.Header
+	body   []byte
+}
+
+func newServer(t *testing.T, status int) (*httptest.Server, <-chan request) {
+	t.Helper()
+
+	requests := make(chan request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		requests <- request{method: r.Method, path: r.URL.RequestURI(), header: r.Header, body: body}
+
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("some response"))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, requests
+}
+
+func TestNewTransport(t *testing.T) {
+	t.Parallel()
+
+	_, err := webhook.NewTransport("callback", "https://example.com/{{ .Identifier")
+	assert.ErrorContains(t, err, "invalid url template")
+
+	_, err = webhook.NewTransport("callback", "https://example.com", webhook.WithBodyTemplate("{{ json .Message"))
+	assert.ErrorContains(t, err, "invalid body template")
+
+	transport, err := webhook.NewTransport("callback", "https://example.com")
+	require.NoError(t, err)
+	assert.Equal(t, event.TransportKey("callback"), transport.Key())
+}
+
+func TestTransport_Push(t *testing.T) {
+	t.Parallel()
+
+	server, requests := newServer(t, http.StatusNoContent)
+
+	transport, err := webhook.NewTransport(
+		"callback",
+		server.URL+`/teams/{{ .Label "team" }}/users/{{ .Identifier "email" | pathEscape }}`,
+		webhook.WithHeader("Authorization", "Bearer some-token"),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, transport.Push(t.Context(), someNotification))
+
+	req := <-requests
+	assert.Equal(t, http.MethodPost, req.method)
+	assert.Equal(t, "/teams/platform/users/rufus@example.com", req.path)
+	assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+	assert.Equal(t, "Bearer some-token", req.header.Get("Authorization"))
+	assert.Empty(t, req.header.Get(webhook.SignatureHeader))
+	assert.JSONEq(t, `{
+		"context": {
+			"id": "a1c11a53-c4be-488f-89b6-f83bf2d48dab",
+			"source": "https://gitlab.example.com",
+			"type": "com.gitlab.merge_request.opened",
+			"time": "2025-01-02T03:04:05Z",
+			"labels": {"team": "platform"}
+		},
+		"recipient": {"email": "rufus@example.com", "slack.com/id": "U123"},
+		"transport": "callback",
+		"message": "Hello, callback!"
+	}`, string(req.body))
+}
+
+func TestTransport_Push_BodyTemplate(t *testing.T) {
+	t.Parallel()
+
+	server, requests := newServer(t, http.StatusOK)
+
+	transport, err := webhook.NewTransport(
+		"chat",
+		server.URL,
+		webhook.WithMethod(http.MethodPut),
+		webhook.WithBodyTemplate(`{"user": {{ .Identifier "slack.com/id" | json }}, "text": {{ json .Message }}}`),
+	)
+	require.NoError(t, err)
+
+	require.NoError(t, transport.Push(t.Context(), someNotification))
+
+	req := <-requests
+	assert.Equal(t, http.MethodPut, req.method)
+	assert.JSONEq(t, `{"user": "U123", "text": "Hello, world!"}`, string(req.body))
+}
+
+func TestTransport_Push_Signing(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("some-secret")
+	server, requests := newServer(t, http.StatusOK)
+
+	transport, err := webhook.NewTransport("callback", server.URL, webhook.WithSigning(secret))
+	require.NoError(t, err)
+
+	require.NoError(t, transport.Push(t.Context(), someNotification))
+
+	// Receivers should be able to verify the signature using the verifier package
+	req := <-requests
+	httpReq := httptest.NewRequest(http.MethodPost, "/", nil)
+	httpReq.Header = req.header
+
+	v := verifier.NewHMAC(webhook.SignatureHeader, secret, verifier.WithPrefix("sha256="))
+	assert.NoError(t, v.Verify(httpReq, req.body))
+	assert.ErrorIs(t, verifier.NewHMAC(webhook.SignatureHeader, []byte("wrong"), verifier.WithPrefix("sha256=")).Verify(httpReq, req.body), verifier.ErrInvalidSignature)
+}
+
+func TestTransport_Push_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		status        int
+		url           string
+		wantErr       string
+		wantPermanent bool
+		wantRequest   bool
+	}{
+		{
+			name:          "bad request",
+			status:        http.StatusBadRequest,
+			wantErr:       "received HTTP status 400: some response",
+			wantPermanent: true,
+			wantRequest:   true,
+		},
+		{
+			name:          "not found",
+			status:        http.StatusNotFound,
+			wantErr:       "received HTTP status 404",
+			wantPermanent: true,
+			wantRequest:   true,
+		},
+		{
+			name:        "rate limited",
+			status:      http.StatusTooManyRequests,
+			wantErr:     "received HTTP status 429",
+			wantRequest: true,
+		},
+		{
+			name:        "server error",
+			status:      http.StatusBadGateway,
+			wantErr:     "received HTTP status 502",
+			wantRequest: true,
+		},
+		{
+			name:          "missing identifier",
+			url:           `/{{ .Identifier "github.com/username" }}`,
+			wantErr:       `recipient does not have a "github.com/username" identifier`,
+			wantPermanent: true,
+		},
+		{
+			name:          "missing label",
+			url:           `/{{ .Label "repository" }}`,
+			wantErr:       `event does not have a "repository" label`,
+			wantPermanent: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server, requests := newServer(t, tc.status)
+
+			transport, err := webhook.NewTransport("callback", server.URL+tc.url)
+			require.NoError(t, err)
+
+			err = transport.Push(t.Context(), someNotification)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tc.wantErr)
+
+			var permanent *backoff.PermanentError
+			assert.Equal(t, tc.wantPermanent, errors.As(err, &permanent))
+
+			if !tc.wantRequest {
+				assert.Empty(t, requests)
+				return
+			}
+
+			assert.Len(t, requests, 1)
+
+			var statusErr *webhook.StatusError
+			require.ErrorAs(t, err, &statusErr)
+			assert.Equal(t, tc.status, statusErr.StatusCode)
+		})
+	}
+}
+
+func TestPayload_JSON(t *testing.T) {
+	t.Parallel()
+
+	// The payload round-trips so receivers can decode it using the same type
+	server, requests := newServer(t, http.StatusOK)
+	transport, err := webhook.NewTransport("callback", server.URL)
+	require.NoError(t, err)
+	require.NoError(t, transport.Push(t.Context(), someNotification))
+
+	var payload webhook.Payload
+	require.NoError(t, json.Unmarshal((<-requests).body, &payload))
+
+	assert.Equal(t, event.ID("a1c11a53-c4be-488f-89b6-f83bf2d48dab"), payload.Context.ID)
+	assert.Equal(t, "U123", payload.Recipient["slack.com/id"])
+	assert.Equal(t, "Hello, callback!", payload.Message)
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package alertmanager
+
+import (
+	"context"
+	"strings"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+)
+
+// Generator is an event.Processor which notifies the owners of each alert, as named by one of its labels
+type Generator struct {
+	label string
+	kind  identifier.NamespaceAndKind
+}
+
+var _ event.Processor = &Generator{}
+
+// NewGenerator creates a new Generator which reads recipients from the given alert label, e.g. "owner_email",
+// treating each as an identifier of the given kind, e.g. identifier.GenericEmail.
+// The label may list several recipients separated by commas. Alerts without the label are skipped.
+func NewGenerator(label string, kind identifier.NamespaceAndKind) *Generator {
+	return &Generator{
+		label: label,
+		kind:  kind,
+	}
+}
+
+func (g *Generator) Process(_ context.Context, evt event.Event, notifications []event.Notification) ([]event.Notification, error) {
+	payload, ok := evt.Data.(Payload)
+	if !ok {
+		return notifications, nil
+	}
+
+	message := Message(evt.Type, payload)
+	for owner := range strings.SplitSeq(payload.Labels[g.label], ",") {
+		owner = strings.TrimSpace(owner)
+		if owner == "" {
+			continue
+		}
+
+		notifications = append(notifications, notification.NewBuilder(evt.Context).
+			WithRecipientIdentifiers(identifier.New(g.kind, owner)).
+			WithDefaultMessage(message).
+			Build())
+	}
+
+	return notifications, nil
+}
+
+// Message renders a short plain-text description of the alert, using its summary and description annotations
+func Message(typ event.Type, payload Payload) string {
+	status := "FIRING"
+	if typ == AlertResolved {
+		status = "RESOLVED"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("[" + status + "] " + payload.Labels["alertname"])
+	if summary := payload.Annotations["summary"]; summary != "" {
+		sb.WriteString(": " + summary)
+	}
+
+	if description := payload.Annotations["description"]; description != "" && typ == AlertFiring {
+		sb.WriteString("\n" + description)
+	}
+
+	if payload.GeneratorURL != "" {
+		sb.WriteString("\n" + payload.GeneratorURL)
+	}
+
+	return sb.String()
+}
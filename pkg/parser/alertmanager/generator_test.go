@@ -0,0 +1,67 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package alertmanager_test
+
+import (
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/parser/alertmanager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_Process(t *testing.T) {
+	t.Parallel()
+
+	events, err := alertmanager.NewParser().ParseBatch(requestFor(t, "webhook.json"))
+	require.NoError(t, err)
+
+	generator := alertmanager.NewGenerator("owner_email", identifier.GenericEmail)
+
+	t.Run("notifies each owner", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := generator.Process(t.Context(), *events[0], nil)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+
+		assert.Equal(t, "rufus@example.com", got[0].Recipient().MustGet(identifier.GenericEmail))
+		assert.Equal(t, "codell@example.com", got[1].Recipient().MustGet(identifier.GenericEmail))
+		assert.Equal(t, "[FIRING] HighLatency: API latency is too high\np99 latency for checkout is 2.5s\nhttps://prometheus.example.com/graph?g0.expr=latency", got[0].Render("email"))
+		assert.Equal(t, events[0].Context, got[0].Context())
+	})
+
+	t.Run("skips alerts without owners", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := generator.Process(t.Context(), *events[1], nil)
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("passes through other events", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := generator.Process(t.Context(), event.Event{Data: "something else"}, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
+func TestMessage(t *testing.T) {
+	t.Parallel()
+
+	payload := alertmanager.Payload{
+		Alert: alertmanager.Alert{
+			Labels:      map[string]string{"alertname": "HighLatency"},
+			Annotations: map[string]string{"summary": "API latency is too high", "description": "it's slow"},
+		},
+	}
+
+	assert.Equal(t, "[FIRING] HighLatency: API latency is too high\nit's slow", alertmanager.Message(alertmanager.AlertFiring, payload))
+	assert.Equal(t, "[RESOLVED] HighLatency: API latency is too high", alertmanager.Message(alertmanager.AlertResolved, payload))
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package alertmanager provides an event.Parser for Prometheus Alertmanager webhooks,
+// producing one event per alert, along with a processor that notifies each alert's owners
+package alertmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/server"
+)
+
+const (
+	AlertFiring   event.Type = "io.prometheus.alertmanager.alert.firing"
+	AlertResolved event.Type = "io.prometheus.alertmanager.alert.resolved"
+)
+
+var eventTypes = []event.TypeDescriptor{
+	{Key: AlertFiring, Title: "Alert Firing", Description: "An alert you own has started firing"},
+	{Key: AlertResolved, Title: "Alert Resolved", Description: "An alert you own has been resolved"},
+}
+
+// Webhook is the payload Alertmanager sends to webhook receivers
+// See https://prometheus.io/docs/alerting/latest/configuration/#webhook_config
+type Webhook struct {
+	Version           string            `json:"version"`
+	GroupKey          string            `json:"groupKey"`
+	TruncatedAlerts   int               `json:"truncatedAlerts"`
+	Status            string            `json:"status"`
+	Receiver          string            `json:"receiver"`
+	GroupLabels       map[string]string `json:"groupLabels"`
+	CommonLabels      map[string]string `json:"commonLabels"`
+	CommonAnnotations map[string]string `json:"commonAnnotations"`
+	ExternalURL       string            `json:"externalURL"`
+	Alerts            []Alert           `json:"alerts"`
+}
+
+// Alert is a single alert within a Webhook
+type Alert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// CorrelationKey identifies a single occurrence of an alert, from when it starts firing until it is resolved.
+// The firing and resolved events for that occurrence share the same key, which is also used as the event Subject.
+func (a Alert) CorrelationKey() string {
+	return fmt.Sprintf("%s@%d", a.Fingerprint, a.StartsAt.Unix())
+}
+
+// Payload is the event.Event Data produced by the Parser
+type Payload struct {
+	Alert
+	// Receiver is the name of the Alertmanager receiver which sent the alert
+	Receiver string
+	// ExternalURL links back to the Alertmanager which sent the alert
+	ExternalURL string
+	// FiringID is the ID of the event for when this alert started firing; for firing alerts it is the event's own ID
+	FiringID event.ID
+}
+
+// Parser parses Alertmanager webhooks, fanning out each alert in the group to its own event.
+// Alert labels are copied into the event's Labels.
+type Parser struct{}
+
+var _ event.BatchParser = &Parser{}
+
+// NewParser creates a new Parser
+func NewParser() *Parser {
+	return &Parser{}
+}
+
+func (p *Parser) EventTypes() []event.TypeDescriptor {
+	return eventTypes
+}
+
+// Parse returns the first alert in the group; ParseBatch should be preferred so that no alerts are dropped
+func (p *Parser) Parse(req *http.Request) (*event.Event, error) {
+	events, err := p.ParseBatch(req)
+	if err != nil || len(events) == 0 {
+		return nil, err
+	}
+
+	return events[0], nil
+}
+
+func (p *Parser) ParseBatch(req *http.Request) ([]*event.Event, error) {
+	var webhook Webhook
+	if err := json.NewDecoder(req.Body).Decode(&webhook); err != nil {
+		return nil, &server.Error{Code: http.StatusBadRequest, Reason: fmt.Errorf("failed to decode payload: %w", err)}
+	}
+
+	source := event.NewSource(webhook.ExternalURL)
+	if source == nil {
+		source = event.NewSource("alertmanager")
+	}
+
+	events := make([]*event.Event, 0, len(webhook.Alerts))
+	for _, alert := range webhook.Alerts {
+		typ := AlertFiring
+		at := alert.StartsAt
+		if alert.Status == "resolved" {
+			typ = AlertResolved
+			at = alert.EndsAt
+		}
+
+		events = append(events, &event.Event{
+			Context: event.Context{
+				ID:      eventID(alert, typ),
+				Source:  *source,
+				Type:    typ,
+				Subject: alert.CorrelationKey(),
+				Time:    at,
+				Labels:  maps.Clone(alert.Labels),
+			},
+			Data: Payload{
+				Alert:       alert,
+				Receiver:    webhook.Receiver,
+				ExternalURL: webhook.ExternalURL,
+				FiringID:    eventID(alert, AlertFiring),
+			},
+		})
+	}
+
+	return events, nil
+}
+
+// eventID is stable across Alertmanager's repeated notifications for the same occurrence of an alert,
+// so repeats are recognised as duplicates
+func eventID(alert Alert, typ event.Type) event.ID {
+	status := "firing"
+	if typ == AlertResolved {
+		status = "resolved"
+	}
+
+	return event.ID(alert.CorrelationKey() + "/" + status)
+}
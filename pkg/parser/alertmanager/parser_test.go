@@ -0,0 +1,112 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package alertmanager_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/parser/alertmanager"
+	"github.com/seatgeek/mailroom/pkg/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParser_ParseBatch(t *testing.T) {
+	t.Parallel()
+
+	events, err := alertmanager.NewParser().ParseBatch(requestFor(t, "webhook.json"))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	firing := events[0]
+	assert.Equal(t, event.ID("c4b2d1b3a5f6e7d8@1735787045/firing"), firing.ID)
+	assert.Equal(t, alertmanager.AlertFiring, firing.Type)
+	assert.Equal(t, "https://alertmanager.example.com", firing.Source.String())
+	assert.Equal(t, "c4b2d1b3a5f6e7d8@1735787045", firing.Subject)
+	assert.Equal(t, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), firing.Time)
+	assert.Equal(t, map[string]string{
+		"alertname":   "HighLatency",
+		"severity":    "page",
+		"service":     "checkout",
+		"owner_email": "rufus@example.com, codell@example.com",
+	}, firing.Labels)
+
+	payload := firing.Data.(alertmanager.Payload)
+	assert.Equal(t, "mailroom", payload.Receiver)
+	assert.Equal(t, "p99 latency for checkout is 2.5s", payload.Annotations["description"])
+	assert.Equal(t, firing.ID, payload.FiringID)
+
+	resolved := events[1]
+	assert.Equal(t, alertmanager.AlertResolved, resolved.Type)
+	assert.Equal(t, time.Date(2025, 1, 2, 3, 0, 0, 0, time.UTC), resolved.Time)
+	assert.Equal(t, "search", resolved.Labels["service"])
+}
+
+func TestParser_ParseBatch_correlatesResolvedAlerts(t *testing.T) {
+	t.Parallel()
+
+	parser := alertmanager.NewParser()
+
+	fire := `{"alerts": [{"status": "firing", "labels": {"alertname": "Down"}, "startsAt": "2025-01-02T03:04:05Z", "fingerprint": "abc"}]}`
+	resolve := `{"alerts": [{"status": "resolved", "labels": {"alertname": "Down"}, "startsAt": "2025-01-02T03:04:05Z", "endsAt": "2025-01-02T04:00:00Z", "fingerprint": "abc"}]}`
+	refire := `{"alerts": [{"status": "firing", "labels": {"alertname": "Down"}, "startsAt": "2025-01-02T05:00:00Z", "fingerprint": "abc"}]}`
+
+	parse := func(body string) *event.Event {
+		req := httptest.NewRequestWithContext(t.Context(), "POST", "/alertmanager", strings.NewReader(body))
+		events, err := parser.ParseBatch(req)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		return events[0]
+	}
+
+	firing, resolved, refiring := parse(fire), parse(resolve), parse(refire)
+
+	assert.NotEqual(t, firing.ID, resolved.ID)
+	assert.Equal(t, firing.Subject, resolved.Subject)
+	assert.Equal(t, firing.ID, resolved.Data.(alertmanager.Payload).FiringID)
+
+	// A new occurrence of the same alert is not correlated with the previous one
+	assert.NotEqual(t, firing.Subject, refiring.Subject)
+	assert.NotEqual(t, firing.ID, refiring.ID)
+}
+
+func TestParser_Parse(t *testing.T) {
+	t.Parallel()
+
+	evt, err := alertmanager.NewParser().Parse(requestFor(t, "webhook.json"))
+	require.NoError(t, err)
+	assert.Equal(t, alertmanager.AlertFiring, evt.Type)
+
+	evt, err = alertmanager.NewParser().Parse(httptest.NewRequestWithContext(t.Context(), "POST", "/alertmanager", strings.NewReader(`{"alerts": []}`)))
+	assert.NoError(t, err)
+	assert.Nil(t, evt)
+}
+
+func TestParser_ParseBatch_invalidPayload(t *testing.T) {
+	t.Parallel()
+
+	_, err := alertmanager.NewParser().ParseBatch(httptest.NewRequestWithContext(t.Context(), "POST", "/alertmanager", strings.NewReader("{")))
+
+	var httpErr *server.Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+func requestFor(t *testing.T, fixture string) *http.Request {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", fixture))
+	require.NoError(t, err)
+
+	return httptest.NewRequestWithContext(t.Context(), "POST", "/alertmanager", bytes.NewReader(body))
+}
@@ -0,0 +1,68 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package cloudevents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+)
+
+// Message is the event data understood by the Generator, e.g.:
+//
+//	{
+//	  "recipients": [{"email": "rufus@example.com"}, {"slack.com/id": "U1234"}],
+//	  "message": "Your build has failed",
+//	  "messages": {"slack": ":x: Your build has failed"}
+//	}
+type Message struct {
+	// Recipients lists the identifiers of each user to notify
+	Recipients []map[identifier.NamespaceAndKind]string `json:"recipients"`
+	// Message is the default message sent via every transport
+	Message string `json:"message"`
+	// Messages optionally overrides the message for specific transports
+	Messages map[event.TransportKey]string `json:"messages,omitempty"`
+}
+
+// Generator is an event.Processor which creates notifications from CloudEvents whose data is a Message.
+// This lets other services send notifications through mailroom without any custom code.
+// Events from other parsers are passed through untouched.
+type Generator struct{}
+
+var _ event.Processor = &Generator{}
+
+// NewGenerator creates a new Generator
+func NewGenerator() *Generator {
+	return &Generator{}
+}
+
+func (g *Generator) Process(_ context.Context, evt event.Event, notifications []event.Notification) ([]event.Notification, error) {
+	payload, ok := evt.Data.(Payload)
+	if !ok {
+		return notifications, nil
+	}
+
+	var msg Message
+	if err := payload.Unmarshal(&msg); err != nil {
+		return nil, badRequest(fmt.Errorf("invalid message data: %w", err))
+	}
+
+	for _, recipient := range msg.Recipients {
+		builder := notification.NewBuilder(evt.Context).
+			WithRecipient(identifier.NewSetFromMap(recipient)).
+			WithDefaultMessage(msg.Message)
+
+		for transport, message := range msg.Messages {
+			builder = builder.WithMessageForTransport(transport, message)
+		}
+
+		notifications = append(notifications, builder.Build())
+	}
+
+	return notifications, nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package cloudevents_test
+
+import (
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/parser/cloudevents"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_Process(t *testing.T) {
+	t.Parallel()
+
+	someContext := event.Context{ID: "1", Source: event.MustSource("/ci"), Type: "com.example.build.failed"}
+
+	t.Run("creates a notification per recipient", func(t *testing.T) {
+		t.Parallel()
+
+		evt := event.Event{
+			Context: someContext,
+			Data: cloudevents.Payload{
+				SpecVersion: "1.0",
+				Data: []byte(`{
+					"recipients": [{"email": "rufus@example.com"}, {"slack.com/id": "U1234", "username": "codell"}],
+					"message": "Your build has failed",
+					"messages": {"slack": ":x: Your build has failed"}
+				}`),
+			},
+		}
+
+		got, err := cloudevents.NewGenerator().Process(t.Context(), evt, nil)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+
+		assert.Equal(t, someContext, got[0].Context())
+		assert.Equal(t, identifier.NewSet(identifier.New(identifier.GenericEmail, "rufus@example.com")), got[0].Recipient())
+		assert.Equal(t, "Your build has failed", got[0].Render("email"))
+		assert.Equal(t, ":x: Your build has failed", got[0].Render("slack"))
+
+		assert.Equal(t, identifier.NewSet(
+			identifier.New("slack.com/id", "U1234"),
+			identifier.New(identifier.GenericUsername, "codell"),
+		), got[1].Recipient())
+	})
+
+	t.Run("passes through other events", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := cloudevents.NewGenerator().Process(t.Context(), event.Event{Context: someContext, Data: "something else"}, nil)
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := cloudevents.NewGenerator().Process(t.Context(), event.Event{Context: someContext, Data: cloudevents.Payload{Data: []byte(`"nope"`)}}, nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,325 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package cloudevents provides an event.Parser for CloudEvents 1.0 delivered over HTTP,
+// in binary, structured or batch content mode.
+// See https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/bindings/http-protocol-binding.md
+package cloudevents
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/server"
+	"github.com/seatgeek/mailroom/pkg/validation"
+)
+
+const (
+	// SpecVersion is the only version of the CloudEvents spec supported by the Parser
+	SpecVersion = "1.0"
+	// ContentTypeStructured is the media type of a single event in structured mode
+	ContentTypeStructured = "application/cloudevents+json"
+	// ContentTypeBatch is the media type of several events in batch mode
+	ContentTypeBatch = "application/cloudevents-batch+json"
+)
+
+// Payload is the event.Event Data produced by the Parser
+type Payload struct {
+	SpecVersion     string
+	DataContentType string
+	DataSchema      string
+	// Data is the raw event data; for JSON content it is the encoded JSON value
+	Data []byte
+}
+
+// Unmarshal decodes JSON event data into v
+func (p Payload) Unmarshal(v any) error {
+	return json.Unmarshal(p.Data, v)
+}
+
+// Parser parses CloudEvents from incoming HTTP requests.
+// Events whose type was not declared are ignored.
+type Parser struct {
+	types []event.TypeDescriptor
+	known map[event.Type]bool
+}
+
+var (
+	_ event.BatchParser    = &Parser{}
+	_ validation.Validator = &Parser{}
+)
+
+// NewParser creates a new Parser which accepts the given event types
+func NewParser(types ...event.TypeDescriptor) *Parser {
+	known := make(map[event.Type]bool, len(types))
+	for _, t := range types {
+		known[t.Key] = true
+	}
+
+	return &Parser{
+		types: types,
+		known: known,
+	}
+}
+
+// LoadEventTypes reads a JSON array of event.TypeDescriptor values, which is a convenient way to declare
+// the types a Parser accepts in a config file, e.g.:
+//
+//	[{"key": "com.example.build.failed", "title": "Build Failed", "description": "Your build has failed"}]
+func LoadEventTypes(r io.Reader) ([]event.TypeDescriptor, error) {
+	var types []event.TypeDescriptor
+	if err := json.NewDecoder(r).Decode(&types); err != nil {
+		return nil, fmt.Errorf("failed to decode event types: %w", err)
+	}
+
+	return types, nil
+}
+
+func (p *Parser) EventTypes() []event.TypeDescriptor {
+	return p.types
+}
+
+// Validate checks that the declared event types are usable
+func (p *Parser) Validate(_ context.Context) error {
+	if len(p.types) == 0 {
+		return errors.New("no event types declared")
+	}
+
+	if len(p.known) != len(p.types) {
+		return errors.New("event types must be unique")
+	}
+
+	for _, t := range p.types {
+		if t.Key == "" || t.Title == "" {
+			return fmt.Errorf("event type %q must have a key and a title", t.Key)
+		}
+	}
+
+	return nil
+}
+
+// Parse parses a single event in binary or structured mode; use ParseBatch for batch mode
+func (p *Parser) Parse(req *http.Request) (*event.Event, error) {
+	if mediaType(req) == ContentTypeBatch {
+		return nil, badRequest(errors.New("batch mode is not supported by Parse"))
+	}
+
+	events, err := p.ParseBatch(req)
+	if err != nil || len(events) == 0 {
+		return nil, err
+	}
+
+	return events[0], nil
+}
+
+// ParseBatch parses one or more events in binary, structured or batch mode
+func (p *Parser) ParseBatch(req *http.Request) ([]*event.Event, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, badRequest(fmt.Errorf("failed to read request body: %w", err))
+	}
+
+	var raw []structured
+	switch mediaType(req) {
+	case ContentTypeBatch:
+		if err := json.Unmarshal(body, &raw); err != nil {
+			return nil, badRequest(fmt.Errorf("invalid batch: %w", err))
+		}
+	case ContentTypeStructured:
+		var s structured
+		if err := json.Unmarshal(body, &s); err != nil {
+			return nil, badRequest(fmt.Errorf("invalid structured event: %w", err))
+		}
+		raw = []structured{s}
+	default:
+		raw = []structured{fromBinary(req, body)}
+	}
+
+	events := make([]*event.Event, 0, len(raw))
+	for _, s := range raw {
+		evt, err := s.toEvent()
+		if err != nil {
+			return nil, badRequest(err)
+		}
+
+		if !p.known[evt.Type] {
+			slog.DebugContext(req.Context(), "ignoring cloudevent with undeclared type", "id", evt.ID, "type", evt.Type)
+			continue
+		}
+
+		events = append(events, evt)
+	}
+
+	return events, nil
+}
+
+// structured holds the attributes of a single event, as found in structured or batch mode
+type structured map[string]json.RawMessage
+
+// fromBinary collects the attributes of an event in binary mode from its ce-* headers
+func fromBinary(req *http.Request, body []byte) structured {
+	s := structured{}
+	for name, values := range req.Header {
+		attr, ok := strings.CutPrefix(strings.ToLower(name), "ce-")
+		if !ok || len(values) == 0 {
+			continue
+		}
+
+		value, err := url.PathUnescape(values[0])
+		if err != nil {
+			value = values[0]
+		}
+
+		s[attr], _ = json.Marshal(value)
+	}
+
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		s["datacontenttype"], _ = json.Marshal(contentType)
+	}
+
+	if len(body) > 0 {
+		s["data_base64"], _ = json.Marshal(base64.StdEncoding.EncodeToString(body))
+	}
+
+	return s
+}
+
+func (s structured) toEvent() (*event.Event, error) {
+	payload := Payload{
+		SpecVersion:     s.string("specversion"),
+		DataContentType: s.string("datacontenttype"),
+		DataSchema:      s.string("dataschema"),
+	}
+
+	if payload.SpecVersion != SpecVersion {
+		return nil, fmt.Errorf("unsupported specversion %q", payload.SpecVersion)
+	}
+
+	id, source, typ := s.string("id"), s.string("source"), s.string("type")
+	if id == "" || source == "" || typ == "" {
+		return nil, errors.New("id, source and type are required")
+	}
+
+	src := event.NewSource(source)
+	if src == nil {
+		return nil, fmt.Errorf("invalid source %q", source)
+	}
+
+	evt := &event.Event{
+		Context: event.Context{
+			ID:      event.ID(id),
+			Source:  *src,
+			Type:    event.Type(typ),
+			Subject: s.string("subject"),
+		},
+	}
+
+	if t := s.string("time"); t != "" {
+		parsed, err := time.Parse(time.RFC3339, t)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time %q: %w", t, err)
+		}
+		evt.Time = parsed
+	}
+
+	data, err := s.data()
+	if err != nil {
+		return nil, err
+	}
+	payload.Data = data
+	evt.Data = payload
+
+	// Any extension attributes become labels
+	for key := range s {
+		if isContextAttribute(key) {
+			continue
+		}
+		if evt.Labels == nil {
+			evt.Labels = make(map[string]string)
+		}
+		evt.Labels[key] = s.string(key)
+	}
+
+	return evt, nil
+}
+
+func (s structured) data() ([]byte, error) {
+	if encoded := s.string("data_base64"); encoded != "" {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid data_base64: %w", err)
+		}
+		return decoded, nil
+	}
+
+	raw, ok := s["data"]
+	if !ok || string(raw) == "null" {
+		return nil, nil
+	}
+
+	// Non-JSON data is carried as a JSON string in structured mode
+	var str string
+	if !isJSON(s.string("datacontenttype")) && json.Unmarshal(raw, &str) == nil {
+		return []byte(str), nil
+	}
+
+	return raw, nil
+}
+
+// string returns the attribute as a string, whatever its JSON type
+func (s structured) string(key string) string {
+	raw, ok := s[key]
+	if !ok {
+		return ""
+	}
+
+	var str string
+	if err := json.Unmarshal(raw, &str); err == nil {
+		return str
+	}
+
+	return string(raw)
+}
+
+func isContextAttribute(key string) bool {
+	switch key {
+	case "id", "source", "specversion", "type", "datacontenttype", "dataschema", "subject", "time", "data", "data_base64":
+		return true
+	}
+
+	return false
+}
+
+func isJSON(contentType string) bool {
+	if contentType == "" {
+		return true // JSON is implied when no content type is given
+	}
+
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mt == "application/json" || mt == "text/json" || strings.HasSuffix(mt, "+json")
+}
+
+func mediaType(req *http.Request) string {
+	mt, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	return mt
+}
+
+func badRequest(err error) error {
+	return &server.Error{Code: http.StatusBadRequest, Reason: err}
+}
@@ -0,0 +1,288 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package cloudevents_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/parser/cloudevents"
+	"github.com/seatgeek/mailroom/pkg/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var buildFailed = event.TypeDescriptor{
+	Key:         "com.example.build.failed",
+	Title:       "Build Failed",
+	Description: "Your build has failed",
+}
+
+func TestParser_ParseBatch(t *testing.T) {
+	t.Parallel()
+
+	parser := cloudevents.NewParser(buildFailed)
+
+	tests := []struct {
+		name        string
+		contentType string
+		headers     map[string]string
+		body        string
+		want        []*event.Event
+		wantErr     bool
+	}{
+		{
+			name:        "binary mode",
+			contentType: "application/json",
+			headers: map[string]string{
+				"ce-specversion": "1.0",
+				"ce-id":          "a1c11a53-c4be-488f-89b6-f83bf2d48dab",
+				"ce-source":      "https://ci.example.com/builds",
+				"ce-type":        "com.example.build.failed",
+				"ce-subject":     "build/123",
+				"ce-time":        "2025-01-02T03:04:05Z",
+				"ce-team":        "platform%20eng",
+			},
+			body: `{"message": "hello"}`,
+			want: []*event.Event{
+				{
+					Context: event.Context{
+						ID:      "a1c11a53-c4be-488f-89b6-f83bf2d48dab",
+						Source:  event.MustSource("https://ci.example.com/builds"),
+						Type:    "com.example.build.failed",
+						Subject: "build/123",
+						Time:    time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+						Labels:  map[string]string{"team": "platform eng"},
+					},
+					Data: cloudevents.Payload{
+						SpecVersion:     "1.0",
+						DataContentType: "application/json",
+						Data:            []byte(`{"message": "hello"}`),
+					},
+				},
+			},
+		},
+		{
+			name:        "structured mode",
+			contentType: "application/cloudevents+json; charset=utf-8",
+			body: `{
+				"specversion": "1.0",
+				"id": "a1c11a53-c4be-488f-89b6-f83bf2d48dab",
+				"source": "https://ci.example.com/builds",
+				"type": "com.example.build.failed",
+				"dataschema": "https://example.com/schema.json",
+				"attempt": 3,
+				"data": {"message": "hello"}
+			}`,
+			want: []*event.Event{
+				{
+					Context: event.Context{
+						ID:     "a1c11a53-c4be-488f-89b6-f83bf2d48dab",
+						Source: event.MustSource("https://ci.example.com/builds"),
+						Type:   "com.example.build.failed",
+						Labels: map[string]string{"attempt": "3"},
+					},
+					Data: cloudevents.Payload{
+						SpecVersion: "1.0",
+						DataSchema:  "https://example.com/schema.json",
+						Data:        []byte(`{"message": "hello"}`),
+					},
+				},
+			},
+		},
+		{
+			name:        "structured mode with non-JSON data",
+			contentType: "application/cloudevents+json",
+			body:        `{"specversion": "1.0", "id": "1", "source": "/ci", "type": "com.example.build.failed", "datacontenttype": "text/plain", "data": "hello"}`,
+			want: []*event.Event{
+				{
+					Context: event.Context{ID: "1", Source: event.MustSource("/ci"), Type: "com.example.build.failed"},
+					Data:    cloudevents.Payload{SpecVersion: "1.0", DataContentType: "text/plain", Data: []byte("hello")},
+				},
+			},
+		},
+		{
+			name:        "structured mode with base64 data",
+			contentType: "application/cloudevents+json",
+			body:        `{"specversion": "1.0", "id": "1", "source": "/ci", "type": "com.example.build.failed", "datacontenttype": "application/octet-stream", "data_base64": "aGVsbG8="}`,
+			want: []*event.Event{
+				{
+					Context: event.Context{ID: "1", Source: event.MustSource("/ci"), Type: "com.example.build.failed"},
+					Data:    cloudevents.Payload{SpecVersion: "1.0", DataContentType: "application/octet-stream", Data: []byte("hello")},
+				},
+			},
+		},
+		{
+			name:        "batch mode skips undeclared types",
+			contentType: "application/cloudevents-batch+json",
+			body: `[
+				{"specversion": "1.0", "id": "1", "source": "/ci", "type": "com.example.build.failed"},
+				{"specversion": "1.0", "id": "2", "source": "/ci", "type": "com.example.build.succeeded"},
+				{"specversion": "1.0", "id": "3", "source": "/ci", "type": "com.example.build.failed"}
+			]`,
+			want: []*event.Event{
+				{
+					Context: event.Context{ID: "1", Source: event.MustSource("/ci"), Type: "com.example.build.failed"},
+					Data:    cloudevents.Payload{SpecVersion: "1.0"},
+				},
+				{
+					Context: event.Context{ID: "3", Source: event.MustSource("/ci"), Type: "com.example.build.failed"},
+					Data:    cloudevents.Payload{SpecVersion: "1.0"},
+				},
+			},
+		},
+		{
+			name:        "undeclared type",
+			contentType: "application/cloudevents+json",
+			body:        `{"specversion": "1.0", "id": "1", "source": "/ci", "type": "com.example.build.succeeded"}`,
+			want:        []*event.Event{},
+		},
+		{
+			name:        "unsupported spec version",
+			contentType: "application/cloudevents+json",
+			body:        `{"specversion": "0.3", "id": "1", "source": "/ci", "type": "com.example.build.failed"}`,
+			wantErr:     true,
+		},
+		{
+			name:        "missing required attribute",
+			contentType: "application/cloudevents+json",
+			body:        `{"specversion": "1.0", "source": "/ci", "type": "com.example.build.failed"}`,
+			wantErr:     true,
+		},
+		{
+			name:        "invalid time",
+			contentType: "application/cloudevents+json",
+			body:        `{"specversion": "1.0", "id": "1", "source": "/ci", "type": "com.example.build.failed", "time": "yesterday"}`,
+			wantErr:     true,
+		},
+		{
+			name:        "malformed JSON",
+			contentType: "application/cloudevents-batch+json",
+			body:        `[{`,
+			wantErr:     true,
+		},
+		{
+			name:        "binary mode without ce headers",
+			contentType: "application/json",
+			body:        `{"message": "hello"}`,
+			wantErr:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequestWithContext(t.Context(), "POST", "/", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", tc.contentType)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+
+			got, err := parser.ParseBatch(req)
+
+			if tc.wantErr {
+				var httpErr *server.Error
+				require.ErrorAs(t, err, &httpErr)
+				assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+				assert.Nil(t, got)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, got, len(tc.want))
+			for i := range tc.want {
+				assert.Equal(t, tc.want[i].Context, got[i].Context)
+				want, gotData := tc.want[i].Data.(cloudevents.Payload), got[i].Data.(cloudevents.Payload)
+				assert.Equal(t, want.SpecVersion, gotData.SpecVersion)
+				assert.Equal(t, want.DataContentType, gotData.DataContentType)
+				assert.Equal(t, want.DataSchema, gotData.DataSchema)
+				assert.Equal(t, string(want.Data), string(gotData.Data))
+			}
+		})
+	}
+}
+
+func TestParser_Parse(t *testing.T) {
+	t.Parallel()
+
+	parser := cloudevents.NewParser(buildFailed)
+
+	t.Run("single event", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequestWithContext(t.Context(), "POST", "/", strings.NewReader(`{"specversion": "1.0", "id": "1", "source": "/ci", "type": "com.example.build.failed"}`))
+		req.Header.Set("Content-Type", cloudevents.ContentTypeStructured)
+
+		got, err := parser.Parse(req)
+		require.NoError(t, err)
+		assert.Equal(t, event.ID("1"), got.ID)
+	})
+
+	t.Run("undeclared type", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequestWithContext(t.Context(), "POST", "/", strings.NewReader(`{"specversion": "1.0", "id": "1", "source": "/ci", "type": "com.example.nope"}`))
+		req.Header.Set("Content-Type", cloudevents.ContentTypeStructured)
+
+		got, err := parser.Parse(req)
+		assert.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequestWithContext(t.Context(), "POST", "/", strings.NewReader(`[]`))
+		req.Header.Set("Content-Type", cloudevents.ContentTypeBatch)
+
+		_, err := parser.Parse(req)
+		assert.Error(t, err)
+	})
+}
+
+func TestParser_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		types   []event.TypeDescriptor
+		wantErr bool
+	}{
+		{name: "valid", types: []event.TypeDescriptor{buildFailed}},
+		{name: "no types", wantErr: true},
+		{name: "duplicate types", types: []event.TypeDescriptor{buildFailed, buildFailed}, wantErr: true},
+		{name: "missing title", types: []event.TypeDescriptor{{Key: "com.example.thing"}}, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := cloudevents.NewParser(tc.types...).Validate(t.Context())
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLoadEventTypes(t *testing.T) {
+	t.Parallel()
+
+	types, err := cloudevents.LoadEventTypes(strings.NewReader(`[{"key": "com.example.build.failed", "title": "Build Failed", "description": "Your build has failed"}]`))
+	require.NoError(t, err)
+	assert.Equal(t, []event.TypeDescriptor{buildFailed}, types)
+	assert.Equal(t, types, cloudevents.NewParser(types...).EventTypes())
+
+	_, err = cloudevents.LoadEventTypes(strings.NewReader(`{`))
+	assert.Error(t, err)
+}
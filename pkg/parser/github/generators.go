@@ -0,0 +1,157 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+)
+
+// NewReviewRequestGenerator returns an event.Processor which notifies users when their review is requested on a pull request
+func NewReviewRequestGenerator() event.Processor {
+	return event.ProcessorFunc(func(_ context.Context, evt event.Event, notifications []event.Notification) ([]event.Notification, error) {
+		payload, ok := evt.Data.(PullRequestEvent)
+		if !ok || evt.Type != PullRequestReviewRequested || payload.RequestedReviewer == nil {
+			return notifications, nil // Team review requests can't be routed to an individual
+		}
+
+		if payload.RequestedReviewer.ID == payload.Sender.ID {
+			return notifications, nil
+		}
+
+		message := fmt.Sprintf("%s requested your review on %s: %s", payload.Sender.Login, pullRequestRef(payload.Repository, payload.PullRequest), payload.PullRequest.HTMLURL)
+
+		return append(notifications, notify(evt, payload.RequestedReviewer.Identifiers(), message)), nil
+	})
+}
+
+var reviewVerbs = map[event.Type]string{
+	PullRequestReviewApproved:         "approved",
+	PullRequestReviewChangesRequested: "requested changes to",
+	PullRequestReviewCommented:        "reviewed",
+}
+
+// NewReviewGenerator returns an event.Processor which notifies pull request authors when a review is submitted
+func NewReviewGenerator() event.Processor {
+	return event.ProcessorFunc(func(_ context.Context, evt event.Event, notifications []event.Notification) ([]event.Notification, error) {
+		payload, ok := evt.Data.(PullRequestReviewEvent)
+		if !ok {
+			return notifications, nil
+		}
+
+		verb, ok := reviewVerbs[evt.Type]
+		author := payload.PullRequest.User
+		if !ok || author.ID == payload.Review.User.ID {
+			return notifications, nil
+		}
+
+		message := fmt.Sprintf("%s %s your pull request %s: %s", payload.Review.User.Login, verb, pullRequestRef(payload.Repository, payload.PullRequest), payload.Review.HTMLURL)
+
+		return append(notifications, notify(evt, author.Identifiers(), message)), nil
+	})
+}
+
+// NewCheckRunFailureGenerator returns an event.Processor which notifies whoever triggered a check run when it fails.
+// Check runs triggered by bots are ignored, since there's nobody to tell.
+func NewCheckRunFailureGenerator() event.Processor {
+	return event.ProcessorFunc(func(_ context.Context, evt event.Event, notifications []event.Notification) ([]event.Notification, error) {
+		payload, ok := evt.Data.(CheckRunEvent)
+		if !ok || evt.Type != CheckRunFailed || payload.Sender.IsBot() {
+			return notifications, nil
+		}
+
+		sha := payload.CheckRun.HeadSHA
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+
+		message := fmt.Sprintf("Check %q on %s@%s concluded with %s: %s", payload.CheckRun.Name, payload.Repository.FullName, sha, payload.CheckRun.Conclusion, payload.CheckRun.HTMLURL)
+
+		return append(notifications, notify(evt, payload.Sender.Identifiers(), message)), nil
+	})
+}
+
+// NewMentionGenerator returns an event.Processor which notifies users who are @mentioned in a new issue, pull request or comment.
+// Mentioned users are only known by their login, so an enrichment processor is needed to reach them on most transports.
+func NewMentionGenerator() event.Processor {
+	return event.ProcessorFunc(func(_ context.Context, evt event.Event, notifications []event.Notification) ([]event.Notification, error) {
+		var sender User
+		var text, where, url string
+
+		switch payload := evt.Data.(type) {
+		case IssuesEvent:
+			if evt.Type != IssueOpened {
+				return notifications, nil
+			}
+			sender, text, where, url = payload.Sender, payload.Issue.Body, issueRef(payload.Repository, payload.Issue), payload.Issue.HTMLURL
+		case PullRequestEvent:
+			if evt.Type != PullRequestOpened {
+				return notifications, nil
+			}
+			sender, text, where, url = payload.Sender, payload.PullRequest.Body, pullRequestRef(payload.Repository, payload.PullRequest), payload.PullRequest.HTMLURL
+		case IssueCommentEvent:
+			sender, text, where, url = payload.Sender, payload.Comment.Body, issueRef(payload.Repository, payload.Issue), payload.Comment.HTMLURL
+		default:
+			return notifications, nil
+		}
+
+		message := fmt.Sprintf("%s mentioned you in %s: %s", sender.Login, where, url)
+		for _, login := range Mentions(text) {
+			if strings.EqualFold(login, sender.Login) {
+				continue
+			}
+
+			notifications = append(notifications, notify(evt, identifier.NewSet(identifier.New(Username, login)), message))
+		}
+
+		return notifications, nil
+	})
+}
+
+// mentionPattern matches @login, but not email addresses or @org/team mentions
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@/])@([A-Za-z0-9](?:[A-Za-z0-9]|-[A-Za-z0-9]){0,38})\b(/)?`)
+
+// Mentions returns the unique logins @mentioned in some text, in the order they first appear
+func Mentions(text string) []string {
+	var logins []string
+	seen := map[string]bool{}
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		if match[2] == "/" { // Team mention
+			continue
+		}
+
+		login := match[1]
+		if seen[strings.ToLower(login)] {
+			continue
+		}
+
+		seen[strings.ToLower(login)] = true
+		logins = append(logins, login)
+	}
+
+	return logins
+}
+
+func pullRequestRef(repo Repository, pr PullRequest) string {
+	return fmt.Sprintf("%s#%d (%s)", repo.FullName, pr.Number, pr.Title)
+}
+
+func issueRef(repo Repository, issue Issue) string {
+	return fmt.Sprintf("%s#%d (%s)", repo.FullName, issue.Number, issue.Title)
+}
+
+func notify(evt event.Event, recipient identifier.Set, message string) event.Notification {
+	return notification.NewBuilder(evt.Context).
+		WithRecipient(recipient).
+		WithDefaultMessage(message).
+		Build()
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package github_test
+
+import (
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/parser/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type wantNotification struct {
+	recipient identifier.Set
+	message   string
+}
+
+func TestGenerators(t *testing.T) {
+	t.Parallel()
+
+	hubot := identifier.NewSet(identifier.New(github.ID, 2), identifier.New(github.Username, "hubot"))
+	octocat := identifier.NewSet(identifier.New(github.ID, 1), identifier.New(github.Username, "octocat"))
+
+	tests := []struct {
+		name      string
+		generator event.Processor
+		hook      string
+		fixture   string
+		want      []wantNotification
+	}{
+		{
+			name:      "review requested",
+			generator: github.NewReviewRequestGenerator(),
+			hook:      "pull_request",
+			fixture:   "pull_request_review_requested.json",
+			want: []wantNotification{
+				{recipient: hubot, message: "octocat requested your review on octocat/Hello-World#1347 (Amazing new feature): https://github.com/octocat/Hello-World/pull/1347"},
+			},
+		},
+		{
+			name:      "review requested from a team",
+			generator: github.NewReviewRequestGenerator(),
+			hook:      "pull_request",
+			fixture:   "pull_request_review_requested_team.json",
+		},
+		{
+			name:      "review submitted",
+			generator: github.NewReviewGenerator(),
+			hook:      "pull_request_review",
+			fixture:   "pull_request_review_submitted.json",
+			want: []wantNotification{
+				{recipient: octocat, message: "hubot approved your pull request octocat/Hello-World#1347 (Amazing new feature): https://github.com/octocat/Hello-World/pull/1347#pullrequestreview-80"},
+			},
+		},
+		{
+			name:      "check run failed",
+			generator: github.NewCheckRunFailureGenerator(),
+			hook:      "check_run",
+			fixture:   "check_run_completed.json",
+			want: []wantNotification{
+				{recipient: octocat, message: `Check "Octocoders-linter" on octocat/Hello-World@ec26c3e concluded with failure: https://github.com/octocat/Hello-World/runs/128620228`},
+			},
+		},
+		{
+			name:      "mentions in a new issue",
+			generator: github.NewMentionGenerator(),
+			hook:      "issues",
+			fixture:   "issues_opened.json",
+			want: []wantNotification{
+				{recipient: identifier.NewSet(identifier.New(github.Username, "hubot")), message: "octocat mentioned you in octocat/Hello-World#1 (Spelling error in the README file): https://github.com/octocat/Hello-World/issues/1"},
+				{recipient: identifier.NewSet(identifier.New(github.Username, "monalisa")), message: "octocat mentioned you in octocat/Hello-World#1 (Spelling error in the README file): https://github.com/octocat/Hello-World/issues/1"},
+			},
+		},
+		{
+			name:      "mentions in a new pull request",
+			generator: github.NewMentionGenerator(),
+			hook:      "pull_request",
+			fixture:   "pull_request_opened.json",
+			want: []wantNotification{
+				{recipient: identifier.NewSet(identifier.New(github.Username, "monalisa")), message: "octocat mentioned you in octocat/Hello-World#1347 (Amazing new feature): https://github.com/octocat/Hello-World/pull/1347"},
+			},
+		},
+		{
+			name:      "mentions in a comment",
+			generator: github.NewMentionGenerator(),
+			hook:      "issue_comment",
+			fixture:   "issue_comment_created.json",
+			want: []wantNotification{
+				{recipient: identifier.NewSet(identifier.New(github.Username, "octocat")), message: "hubot mentioned you in octocat/Hello-World#1 (Spelling error in the README file): https://github.com/octocat/Hello-World/issues/1#issuecomment-492700400"},
+				{recipient: identifier.NewSet(identifier.New(github.Username, "MonaLisa")), message: "hubot mentioned you in octocat/Hello-World#1 (Spelling error in the README file): https://github.com/octocat/Hello-World/issues/1#issuecomment-492700400"},
+			},
+		},
+		{
+			name:      "no mentions when a pull request is merged",
+			generator: github.NewMentionGenerator(),
+			hook:      "pull_request",
+			fixture:   "pull_request_closed.json",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			evt, err := github.NewParser([]byte(someSecret)).Parse(requestFor(t, tc.hook, tc.fixture))
+			require.NoError(t, err)
+			require.NotNil(t, evt)
+
+			got, err := tc.generator.Process(t.Context(), *evt, nil)
+			require.NoError(t, err)
+			require.Len(t, got, len(tc.want))
+
+			for i, want := range tc.want {
+				assert.Equal(t, evt.Context, got[i].Context())
+				assert.Equal(t, want.recipient.ToMap(), got[i].Recipient().ToMap())
+				assert.Equal(t, want.message, got[i].Render("any"))
+			}
+		})
+	}
+}
+
+func TestMentions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{text: "", want: nil},
+		{text: "@octocat", want: []string{"octocat"}},
+		{text: "hey @octocat, @mona-lisa and @octocat again", want: []string{"octocat", "mona-lisa"}},
+		{text: "cc @octo-org/reviewers", want: nil},
+		{text: "email octocat@github.com", want: nil},
+		{text: "(@hubot)", want: []string{"hubot"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.text, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, github.Mentions(tc.text))
+		})
+	}
+}
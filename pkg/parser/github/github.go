@@ -0,0 +1,58 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package github provides an event.Parser for GitHub webhooks, along with processors that generate notifications for them
+package github
+
+import (
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+)
+
+var (
+	// ID is the numeric ID of a GitHub user
+	ID = identifier.NewNamespaceAndKind("github.com", identifier.KindID)
+	// Username is the login of a GitHub user
+	Username = identifier.NewNamespaceAndKind("github.com", identifier.KindUsername)
+)
+
+const (
+	PullRequestOpened                 event.Type = "com.github.pull_request.opened"
+	PullRequestReopened               event.Type = "com.github.pull_request.reopened"
+	PullRequestReadyForReview         event.Type = "com.github.pull_request.ready_for_review"
+	PullRequestReviewRequested        event.Type = "com.github.pull_request.review_requested"
+	PullRequestAssigned               event.Type = "com.github.pull_request.assigned"
+	PullRequestMerged                 event.Type = "com.github.pull_request.merged"
+	PullRequestClosed                 event.Type = "com.github.pull_request.closed"
+	PullRequestReviewApproved         event.Type = "com.github.pull_request_review.approved"
+	PullRequestReviewChangesRequested event.Type = "com.github.pull_request_review.changes_requested"
+	PullRequestReviewCommented        event.Type = "com.github.pull_request_review.commented"
+	CheckRunFailed                    event.Type = "com.github.check_run.failed"
+	CheckRunSucceeded                 event.Type = "com.github.check_run.succeeded"
+	IssueOpened                       event.Type = "com.github.issues.opened"
+	IssueAssigned                     event.Type = "com.github.issues.assigned"
+	IssueClosed                       event.Type = "com.github.issues.closed"
+	IssueReopened                     event.Type = "com.github.issues.reopened"
+	IssueCommentCreated               event.Type = "com.github.issue_comment.created"
+)
+
+var eventTypes = []event.TypeDescriptor{
+	{Key: PullRequestOpened, Title: "Pull Request Opened", Description: "A pull request was opened"},
+	{Key: PullRequestReopened, Title: "Pull Request Reopened", Description: "A pull request was reopened"},
+	{Key: PullRequestReadyForReview, Title: "Pull Request Ready for Review", Description: "A draft pull request was marked as ready for review"},
+	{Key: PullRequestReviewRequested, Title: "Review Requested", Description: "Someone requested your review on a pull request"},
+	{Key: PullRequestAssigned, Title: "Pull Request Assigned", Description: "You were assigned to a pull request"},
+	{Key: PullRequestMerged, Title: "Pull Request Merged", Description: "A pull request was merged"},
+	{Key: PullRequestClosed, Title: "Pull Request Closed", Description: "A pull request was closed without being merged"},
+	{Key: PullRequestReviewApproved, Title: "Pull Request Approved", Description: "Someone approved your pull request"},
+	{Key: PullRequestReviewChangesRequested, Title: "Changes Requested", Description: "Someone requested changes to your pull request"},
+	{Key: PullRequestReviewCommented, Title: "Pull Request Reviewed", Description: "Someone left review comments on your pull request"},
+	{Key: CheckRunFailed, Title: "Check Failed", Description: "A check run failed, timed out or requires action"},
+	{Key: CheckRunSucceeded, Title: "Check Succeeded", Description: "A check run completed successfully"},
+	{Key: IssueOpened, Title: "Issue Opened", Description: "An issue was opened"},
+	{Key: IssueAssigned, Title: "Issue Assigned", Description: "You were assigned to an issue"},
+	{Key: IssueClosed, Title: "Issue Closed", Description: "An issue was closed"},
+	{Key: IssueReopened, Title: "Issue Reopened", Description: "An issue was reopened"},
+	{Key: IssueCommentCreated, Title: "Issue Comment", Description: "Someone commented on an issue or pull request"},
+}
@@ -0,0 +1,189 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/server"
+	"github.com/seatgeek/mailroom/pkg/verifier"
+)
+
+// Parser parses GitHub webhooks for pull requests, reviews, check runs, issues and comments.
+// Any other webhooks, or actions we don't send notifications for, are ignored.
+type Parser struct {
+	verifier verifier.Verifier
+}
+
+var _ event.Parser = &Parser{}
+
+// NewParser creates a new Parser which verifies the signature of each webhook using the given secret
+func NewParser(secret []byte) *Parser {
+	return &Parser{
+		verifier: verifier.GitHub(secret),
+	}
+}
+
+func (p *Parser) EventTypes() []event.TypeDescriptor {
+	return eventTypes
+}
+
+func (p *Parser) Parse(req *http.Request) (*event.Event, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, &server.Error{Code: http.StatusBadRequest, Reason: fmt.Errorf("failed to read request body: %w", err)}
+	}
+
+	if err := p.verifier.Verify(req, body); err != nil {
+		return nil, &server.Error{Code: http.StatusUnauthorized, Reason: err}
+	}
+
+	switch req.Header.Get("X-GitHub-Event") {
+	case "pull_request":
+		return parse(req, body, pullRequestContext)
+	case "pull_request_review":
+		return parse(req, body, pullRequestReviewContext)
+	case "check_run":
+		return parse(req, body, checkRunContext)
+	case "issues":
+		return parse(req, body, issuesContext)
+	case "issue_comment":
+		return parse(req, body, issueCommentContext)
+	default:
+		return nil, nil
+	}
+}
+
+// parse decodes the payload and builds an event from it, unless the payload isn't interesting
+func parse[T any](req *http.Request, body []byte, contextFor func(T) (event.Context, bool)) (*event.Event, error) {
+	var payload T
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, &server.Error{Code: http.StatusBadRequest, Reason: fmt.Errorf("failed to decode payload: %w", err)}
+	}
+
+	ctx, ok := contextFor(payload)
+	if !ok {
+		return nil, nil
+	}
+
+	id := req.Header.Get("X-GitHub-Delivery")
+	if id == "" {
+		id = uuid.New().String()
+	}
+	ctx.ID = event.ID(id)
+
+	return &event.Event{
+		Context: ctx,
+		Data:    payload,
+	}, nil
+}
+
+var pullRequestActions = map[string]event.Type{
+	"opened":           PullRequestOpened,
+	"reopened":         PullRequestReopened,
+	"ready_for_review": PullRequestReadyForReview,
+	"review_requested": PullRequestReviewRequested,
+	"assigned":         PullRequestAssigned,
+	"closed":           PullRequestClosed,
+}
+
+func pullRequestContext(payload PullRequestEvent) (event.Context, bool) {
+	typ, ok := pullRequestActions[payload.Action]
+	if !ok {
+		return event.Context{}, false
+	}
+
+	if typ == PullRequestClosed && payload.PullRequest.Merged {
+		typ = PullRequestMerged
+	}
+
+	return contextFor(payload.Repository, typ, payload.PullRequest.HTMLURL), true
+}
+
+var reviewStates = map[string]event.Type{
+	"approved":          PullRequestReviewApproved,
+	"changes_requested": PullRequestReviewChangesRequested,
+	"commented":         PullRequestReviewCommented,
+}
+
+func pullRequestReviewContext(payload PullRequestReviewEvent) (event.Context, bool) {
+	if payload.Action != "submitted" {
+		return event.Context{}, false
+	}
+
+	typ, ok := reviewStates[payload.Review.State]
+	if !ok {
+		return event.Context{}, false
+	}
+
+	return contextFor(payload.Repository, typ, payload.Review.HTMLURL), true
+}
+
+var checkRunConclusions = map[string]event.Type{
+	"success":         CheckRunSucceeded,
+	"failure":         CheckRunFailed,
+	"timed_out":       CheckRunFailed,
+	"action_required": CheckRunFailed,
+}
+
+func checkRunContext(payload CheckRunEvent) (event.Context, bool) {
+	if payload.Action != "completed" {
+		return event.Context{}, false
+	}
+
+	typ, ok := checkRunConclusions[payload.CheckRun.Conclusion]
+	if !ok {
+		return event.Context{}, false
+	}
+
+	return contextFor(payload.Repository, typ, payload.CheckRun.HTMLURL), true
+}
+
+var issueActions = map[string]event.Type{
+	"opened":   IssueOpened,
+	"assigned": IssueAssigned,
+	"closed":   IssueClosed,
+	"reopened": IssueReopened,
+}
+
+func issuesContext(payload IssuesEvent) (event.Context, bool) {
+	typ, ok := issueActions[payload.Action]
+	if !ok {
+		return event.Context{}, false
+	}
+
+	return contextFor(payload.Repository, typ, payload.Issue.HTMLURL), true
+}
+
+func issueCommentContext(payload IssueCommentEvent) (event.Context, bool) {
+	if payload.Action != "created" {
+		return event.Context{}, false
+	}
+
+	return contextFor(payload.Repository, IssueCommentCreated, payload.Comment.HTMLURL), true
+}
+
+func contextFor(repo Repository, typ event.Type, subject string) event.Context {
+	ctx := event.Context{
+		Type:    typ,
+		Subject: subject,
+		Labels: map[string]string{
+			"repository": repo.FullName,
+		},
+	}
+
+	if src := event.NewSource(repo.HTMLURL); src != nil {
+		ctx.Source = *src
+	} else {
+		ctx.Source = event.MustSource("https://github.com")
+	}
+
+	return ctx
+}
@@ -0,0 +1,187 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package github_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/parser/github"
+	"github.com/seatgeek/mailroom/pkg/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const someSecret = "s3cr3t"
+
+func TestParser_Parse(t *testing.T) {
+	t.Parallel()
+
+	parser := github.NewParser([]byte(someSecret))
+
+	tests := []struct {
+		name     string
+		hook     string
+		fixture  string
+		wantType event.Type
+		wantData any
+	}{
+		{
+			name:     "review requested",
+			hook:     "pull_request",
+			fixture:  "pull_request_review_requested.json",
+			wantType: github.PullRequestReviewRequested,
+			wantData: github.PullRequestEvent{},
+		},
+		{
+			name:     "pull request opened",
+			hook:     "pull_request",
+			fixture:  "pull_request_opened.json",
+			wantType: github.PullRequestOpened,
+			wantData: github.PullRequestEvent{},
+		},
+		{
+			name:     "pull request merged",
+			hook:     "pull_request",
+			fixture:  "pull_request_closed.json",
+			wantType: github.PullRequestMerged,
+			wantData: github.PullRequestEvent{},
+		},
+		{
+			name:    "uninteresting pull request action",
+			hook:    "pull_request",
+			fixture: "pull_request_labeled.json",
+		},
+		{
+			name:     "review submitted",
+			hook:     "pull_request_review",
+			fixture:  "pull_request_review_submitted.json",
+			wantType: github.PullRequestReviewApproved,
+			wantData: github.PullRequestReviewEvent{},
+		},
+		{
+			name:     "check run failed",
+			hook:     "check_run",
+			fixture:  "check_run_completed.json",
+			wantType: github.CheckRunFailed,
+			wantData: github.CheckRunEvent{},
+		},
+		{
+			name:     "issue opened",
+			hook:     "issues",
+			fixture:  "issues_opened.json",
+			wantType: github.IssueOpened,
+			wantData: github.IssuesEvent{},
+		},
+		{
+			name:     "issue comment",
+			hook:     "issue_comment",
+			fixture:  "issue_comment_created.json",
+			wantType: github.IssueCommentCreated,
+			wantData: github.IssueCommentEvent{},
+		},
+		{
+			name:    "unsupported webhook",
+			hook:    "push",
+			fixture: "issues_opened.json",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parser.Parse(requestFor(t, tc.hook, tc.fixture))
+			require.NoError(t, err)
+
+			if tc.wantType == "" {
+				assert.Nil(t, got)
+				return
+			}
+
+			require.NotNil(t, got)
+			assert.Equal(t, event.ID("72d3162e-cc78-11e3-81ab-4c9367dc0958"), got.ID)
+			assert.Equal(t, tc.wantType, got.Type)
+			assert.Equal(t, "https://github.com/octocat/Hello-World", got.Source.String())
+			assert.NotEmpty(t, got.Subject)
+			assert.Equal(t, "octocat/Hello-World", got.Labels["repository"])
+			assert.IsType(t, tc.wantData, got.Data)
+		})
+	}
+}
+
+func TestParser_Parse_decodesPayload(t *testing.T) {
+	t.Parallel()
+
+	got, err := github.NewParser([]byte(someSecret)).Parse(requestFor(t, "pull_request", "pull_request_review_requested.json"))
+	require.NoError(t, err)
+
+	payload := got.Data.(github.PullRequestEvent)
+	assert.Equal(t, "octocat", payload.Sender.Login)
+	assert.Equal(t, int64(1347), payload.PullRequest.Number)
+	assert.Equal(t, "Amazing new feature", payload.PullRequest.Title)
+	require.NotNil(t, payload.RequestedReviewer)
+	assert.Equal(t, "hubot", payload.RequestedReviewer.Login)
+	assert.Equal(t, "https://github.com/octocat/Hello-World/pull/1347", got.Subject)
+}
+
+func TestParser_Parse_verifiesSignature(t *testing.T) {
+	t.Parallel()
+
+	req := requestFor(t, "pull_request", "pull_request_opened.json")
+	req.Header.Set("X-Hub-Signature-256", "sha256="+sign("wrong", []byte("{}")))
+
+	got, err := github.NewParser([]byte(someSecret)).Parse(req)
+	assert.Nil(t, got)
+
+	var httpErr *server.Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+}
+
+func TestParser_EventTypes(t *testing.T) {
+	t.Parallel()
+
+	types := github.NewParser([]byte(someSecret)).EventTypes()
+
+	seen := map[event.Type]bool{}
+	for _, typ := range types {
+		assert.NotEmpty(t, typ.Title)
+		assert.NotEmpty(t, typ.Description)
+		assert.False(t, seen[typ.Key], "duplicate event type %s", typ.Key)
+		seen[typ.Key] = true
+	}
+
+	assert.Len(t, types, 17)
+}
+
+func requestFor(t *testing.T, hook, fixture string) *http.Request {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", fixture))
+	require.NoError(t, err)
+
+	req := httptest.NewRequestWithContext(t.Context(), "POST", "/github", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", hook)
+	req.Header.Set("X-GitHub-Delivery", "72d3162e-cc78-11e3-81ab-4c9367dc0958")
+	req.Header.Set("X-Hub-Signature-256", "sha256="+sign(someSecret, body))
+
+	return req
+}
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package github
+
+import (
+	"github.com/seatgeek/mailroom/pkg/identifier"
+)
+
+// User is a GitHub user (or bot) as it appears in webhook payloads
+type User struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+	Type  string `json:"type"`
+}
+
+// Identifiers returns the identifiers known for the user
+func (u User) Identifiers() identifier.Set {
+	ids := identifier.NewSet()
+	if u.ID != 0 {
+		ids.Add(identifier.New(ID, u.ID))
+	}
+	if u.Login != "" {
+		ids.Add(identifier.New(Username, u.Login))
+	}
+
+	return ids
+}
+
+// IsBot returns true for GitHub Apps and other automated accounts
+func (u User) IsBot() bool {
+	return u.Type == "Bot"
+}
+
+// Team is a GitHub team, which may be requested to review a pull request
+type Team struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// Repository is the GitHub repository which an event relates to
+type Repository struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	FullName string `json:"full_name"`
+	HTMLURL  string `json:"html_url"`
+}
+
+// PullRequest holds the attributes of a pull request
+type PullRequest struct {
+	ID                 int64  `json:"id"`
+	Number             int64  `json:"number"`
+	Title              string `json:"title"`
+	Body               string `json:"body"`
+	State              string `json:"state"`
+	HTMLURL            string `json:"html_url"`
+	Draft              bool   `json:"draft"`
+	Merged             bool   `json:"merged"`
+	User               User   `json:"user"`
+	Assignees          []User `json:"assignees"`
+	RequestedReviewers []User `json:"requested_reviewers"`
+}
+
+// Review holds the attributes of a pull request review
+type Review struct {
+	ID      int64  `json:"id"`
+	Body    string `json:"body"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+	User    User   `json:"user"`
+}
+
+// CheckRun holds the attributes of a check run
+type CheckRun struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	HeadSHA      string `json:"head_sha"`
+	Status       string `json:"status"`
+	Conclusion   string `json:"conclusion"`
+	HTMLURL      string `json:"html_url"`
+	DetailsURL   string `json:"details_url"`
+	PullRequests []struct {
+		Number int64 `json:"number"`
+	} `json:"pull_requests"`
+}
+
+// Issue holds the attributes of an issue (or of a pull request, when commented on)
+type Issue struct {
+	ID        int64  `json:"id"`
+	Number    int64  `json:"number"`
+	Title     string `json:"title"`
+	Body      string `json:"body"`
+	State     string `json:"state"`
+	HTMLURL   string `json:"html_url"`
+	User      User   `json:"user"`
+	Assignees []User `json:"assignees"`
+	// PullRequest is set if the issue is actually a pull request
+	PullRequest *struct {
+		HTMLURL string `json:"html_url"`
+	} `json:"pull_request,omitempty"`
+}
+
+// Comment holds the attributes of an issue or pull request comment
+type Comment struct {
+	ID      int64  `json:"id"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+	User    User   `json:"user"`
+}
+
+// PullRequestEvent is the payload of a "pull_request" webhook
+// See https://docs.github.com/en/webhooks/webhook-events-and-payloads#pull_request
+type PullRequestEvent struct {
+	Action            string      `json:"action"`
+	Number            int64       `json:"number"`
+	PullRequest       PullRequest `json:"pull_request"`
+	RequestedReviewer *User       `json:"requested_reviewer,omitempty"`
+	RequestedTeam     *Team       `json:"requested_team,omitempty"`
+	Assignee          *User       `json:"assignee,omitempty"`
+	Repository        Repository  `json:"repository"`
+	Sender            User        `json:"sender"`
+}
+
+// PullRequestReviewEvent is the payload of a "pull_request_review" webhook
+// See https://docs.github.com/en/webhooks/webhook-events-and-payloads#pull_request_review
+type PullRequestReviewEvent struct {
+	Action      string      `json:"action"`
+	Review      Review      `json:"review"`
+	PullRequest PullRequest `json:"pull_request"`
+	Repository  Repository  `json:"repository"`
+	Sender      User        `json:"sender"`
+}
+
+// CheckRunEvent is the payload of a "check_run" webhook
+// See https://docs.github.com/en/webhooks/webhook-events-and-payloads#check_run
+type CheckRunEvent struct {
+	Action     string     `json:"action"`
+	CheckRun   CheckRun   `json:"check_run"`
+	Repository Repository `json:"repository"`
+	Sender     User       `json:"sender"`
+}
+
+// IssuesEvent is the payload of an "issues" webhook
+// See https://docs.github.com/en/webhooks/webhook-events-and-payloads#issues
+type IssuesEvent struct {
+	Action     string     `json:"action"`
+	Issue      Issue      `json:"issue"`
+	Assignee   *User      `json:"assignee,omitempty"`
+	Repository Repository `json:"repository"`
+	Sender     User       `json:"sender"`
+}
+
+// IssueCommentEvent is the payload of an "issue_comment" webhook
+// See https://docs.github.com/en/webhooks/webhook-events-and-payloads#issue_comment
+type IssueCommentEvent struct {
+	Action     string     `json:"action"`
+	Issue      Issue      `json:"issue"`
+	Comment    Comment    `json:"comment"`
+	Repository Repository `json:"repository"`
+	Sender     User       `json:"sender"`
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+)
+
+// NewReviewerGenerator returns an event.Processor which notifies users when their review is requested on a merge request
+func NewReviewerGenerator() event.Processor {
+	return event.ProcessorFunc(func(_ context.Context, evt event.Event, notifications []event.Notification) ([]event.Notification, error) {
+		payload, ok := evt.Data.(MergeRequestEvent)
+		if !ok {
+			return notifications, nil
+		}
+
+		var reviewers []User
+		switch evt.Type {
+		case MergeRequestOpened, MergeRequestReopened:
+			reviewers = payload.Reviewers
+		case MergeRequestUpdated:
+			if payload.Changes.Reviewers != nil {
+				reviewers = payload.Changes.Reviewers.Added()
+			}
+		}
+
+		message := fmt.Sprintf("%s requested your review on %s: %s", payload.User.Name, mergeRequestRef(payload.Project, payload.ObjectAttributes), payload.ObjectAttributes.URL)
+		for _, u := range excluding(reviewers, payload.User.ID) {
+			notifications = append(notifications, notify(evt, u.Identifiers(), message))
+		}
+
+		return notifications, nil
+	})
+}
+
+// NewAssigneeGenerator returns an event.Processor which notifies users when they are assigned to a merge request or issue
+func NewAssigneeGenerator() event.Processor {
+	return event.ProcessorFunc(func(_ context.Context, evt event.Event, notifications []event.Notification) ([]event.Notification, error) {
+		var actor User
+		var assignees []User
+		var ref, url string
+
+		switch payload := evt.Data.(type) {
+		case MergeRequestEvent:
+			actor, ref, url = payload.User, mergeRequestRef(payload.Project, payload.ObjectAttributes), payload.ObjectAttributes.URL
+			assignees = assigneesFor(evt.Type, payload.Assignees, payload.Changes.Assignees)
+		case IssueEvent:
+			actor, ref, url = payload.User, issueRef(payload.Project, payload.ObjectAttributes), payload.ObjectAttributes.URL
+			assignees = assigneesFor(evt.Type, payload.Assignees, payload.Changes.Assignees)
+		default:
+			return notifications, nil
+		}
+
+		message := fmt.Sprintf("%s assigned you to %s: %s", actor.Name, ref, url)
+		for _, u := range excluding(assignees, actor.ID) {
+			notifications = append(notifications, notify(evt, u.Identifiers(), message))
+		}
+
+		return notifications, nil
+	})
+}
+
+// NewAuthorGenerator returns an event.Processor which notifies authors about activity on their merge requests, issues and pipelines
+func NewAuthorGenerator() event.Processor {
+	return event.ProcessorFunc(func(_ context.Context, evt event.Event, notifications []event.Notification) ([]event.Notification, error) {
+		var authorID int64
+		var actor User
+		var message string
+
+		switch payload := evt.Data.(type) {
+		case MergeRequestEvent:
+			verb, ok := mergeRequestVerbs[evt.Type]
+			if !ok {
+				return notifications, nil
+			}
+			authorID, actor = payload.ObjectAttributes.AuthorID, payload.User
+			message = fmt.Sprintf("%s %s your merge request %s: %s", actor.Name, verb, mergeRequestRef(payload.Project, payload.ObjectAttributes), payload.ObjectAttributes.URL)
+		case IssueEvent:
+			verb, ok := issueVerbs[evt.Type]
+			if !ok {
+				return notifications, nil
+			}
+			authorID, actor = payload.ObjectAttributes.AuthorID, payload.User
+			message = fmt.Sprintf("%s %s your issue %s: %s", actor.Name, verb, issueRef(payload.Project, payload.ObjectAttributes), payload.ObjectAttributes.URL)
+		case NoteEvent:
+			var ref string
+			authorID, actor, ref = noteTarget(payload)
+			if authorID == 0 {
+				return notifications, nil
+			}
+			message = fmt.Sprintf("%s commented on %s: %s", actor.Name, ref, payload.ObjectAttributes.URL)
+		case PipelineEvent:
+			// Whoever triggered the pipeline wants to hear about it, even though they are the actor
+			return append(notifications, notify(evt, payload.User.Identifiers(), fmt.Sprintf("Pipeline #%d for %s on %s %s: %s", payload.ObjectAttributes.ID, payload.ObjectAttributes.Ref, payload.Project.PathWithNamespace, pipelineVerbs[evt.Type], payload.ObjectAttributes.URL))), nil
+		default:
+			return notifications, nil
+		}
+
+		if authorID == 0 || authorID == actor.ID {
+			return notifications, nil
+		}
+
+		return append(notifications, notify(evt, identifier.NewSet(identifier.New(ID, authorID)), message)), nil
+	})
+}
+
+var mergeRequestVerbs = map[event.Type]string{
+	MergeRequestApproved:   "approved",
+	MergeRequestUnapproved: "revoked their approval of",
+	MergeRequestMerged:     "merged",
+	MergeRequestClosed:     "closed",
+}
+
+var issueVerbs = map[event.Type]string{
+	IssueClosed:   "closed",
+	IssueReopened: "reopened",
+}
+
+var pipelineVerbs = map[event.Type]string{
+	PipelineFailed:    "failed",
+	PipelineSucceeded: "succeeded",
+}
+
+// assigneesFor returns whoever should be told they were assigned: everyone when opened, or only those newly added when updated
+func assigneesFor(typ event.Type, assignees []User, change *UserChange) []User {
+	switch typ {
+	case MergeRequestOpened, MergeRequestReopened, IssueOpened:
+		return assignees
+	case MergeRequestUpdated, IssueUpdated:
+		if change != nil {
+			return change.Added()
+		}
+	}
+
+	return nil
+}
+
+// noteTarget returns the author of whatever was commented on, the commenter, and a reference to the thing commented on
+func noteTarget(payload NoteEvent) (int64, User, string) {
+	switch {
+	case payload.MergeRequest != nil:
+		return payload.MergeRequest.AuthorID, payload.User, "your merge request " + mergeRequestRef(payload.Project, *payload.MergeRequest)
+	case payload.Issue != nil:
+		return payload.Issue.AuthorID, payload.User, "your issue " + issueRef(payload.Project, *payload.Issue)
+	default:
+		return 0, payload.User, ""
+	}
+}
+
+func excluding(users []User, id int64) []User {
+	res := make([]User, 0, len(users))
+	for _, u := range users {
+		if u.ID != id {
+			res = append(res, u)
+		}
+	}
+
+	return res
+}
+
+func mergeRequestRef(project Project, mr MergeRequest) string {
+	return fmt.Sprintf("%s!%d (%s)", project.PathWithNamespace, mr.IID, mr.Title)
+}
+
+func issueRef(project Project, issue Issue) string {
+	return fmt.Sprintf("%s#%d (%s)", project.PathWithNamespace, issue.IID, issue.Title)
+}
+
+func notify(evt event.Event, recipient identifier.Set, message string) event.Notification {
+	return notification.NewBuilder(evt.Context).
+		WithRecipient(recipient).
+		WithDefaultMessage(message).
+		Build()
+}
@@ -0,0 +1,210 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package gitlab_test
+
+import (
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/parser/gitlab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type wantNotification struct {
+	recipient identifier.Set
+	message   string
+}
+
+func TestGenerators(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		generator event.Processor
+		hook      string
+		fixture   string
+		want      []wantNotification
+	}{
+		{
+			name:      "reviewers of a new merge request",
+			generator: gitlab.NewReviewerGenerator(),
+			hook:      "Merge Request Hook",
+			fixture:   "merge_request_open.json",
+			want: []wantNotification{
+				{
+					recipient: identifier.NewSet(identifier.New(gitlab.ID, 6), identifier.New(gitlab.Username, "user1")),
+					message:   "Administrator requested your review on gitlabhq/gitlab-test!1 (MS-Viewport): https://gitlab.example.com/gitlabhq/gitlab-test/-/merge_requests/1",
+				},
+				{
+					recipient: identifier.NewSet(identifier.New(gitlab.ID, 7), identifier.New(gitlab.Username, "user2")),
+					message:   "Administrator requested your review on gitlabhq/gitlab-test!1 (MS-Viewport): https://gitlab.example.com/gitlabhq/gitlab-test/-/merge_requests/1",
+				},
+			},
+		},
+		{
+			name:      "reviewers newly added to a merge request",
+			generator: gitlab.NewReviewerGenerator(),
+			hook:      "Merge Request Hook",
+			fixture:   "merge_request_update.json",
+			want: []wantNotification{
+				{
+					recipient: identifier.NewSet(identifier.New(gitlab.ID, 8), identifier.New(gitlab.Username, "user3")),
+					message:   "User1 requested your review on gitlabhq/gitlab-test!1 (MS-Viewport): https://gitlab.example.com/gitlabhq/gitlab-test/-/merge_requests/1",
+				},
+			},
+		},
+		{
+			name:      "reviewers aren't notified about approvals",
+			generator: gitlab.NewReviewerGenerator(),
+			hook:      "Merge Request Hook",
+			fixture:   "merge_request_approval.json",
+		},
+		{
+			name:      "assignees of a new merge request",
+			generator: gitlab.NewAssigneeGenerator(),
+			hook:      "Merge Request Hook",
+			fixture:   "merge_request_open.json",
+			want: []wantNotification{
+				{
+					recipient: identifier.NewSet(identifier.New(gitlab.ID, 6), identifier.New(gitlab.Username, "user1")),
+					message:   "Administrator assigned you to gitlabhq/gitlab-test!1 (MS-Viewport): https://gitlab.example.com/gitlabhq/gitlab-test/-/merge_requests/1",
+				},
+			},
+		},
+		{
+			name:      "assignees newly added to a merge request",
+			generator: gitlab.NewAssigneeGenerator(),
+			hook:      "Merge Request Hook",
+			fixture:   "merge_request_update.json",
+			want: []wantNotification{
+				{
+					recipient: identifier.NewSet(identifier.New(gitlab.ID, 8), identifier.New(gitlab.Username, "user3")),
+					message:   "User1 assigned you to gitlabhq/gitlab-test!1 (MS-Viewport): https://gitlab.example.com/gitlabhq/gitlab-test/-/merge_requests/1",
+				},
+			},
+		},
+		{
+			name:      "assignees of a new issue",
+			generator: gitlab.NewAssigneeGenerator(),
+			hook:      "Issue Hook",
+			fixture:   "issue_open.json",
+			want: []wantNotification{
+				{
+					recipient: identifier.NewSet(
+						identifier.New(gitlab.ID, 51),
+						identifier.New(gitlab.Username, "user1"),
+						identifier.New(identifier.GenericEmail, "user1@example.com"),
+					),
+					message: "Administrator assigned you to gitlabhq/gitlab-test#23 (New API: create/update/delete file): https://gitlab.example.com/gitlabhq/gitlab-test/-/issues/23",
+				},
+			},
+		},
+		{
+			name:      "author of an approved merge request",
+			generator: gitlab.NewAuthorGenerator(),
+			hook:      "Merge Request Hook",
+			fixture:   "merge_request_approval.json",
+			want: []wantNotification{
+				{
+					recipient: identifier.NewSet(identifier.New(gitlab.ID, 1)),
+					message:   "User2 approved your merge request gitlabhq/gitlab-test!1 (MS-Viewport): https://gitlab.example.com/gitlabhq/gitlab-test/-/merge_requests/1",
+				},
+			},
+		},
+		{
+			name:      "authors aren't notified about opening their own merge request",
+			generator: gitlab.NewAuthorGenerator(),
+			hook:      "Merge Request Hook",
+			fixture:   "merge_request_open.json",
+		},
+		{
+			name:      "author of a commented merge request",
+			generator: gitlab.NewAuthorGenerator(),
+			hook:      "Note Hook",
+			fixture:   "note_merge_request.json",
+			want: []wantNotification{
+				{
+					recipient: identifier.NewSet(identifier.New(gitlab.ID, 1)),
+					message:   "User2 commented on your merge request gitlabhq/gitlab-test!1 (Tempora et eos debitis quae laborum et.): https://gitlab.example.com/gitlabhq/gitlab-test/-/merge_requests/1#note_1244",
+				},
+			},
+		},
+		{
+			name:      "author of a commented issue",
+			generator: gitlab.NewAuthorGenerator(),
+			hook:      "Note Hook",
+			fixture:   "note_issue.json",
+			want: []wantNotification{
+				{
+					recipient: identifier.NewSet(identifier.New(gitlab.ID, 7)),
+					message:   "Administrator commented on your issue gitlabhq/gitlab-test#17 (test): https://gitlab.example.com/gitlabhq/gitlab-test/-/issues/17#note_1241",
+				},
+			},
+		},
+		{
+			name:      "author of a closed issue",
+			generator: gitlab.NewAuthorGenerator(),
+			hook:      "Issue Hook",
+			fixture:   "issue_close.json",
+			want: []wantNotification{
+				{
+					recipient: identifier.NewSet(identifier.New(gitlab.ID, 1)),
+					message:   "User1 closed your issue gitlabhq/gitlab-test#23 (New API: create/update/delete file): https://gitlab.example.com/gitlabhq/gitlab-test/-/issues/23",
+				},
+			},
+		},
+		{
+			name:      "whoever triggered a failed pipeline",
+			generator: gitlab.NewAuthorGenerator(),
+			hook:      "Pipeline Hook",
+			fixture:   "pipeline_failed.json",
+			want: []wantNotification{
+				{
+					recipient: identifier.NewSet(
+						identifier.New(gitlab.ID, 1),
+						identifier.New(gitlab.Username, "root"),
+						identifier.New(identifier.GenericEmail, "user_email@gitlab.com"),
+					),
+					message: "Pipeline #31 for master on gitlab-org/gitlab-test failed: https://gitlab.example.com/gitlab-org/gitlab-test/-/pipelines/31",
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			evt, err := gitlab.NewParser([]byte(someToken)).Parse(requestFor(t, tc.hook, tc.fixture))
+			require.NoError(t, err)
+			require.NotNil(t, evt)
+
+			got, err := tc.generator.Process(t.Context(), *evt, nil)
+			require.NoError(t, err)
+			require.Len(t, got, len(tc.want))
+
+			for i, want := range tc.want {
+				assert.Equal(t, evt.Context, got[i].Context())
+				assert.Equal(t, want.recipient.ToMap(), got[i].Recipient().ToMap())
+				assert.Equal(t, want.message, got[i].Render("any"))
+			}
+		})
+	}
+}
+
+func TestGenerators_ignoreOtherEvents(t *testing.T) {
+	t.Parallel()
+
+	evt := event.Event{Context: event.Context{Type: "com.example.other"}, Data: "something else"}
+	existing := []event.Notification{nil}
+
+	for _, generator := range []event.Processor{gitlab.NewReviewerGenerator(), gitlab.NewAssigneeGenerator(), gitlab.NewAuthorGenerator()} {
+		got, err := generator.Process(t.Context(), evt, existing)
+		assert.NoError(t, err)
+		assert.Equal(t, existing, got)
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package gitlab provides an event.Parser for GitLab webhooks, along with processors that generate notifications for them
+package gitlab
+
+import (
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+)
+
+var (
+	// ID is the numeric ID of a GitLab user
+	ID = identifier.NewNamespaceAndKind("gitlab.com", identifier.KindID)
+	// Username is the username of a GitLab user
+	Username = identifier.NewNamespaceAndKind("gitlab.com", identifier.KindUsername)
+)
+
+const (
+	MergeRequestOpened     event.Type = "com.gitlab.merge_request.opened"
+	MergeRequestUpdated    event.Type = "com.gitlab.merge_request.updated"
+	MergeRequestApproved   event.Type = "com.gitlab.merge_request.approved"
+	MergeRequestUnapproved event.Type = "com.gitlab.merge_request.unapproved"
+	MergeRequestMerged     event.Type = "com.gitlab.merge_request.merged"
+	MergeRequestClosed     event.Type = "com.gitlab.merge_request.closed"
+	MergeRequestReopened   event.Type = "com.gitlab.merge_request.reopened"
+	PipelineFailed         event.Type = "com.gitlab.pipeline.failed"
+	PipelineSucceeded      event.Type = "com.gitlab.pipeline.succeeded"
+	NoteOnMergeRequest     event.Type = "com.gitlab.note.merge_request"
+	NoteOnIssue            event.Type = "com.gitlab.note.issue"
+	IssueOpened            event.Type = "com.gitlab.issue.opened"
+	IssueUpdated           event.Type = "com.gitlab.issue.updated"
+	IssueClosed            event.Type = "com.gitlab.issue.closed"
+	IssueReopened          event.Type = "com.gitlab.issue.reopened"
+)
+
+var eventTypes = []event.TypeDescriptor{
+	{Key: MergeRequestOpened, Title: "Merge Request Opened", Description: "A merge request you're reviewing or assigned to was opened"},
+	{Key: MergeRequestUpdated, Title: "Merge Request Reviewer/Assignee Added", Description: "You were added as a reviewer or assignee on a merge request"},
+	{Key: MergeRequestApproved, Title: "Merge Request Approved", Description: "Someone approved your merge request"},
+	{Key: MergeRequestUnapproved, Title: "Merge Request Unapproved", Description: "Someone revoked their approval of your merge request"},
+	{Key: MergeRequestMerged, Title: "Merge Request Merged", Description: "Your merge request was merged"},
+	{Key: MergeRequestClosed, Title: "Merge Request Closed", Description: "Your merge request was closed"},
+	{Key: MergeRequestReopened, Title: "Merge Request Reopened", Description: "A merge request you're reviewing or assigned to was reopened"},
+	{Key: PipelineFailed, Title: "Pipeline Failed", Description: "A pipeline you triggered has failed"},
+	{Key: PipelineSucceeded, Title: "Pipeline Succeeded", Description: "A pipeline you triggered has succeeded"},
+	{Key: NoteOnMergeRequest, Title: "Merge Request Comment", Description: "Someone commented on your merge request"},
+	{Key: NoteOnIssue, Title: "Issue Comment", Description: "Someone commented on your issue"},
+	{Key: IssueOpened, Title: "Issue Opened", Description: "An issue assigned to you was opened"},
+	{Key: IssueUpdated, Title: "Issue Assigned", Description: "You were assigned to an issue"},
+	{Key: IssueClosed, Title: "Issue Closed", Description: "Your issue was closed"},
+	{Key: IssueReopened, Title: "Issue Reopened", Description: "Your issue was reopened"},
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/server"
+	"github.com/seatgeek/mailroom/pkg/verifier"
+)
+
+// Parser parses GitLab webhooks for merge requests, pipelines, comments and issues.
+// Any other hooks, or actions we don't send notifications for, are ignored.
+type Parser struct {
+	verifier verifier.Verifier
+}
+
+var _ event.Parser = &Parser{}
+
+// NewParser creates a new Parser which verifies the secret token configured on the GitLab webhook
+func NewParser(token []byte) *Parser {
+	return &Parser{
+		verifier: verifier.GitLab(token),
+	}
+}
+
+func (p *Parser) EventTypes() []event.TypeDescriptor {
+	return eventTypes
+}
+
+func (p *Parser) Parse(req *http.Request) (*event.Event, error) {
+	if err := p.verifier.Verify(req, nil); err != nil {
+		return nil, &server.Error{Code: http.StatusUnauthorized, Reason: err}
+	}
+
+	switch req.Header.Get("X-Gitlab-Event") {
+	case "Merge Request Hook":
+		return parse(req, mergeRequestContext)
+	case "Pipeline Hook":
+		return parse(req, pipelineContext)
+	case "Note Hook":
+		return parse(req, noteContext)
+	case "Issue Hook":
+		return parse(req, issueContext)
+	default:
+		return nil, nil
+	}
+}
+
+// parse decodes the payload and builds an event from it, unless the payload isn't interesting
+func parse[T any](req *http.Request, contextFor func(T) (event.Context, bool)) (*event.Event, error) {
+	var payload T
+	if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+		return nil, &server.Error{Code: http.StatusBadRequest, Reason: fmt.Errorf("failed to decode payload: %w", err)}
+	}
+
+	ctx, ok := contextFor(payload)
+	if !ok {
+		return nil, nil
+	}
+
+	id := req.Header.Get("X-Gitlab-Event-UUID")
+	if id == "" {
+		id = uuid.New().String()
+	}
+	ctx.ID = event.ID(id)
+
+	return &event.Event{
+		Context: ctx,
+		Data:    payload,
+	}, nil
+}
+
+var mergeRequestActions = map[string]event.Type{
+	"open":       MergeRequestOpened,
+	"update":     MergeRequestUpdated,
+	"approval":   MergeRequestApproved,
+	"unapproval": MergeRequestUnapproved,
+	"merge":      MergeRequestMerged,
+	"close":      MergeRequestClosed,
+	"reopen":     MergeRequestReopened,
+}
+
+func mergeRequestContext(payload MergeRequestEvent) (event.Context, bool) {
+	typ, ok := mergeRequestActions[payload.ObjectAttributes.Action]
+	if !ok {
+		return event.Context{}, false
+	}
+
+	return contextFor(payload.Project, typ, payload.ObjectAttributes.URL), true
+}
+
+var pipelineStatuses = map[string]event.Type{
+	"failed":  PipelineFailed,
+	"success": PipelineSucceeded,
+}
+
+func pipelineContext(payload PipelineEvent) (event.Context, bool) {
+	typ, ok := pipelineStatuses[payload.ObjectAttributes.Status]
+	if !ok {
+		return event.Context{}, false
+	}
+
+	return contextFor(payload.Project, typ, payload.ObjectAttributes.URL), true
+}
+
+var noteableTypes = map[string]event.Type{
+	"MergeRequest": NoteOnMergeRequest,
+	"Issue":        NoteOnIssue,
+}
+
+func noteContext(payload NoteEvent) (event.Context, bool) {
+	typ, ok := noteableTypes[payload.ObjectAttributes.NoteableType]
+	if !ok {
+		return event.Context{}, false
+	}
+
+	return contextFor(payload.Project, typ, payload.ObjectAttributes.URL), true
+}
+
+var issueActions = map[string]event.Type{
+	"open":   IssueOpened,
+	"update": IssueUpdated,
+	"close":  IssueClosed,
+	"reopen": IssueReopened,
+}
+
+func issueContext(payload IssueEvent) (event.Context, bool) {
+	typ, ok := issueActions[payload.ObjectAttributes.Action]
+	if !ok {
+		return event.Context{}, false
+	}
+
+	return contextFor(payload.Project, typ, payload.ObjectAttributes.URL), true
+}
+
+func contextFor(project Project, typ event.Type, subject string) event.Context {
+	ctx := event.Context{
+		Type:    typ,
+		Subject: subject,
+		Labels: map[string]string{
+			"project": project.PathWithNamespace,
+		},
+	}
+
+	if src := event.NewSource(project.WebURL); src != nil {
+		ctx.Source = *src
+	} else {
+		ctx.Source = event.MustSource("https://gitlab.com")
+	}
+
+	return ctx
+}
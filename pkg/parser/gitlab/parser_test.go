@@ -0,0 +1,218 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package gitlab_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/parser/gitlab"
+	"github.com/seatgeek/mailroom/pkg/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const someToken = "s3cr3t"
+
+func TestParser_Parse(t *testing.T) {
+	t.Parallel()
+
+	parser := gitlab.NewParser([]byte(someToken))
+
+	tests := []struct {
+		name     string
+		hook     string
+		fixture  string
+		wantType event.Type
+		wantData any
+	}{
+		{
+			name:     "merge request opened",
+			hook:     "Merge Request Hook",
+			fixture:  "merge_request_open.json",
+			wantType: gitlab.MergeRequestOpened,
+			wantData: gitlab.MergeRequestEvent{},
+		},
+		{
+			name:     "merge request updated",
+			hook:     "Merge Request Hook",
+			fixture:  "merge_request_update.json",
+			wantType: gitlab.MergeRequestUpdated,
+			wantData: gitlab.MergeRequestEvent{},
+		},
+		{
+			name:     "merge request approved",
+			hook:     "Merge Request Hook",
+			fixture:  "merge_request_approval.json",
+			wantType: gitlab.MergeRequestApproved,
+			wantData: gitlab.MergeRequestEvent{},
+		},
+		{
+			name:     "pipeline failed",
+			hook:     "Pipeline Hook",
+			fixture:  "pipeline_failed.json",
+			wantType: gitlab.PipelineFailed,
+			wantData: gitlab.PipelineEvent{},
+		},
+		{
+			name:    "pipeline still running",
+			hook:    "Pipeline Hook",
+			fixture: "pipeline_running.json",
+		},
+		{
+			name:     "comment on merge request",
+			hook:     "Note Hook",
+			fixture:  "note_merge_request.json",
+			wantType: gitlab.NoteOnMergeRequest,
+			wantData: gitlab.NoteEvent{},
+		},
+		{
+			name:     "comment on issue",
+			hook:     "Note Hook",
+			fixture:  "note_issue.json",
+			wantType: gitlab.NoteOnIssue,
+			wantData: gitlab.NoteEvent{},
+		},
+		{
+			name:     "issue opened",
+			hook:     "Issue Hook",
+			fixture:  "issue_open.json",
+			wantType: gitlab.IssueOpened,
+			wantData: gitlab.IssueEvent{},
+		},
+		{
+			name:     "issue closed",
+			hook:     "Issue Hook",
+			fixture:  "issue_close.json",
+			wantType: gitlab.IssueClosed,
+			wantData: gitlab.IssueEvent{},
+		},
+		{
+			name:    "unsupported hook",
+			hook:    "Push Hook",
+			fixture: "pipeline_failed.json",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parser.Parse(requestFor(t, tc.hook, tc.fixture))
+			require.NoError(t, err)
+
+			if tc.wantType == "" {
+				assert.Nil(t, got)
+				return
+			}
+
+			require.NotNil(t, got)
+			assert.Equal(t, event.ID("e2f6ae0c-5a1e-4fa6-a5b0-ba1c0f0f2a4d"), got.ID)
+			assert.Equal(t, tc.wantType, got.Type)
+			assert.True(t, strings.HasPrefix(got.Source.String(), "https://gitlab.example.com/"))
+			assert.NotEmpty(t, got.Subject)
+			assert.NotEmpty(t, got.Labels["project"])
+			assert.IsType(t, tc.wantData, got.Data)
+		})
+	}
+}
+
+func TestParser_Parse_decodesPayload(t *testing.T) {
+	t.Parallel()
+
+	got, err := gitlab.NewParser([]byte(someToken)).Parse(requestFor(t, "Merge Request Hook", "merge_request_open.json"))
+	require.NoError(t, err)
+
+	payload := got.Data.(gitlab.MergeRequestEvent)
+	assert.Equal(t, "root", payload.User.Username)
+	assert.Equal(t, int64(1), payload.ObjectAttributes.IID)
+	assert.Equal(t, "MS-Viewport", payload.ObjectAttributes.Title)
+	assert.Equal(t, int64(1), payload.ObjectAttributes.AuthorID)
+	assert.Len(t, payload.Reviewers, 2)
+	assert.Len(t, payload.Assignees, 1)
+	assert.Equal(t, "https://gitlab.example.com/gitlabhq/gitlab-test/-/merge_requests/1", got.Subject)
+}
+
+func TestParser_Parse_verifiesToken(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "missing token"},
+		{name: "wrong token", token: "nope"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := requestFor(t, "Merge Request Hook", "merge_request_open.json")
+			req.Header.Del("X-Gitlab-Token")
+			if tc.token != "" {
+				req.Header.Set("X-Gitlab-Token", tc.token)
+			}
+
+			got, err := gitlab.NewParser([]byte(someToken)).Parse(req)
+			assert.Nil(t, got)
+
+			var httpErr *server.Error
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+		})
+	}
+}
+
+func TestParser_Parse_invalidPayload(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequestWithContext(t.Context(), "POST", "/gitlab", strings.NewReader("{"))
+	req.Header.Set("X-Gitlab-Token", someToken)
+	req.Header.Set("X-Gitlab-Event", "Issue Hook")
+
+	_, err := gitlab.NewParser([]byte(someToken)).Parse(req)
+
+	var httpErr *server.Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+func TestParser_EventTypes(t *testing.T) {
+	t.Parallel()
+
+	types := gitlab.NewParser([]byte(someToken)).EventTypes()
+
+	seen := map[event.Type]bool{}
+	for _, typ := range types {
+		assert.NotEmpty(t, typ.Title)
+		assert.NotEmpty(t, typ.Description)
+		assert.False(t, seen[typ.Key], "duplicate event type %s", typ.Key)
+		seen[typ.Key] = true
+	}
+
+	assert.Len(t, types, 15)
+}
+
+func requestFor(t *testing.T, hook, fixture string) *http.Request {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", fixture))
+	require.NoError(t, err)
+
+	req := httptest.NewRequestWithContext(t.Context(), "POST", "/gitlab", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitlab-Event", hook)
+	req.Header.Set("X-Gitlab-Event-UUID", "e2f6ae0c-5a1e-4fa6-a5b0-ba1c0f0f2a4d")
+	req.Header.Set("X-Gitlab-Token", someToken)
+
+	return req
+}
@@ -0,0 +1,173 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package gitlab
+
+import (
+	"github.com/seatgeek/mailroom/pkg/identifier"
+)
+
+// User is a GitLab user as it appears in webhook payloads
+type User struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+}
+
+// Identifiers returns the identifiers known for the user
+func (u User) Identifiers() identifier.Set {
+	ids := identifier.NewSet()
+	if u.ID != 0 {
+		ids.Add(identifier.New(ID, u.ID))
+	}
+	if u.Username != "" {
+		ids.Add(identifier.New(Username, u.Username))
+	}
+	// GitLab redacts emails unless the user has made theirs public
+	if u.Email != "" && u.Email != "[REDACTED]" {
+		ids.Add(identifier.New(identifier.GenericEmail, u.Email))
+	}
+
+	return ids
+}
+
+// Project is the GitLab project which an event relates to
+type Project struct {
+	ID                int64  `json:"id"`
+	Name              string `json:"name"`
+	PathWithNamespace string `json:"path_with_namespace"`
+	WebURL            string `json:"web_url"`
+}
+
+// MergeRequest holds the attributes of a merge request
+type MergeRequest struct {
+	ID           int64   `json:"id"`
+	IID          int64   `json:"iid"`
+	Title        string  `json:"title"`
+	Description  string  `json:"description"`
+	State        string  `json:"state"`
+	Action       string  `json:"action"`
+	URL          string  `json:"url"`
+	SourceBranch string  `json:"source_branch"`
+	TargetBranch string  `json:"target_branch"`
+	AuthorID     int64   `json:"author_id"`
+	AssigneeIDs  []int64 `json:"assignee_ids"`
+	ReviewerIDs  []int64 `json:"reviewer_ids"`
+}
+
+// Issue holds the attributes of an issue
+type Issue struct {
+	ID          int64   `json:"id"`
+	IID         int64   `json:"iid"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	State       string  `json:"state"`
+	Action      string  `json:"action"`
+	URL         string  `json:"url"`
+	AuthorID    int64   `json:"author_id"`
+	AssigneeIDs []int64 `json:"assignee_ids"`
+}
+
+// UserChange describes how a list of users (such as reviewers) changed in an update
+type UserChange struct {
+	Previous []User `json:"previous"`
+	Current  []User `json:"current"`
+}
+
+// Added returns the users which are in Current but not Previous
+func (c UserChange) Added() []User {
+	previous := make(map[int64]bool, len(c.Previous))
+	for _, u := range c.Previous {
+		previous[u.ID] = true
+	}
+
+	var added []User
+	for _, u := range c.Current {
+		if !previous[u.ID] {
+			added = append(added, u)
+		}
+	}
+
+	return added
+}
+
+// MergeRequestChanges describes which attributes changed when a merge request was updated
+type MergeRequestChanges struct {
+	Assignees *UserChange `json:"assignees,omitempty"`
+	Reviewers *UserChange `json:"reviewers,omitempty"`
+}
+
+// MergeRequestEvent is the payload of a "Merge Request Hook"
+// See https://docs.gitlab.com/ee/user/project/integrations/webhook_events.html#merge-request-events
+type MergeRequestEvent struct {
+	User             User                `json:"user"`
+	Project          Project             `json:"project"`
+	ObjectAttributes MergeRequest        `json:"object_attributes"`
+	Assignees        []User              `json:"assignees"`
+	Reviewers        []User              `json:"reviewers"`
+	Changes          MergeRequestChanges `json:"changes"`
+}
+
+// Pipeline holds the attributes of a pipeline
+type Pipeline struct {
+	ID     int64  `json:"id"`
+	IID    int64  `json:"iid"`
+	Ref    string `json:"ref"`
+	SHA    string `json:"sha"`
+	Status string `json:"status"`
+	URL    string `json:"url"`
+}
+
+// Commit is the commit which a pipeline ran for
+type Commit struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+}
+
+// PipelineEvent is the payload of a "Pipeline Hook"
+// See https://docs.gitlab.com/ee/user/project/integrations/webhook_events.html#pipeline-events
+type PipelineEvent struct {
+	User             User          `json:"user"`
+	Project          Project       `json:"project"`
+	ObjectAttributes Pipeline      `json:"object_attributes"`
+	MergeRequest     *MergeRequest `json:"merge_request,omitempty"`
+	Commit           *Commit       `json:"commit,omitempty"`
+}
+
+// Note holds the attributes of a comment
+type Note struct {
+	ID           int64  `json:"id"`
+	Note         string `json:"note"`
+	NoteableType string `json:"noteable_type"`
+	URL          string `json:"url"`
+	AuthorID     int64  `json:"author_id"`
+}
+
+// NoteEvent is the payload of a "Note Hook" for a comment on a merge request or issue
+// See https://docs.gitlab.com/ee/user/project/integrations/webhook_events.html#comment-events
+type NoteEvent struct {
+	User             User          `json:"user"`
+	Project          Project       `json:"project"`
+	ObjectAttributes Note          `json:"object_attributes"`
+	MergeRequest     *MergeRequest `json:"merge_request,omitempty"`
+	Issue            *Issue        `json:"issue,omitempty"`
+}
+
+// IssueEvent is the payload of an "Issue Hook"
+// See https://docs.gitlab.com/ee/user/project/integrations/webhook_events.html#issue-events
+type IssueEvent struct {
+	User             User         `json:"user"`
+	Project          Project      `json:"project"`
+	ObjectAttributes Issue        `json:"object_attributes"`
+	Assignees        []User       `json:"assignees"`
+	Changes          IssueChanges `json:"changes"`
+}
+
+// IssueChanges describes which attributes changed when an issue was updated
+type IssueChanges struct {
+	Assignees *UserChange `json:"assignees,omitempty"`
+}
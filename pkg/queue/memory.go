@@ -0,0 +1,91 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package queue
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// InMemoryQueue is a simple in-memory implementation of the Queue interface
+// Messages do not survive restarts, so this is best suited for testing or for applications that don't need durable delivery.
+type InMemoryQueue struct {
+	messages []*inMemoryMessage
+	lease    time.Duration
+	mu       sync.Mutex
+}
+
+type inMemoryMessage struct {
+	msg         *Message
+	leasedUntil time.Time
+}
+
+var _ Queue = &InMemoryQueue{}
+
+// NewInMemoryQueue creates a new in-memory queue
+// Dequeued messages which are not acknowledged within the given lease duration will be redelivered.
+func NewInMemoryQueue(lease time.Duration) *InMemoryQueue {
+	return &InMemoryQueue{lease: lease}
+}
+
+func (q *InMemoryQueue) Enqueue(_ context.Context, msg *Message) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.messages = append(q.messages, &inMemoryMessage{msg: msg})
+	return nil
+}
+
+func (q *InMemoryQueue) Dequeue(_ context.Context) (*Message, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	for _, m := range q.messages {
+		if m.leasedUntil.After(now) {
+			continue
+		}
+
+		m.leasedUntil = now.Add(q.lease)
+		m.msg.Attempts++
+
+		return m.msg, nil
+	}
+
+	return nil, ErrEmpty
+}
+
+func (q *InMemoryQueue) Ack(_ context.Context, msg *Message) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.messages = slices.DeleteFunc(q.messages, func(m *inMemoryMessage) bool {
+		return m.msg.ID == msg.ID
+	})
+	return nil
+}
+
+func (q *InMemoryQueue) Retry(_ context.Context, msg *Message, delay time.Duration) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for _, m := range q.messages {
+		if m.msg.ID == msg.ID {
+			m.msg.Transports = msg.Transports
+			m.leasedUntil = time.Now().Add(delay)
+		}
+	}
+	return nil
+}
+
+// Len returns the number of messages in the queue, including those which are currently leased
+func (q *InMemoryQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.messages)
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package queue_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/queue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryQueue(t *testing.T) {
+	t.Parallel()
+
+	q := queue.NewInMemoryQueue(50 * time.Millisecond)
+
+	_, err := q.Dequeue(t.Context())
+	assert.ErrorIs(t, err, queue.ErrEmpty)
+
+	first := someMessage(t, "first")
+	second := someMessage(t, "second")
+	require.NoError(t, q.Enqueue(t.Context(), first))
+	require.NoError(t, q.Enqueue(t.Context(), second))
+	assert.Equal(t, 2, q.Len())
+
+	// Messages are dequeued in order
+	got, err := q.Dequeue(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, got.ID)
+	assert.Equal(t, uint(1), got.Attempts)
+
+	got, err = q.Dequeue(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, second.ID, got.ID)
+
+	// Both messages are leased, so nothing else is available
+	_, err = q.Dequeue(t.Context())
+	assert.ErrorIs(t, err, queue.ErrEmpty)
+
+	// Acked messages are gone for good
+	require.NoError(t, q.Ack(t.Context(), second))
+	assert.Equal(t, 1, q.Len())
+
+	// Unacked messages are redelivered once their lease expires
+	time.Sleep(60 * time.Millisecond)
+
+	got, err = q.Dequeue(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, got.ID)
+	assert.Equal(t, uint(2), got.Attempts)
+
+	// Retried messages are redelivered after the given delay rather than when their lease expires
+	got.Transports = []event.TransportKey{"email"}
+	require.NoError(t, q.Retry(t.Context(), got, 10*time.Millisecond))
+	_, err = q.Dequeue(t.Context())
+	assert.ErrorIs(t, err, queue.ErrEmpty)
+
+	time.Sleep(20 * time.Millisecond)
+
+	got, err = q.Dequeue(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, got.ID)
+	assert.Equal(t, uint(3), got.Attempts)
+	assert.Equal(t, []event.TransportKey{"email"}, got.Transports)
+}
+
+func someMessage(t *testing.T, id event.ID) *queue.Message {
+	t.Helper()
+
+	msg, err := queue.NewMessage(
+		notification.NewBuilder(event.Context{ID: id, Type: "com.example.test"}).
+			WithRecipientIdentifiers(identifier.New(identifier.GenericUsername, "codell")).
+			WithDefaultMessage("hello "+string(id)).
+			Build(),
+		[]event.TransportKey{"email"},
+	)
+	require.NoError(t, err)
+
+	return msg
+}
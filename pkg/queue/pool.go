@@ -0,0 +1,276 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/seatgeek/mailroom/pkg/deadletter"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+)
+
+// Pool is a group of workers which drain a Queue, handing each message to a notifier.Notifier for delivery
+type Pool struct {
+	queue        Queue
+	notifier     notifier.Notifier
+	workers      int
+	pollInterval time.Duration
+	maxAttempts  uint
+	retryDelay   time.Duration
+	deadLetters  deadletter.Store
+}
+
+// Option configures a Pool
+type Option func(*Pool)
+
+// WithWorkers sets the number of concurrent workers (default 4)
+func WithWorkers(n int) Option {
+	return func(p *Pool) {
+		p.workers = n
+	}
+}
+
+// WithPollInterval sets how long an idle worker waits before checking the queue again (default 1s)
+func WithPollInterval(d time.Duration) Option {
+	return func(p *Pool) {
+		p.pollInterval = d
+	}
+}
+
+// WithMaxAttempts sets how many times delivery of a message may be attempted before giving up on it (default 5)
+func WithMaxAttempts(n uint) Option {
+	return func(p *Pool) {
+		p.maxAttempts = n
+	}
+}
+
+// WithRetryDelay sets how long to wait before retrying a failed delivery, doubling after each attempt (default 30s)
+func WithRetryDelay(d time.Duration) Option {
+	return func(p *Pool) {
+		p.retryDelay = d
+	}
+}
+
+// WithDeadLetters writes notifications which still couldn't be delivered after the last attempt to the given
+// deadletter.Store. Use this instead of deadletter.WithDeadLetters, which would capture every failed attempt.
+func WithDeadLetters(store deadletter.Store) Option {
+	return func(p *Pool) {
+		p.deadLetters = store
+	}
+}
+
+// NewPool creates a new Pool which delivers messages from the given Queue using the given Notifier
+func NewPool(q Queue, n notifier.Notifier, opts ...Option) *Pool {
+	p := &Pool{
+		queue:        q,
+		notifier:     n,
+		workers:      4,
+		pollInterval: time.Second,
+		maxAttempts:  5,
+		retryDelay:   30 * time.Second,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Run starts the workers and blocks until the given context is canceled and all in-flight deliveries have finished
+func (p *Pool) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := range p.workers {
+		wg.Go(func() {
+			p.work(ctx, slog.With("worker", i))
+		})
+	}
+
+	wg.Wait()
+}
+
+func (p *Pool) work(ctx context.Context, logger *slog.Logger) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if p.processNext(ctx, logger) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(p.pollInterval):
+		}
+	}
+}
+
+// processNext delivers the next available message, returning false if there was nothing to do
+func (p *Pool) processNext(ctx context.Context, logger *slog.Logger) bool {
+	msg, err := p.queue.Dequeue(ctx)
+	if errors.Is(err, ErrEmpty) {
+		return false
+	}
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to dequeue message", "error", err)
+		return false
+	}
+
+	logger = logger.With("message_id", msg.ID, "id", msg.Envelope.Context.ID, "attempt", msg.Attempts)
+
+	// In-flight deliveries should be allowed to finish even if we're shutting down
+	ctx = context.WithoutCancel(ctx)
+
+	n := msg.Notification()
+	if msg.Attempts > p.maxAttempts {
+		// A previous worker must have crashed mid-delivery, since it would have given up on the last attempt
+		err = fmt.Errorf("exceeded %d delivery attempts", p.maxAttempts)
+		p.giveUp(ctx, logger, n, failuresFor(msg, err))
+	} else if err = p.push(ctx, msg, n); err != nil {
+		p.handleFailure(ctx, logger, msg, n, err)
+		return true
+	}
+
+	if err = p.queue.Ack(ctx, msg); err != nil {
+		logger.ErrorContext(ctx, "failed to acknowledge message", "error", err)
+	}
+
+	return true
+}
+
+func (p *Pool) push(ctx context.Context, msg *Message, n event.Notification) error {
+	if len(msg.Transports) > 0 {
+		ctx = notifier.WithOnlyTransports(ctx, msg.Transports...)
+	}
+
+	return p.notifier.Push(ctx, n)
+}
+
+// handleFailure retries the transports which failed, unless they failed permanently or there are no attempts left
+func (p *Pool) handleFailure(ctx context.Context, logger *slog.Logger, msg *Message, n event.Notification, err error) {
+	failures := failuresFor(msg, err)
+
+	var retryable, permanent []*notifier.TransportError
+	for _, f := range failures {
+		if isPermanent(f) || msg.Attempts >= p.maxAttempts {
+			permanent = append(permanent, f)
+		} else {
+			retryable = append(retryable, f)
+		}
+	}
+
+	p.giveUp(ctx, logger, n, permanent)
+
+	if len(retryable) == 0 {
+		if err = p.queue.Ack(ctx, msg); err != nil {
+			logger.ErrorContext(ctx, "failed to acknowledge message", "error", err)
+		}
+		return
+	}
+
+	// Only retry the transports which failed, so that the others don't send the notification twice
+	msg.Transports = nil
+	for _, f := range retryable {
+		if f.Transport != "" {
+			msg.Transports = append(msg.Transports, f.Transport)
+		}
+	}
+	if len(msg.Transports) < len(retryable) {
+		msg.Transports = nil // Some failures couldn't be attributed to a transport, so retry all of them
+	}
+
+	delay := p.retryDelayFor(msg.Attempts, retryable)
+	logger.WarnContext(ctx, "failed to deliver queued notification; will retry", "error", err, "transports", msg.Transports, "retry_in", delay)
+
+	if err = p.queue.Retry(ctx, msg, delay); err != nil {
+		logger.ErrorContext(ctx, "failed to schedule retry; message will be redelivered once its lease expires", "error", err)
+	}
+}
+
+// giveUp writes the given failures to the dead letter store, if there is one
+func (p *Pool) giveUp(ctx context.Context, logger *slog.Logger, n event.Notification, failures []*notifier.TransportError) {
+	for _, f := range failures {
+		if p.deadLetters == nil || f.Transport == "" {
+			logger.ErrorContext(ctx, "giving up on queued notification", "transport", f.Transport, "error", f.Err)
+			continue
+		}
+
+		letter, err := deadletter.NewLetter(n, f.Transport, f.Err)
+		if err != nil {
+			logger.ErrorContext(ctx, "failed to create dead letter", "transport", f.Transport, "error", err)
+		} else if err = p.deadLetters.Add(ctx, letter); err != nil {
+			logger.ErrorContext(ctx, "failed to store dead letter", "transport", f.Transport, "error", err)
+		} else {
+			logger.WarnContext(ctx, "notification moved to dead letter store", "transport", f.Transport, "dead_letter_id", letter.ID)
+		}
+	}
+}
+
+// retryDelayFor returns how long to wait before retrying the given failures
+// Failures which say when to retry (see notifier.RetryAfter) are retried then, rather than backing off.
+func (p *Pool) retryDelayFor(attempts uint, failures []*notifier.TransportError) time.Duration {
+	backoffDelay := p.retryDelay << min(attempts-1, 10)
+
+	var delay time.Duration
+	for _, f := range failures {
+		var retryAfter *backoff.RetryAfterError
+		if errors.As(f, &retryAfter) {
+			delay = max(delay, retryAfter.Duration)
+		} else {
+			delay = max(delay, backoffDelay)
+		}
+	}
+
+	return delay
+}
+
+// failuresFor splits err into the failure of each transport
+// Errors which don't say which transport failed are attributed to each of the message's transports.
+func failuresFor(msg *Message, err error) []*notifier.TransportError {
+	errs := []error{err}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	}
+
+	var failures []*notifier.TransportError
+	var others []error
+	for _, e := range errs {
+		var transportErr *notifier.TransportError
+		if errors.As(e, &transportErr) {
+			failures = append(failures, transportErr)
+		} else {
+			others = append(others, e)
+		}
+	}
+
+	if len(others) == 0 {
+		return failures
+	}
+
+	err = errors.Join(others...)
+	if len(msg.Transports) == 0 {
+		return append(failures, &notifier.TransportError{Notification: msg.Envelope.Context.ID, Err: err})
+	}
+
+	for _, key := range msg.Transports {
+		failures = append(failures, &notifier.TransportError{Transport: key, Notification: msg.Envelope.Context.ID, Err: err})
+	}
+
+	return failures
+}
+
+func isPermanent(err error) bool {
+	var permanent *backoff.PermanentError
+	return errors.As(err, &permanent)
+}
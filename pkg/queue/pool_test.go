@@ -0,0 +1,173 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package queue_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/deadletter"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/seatgeek/mailroom/pkg/notifier/preference"
+	"github.com/seatgeek/mailroom/pkg/queue"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPool_Run(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		pushErr         error
+		attempts        uint
+		wantPushed      int
+		wantQueued      int
+		wantDeadLetters int
+	}{
+		{
+			name:       "delivers messages",
+			wantPushed: 3,
+		},
+		{
+			name:            "retries failed deliveries until they run out of attempts",
+			pushErr:         errors.New("some error"),
+			wantPushed:      9,
+			wantDeadLetters: 3,
+		},
+		{
+			name:            "doesn't retry permanent failures",
+			pushErr:         notifier.Permanent(errors.New("some error")),
+			wantPushed:      3,
+			wantDeadLetters: 3,
+		},
+		{
+			name:       "waits as long as failures ask before retrying",
+			pushErr:    notifier.RetryAfter(time.Hour),
+			wantPushed: 3,
+			wantQueued: 3,
+		},
+		{
+			name:            "gives up on messages exceeding max attempts",
+			attempts:        10,
+			wantPushed:      0,
+			wantDeadLetters: 3,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			q := queue.NewInMemoryQueue(time.Minute)
+			for _, id := range []event.ID{"one", "two", "three"} {
+				msg := someMessage(t, id)
+				msg.Attempts = tc.attempts
+				require.NoError(t, q.Enqueue(t.Context(), msg))
+			}
+
+			var mu sync.Mutex
+			var pushed []string
+			ntfr := notifier.NewNotifier(func(_ context.Context, n event.Notification) error {
+				mu.Lock()
+				defer mu.Unlock()
+				pushed = append(pushed, n.Render("email"))
+				return tc.pushErr
+			})
+
+			deadLetters := deadletter.NewInMemoryStore()
+
+			ctx, cancel := context.WithTimeout(t.Context(), 200*time.Millisecond)
+			defer cancel()
+
+			queue.NewPool(q, ntfr,
+				queue.WithWorkers(2),
+				queue.WithPollInterval(10*time.Millisecond),
+				queue.WithMaxAttempts(3),
+				queue.WithRetryDelay(time.Millisecond),
+				queue.WithDeadLetters(deadLetters),
+			).Run(ctx)
+
+			assert.Len(t, pushed, tc.wantPushed)
+			assert.Equal(t, tc.wantQueued, q.Len())
+
+			page, err := deadLetters.List(t.Context(), deadletter.ListOptions{Limit: deadletter.MaxLimit})
+			require.NoError(t, err)
+			assert.Len(t, page.DeadLetters, tc.wantDeadLetters)
+			for _, letter := range page.DeadLetters {
+				assert.Equal(t, event.TransportKey("email"), letter.Transport)
+			}
+		})
+	}
+}
+
+func TestPool_Run_RetriesFailedTransports(t *testing.T) {
+	t.Parallel()
+
+	q := queue.NewInMemoryQueue(time.Minute)
+	msg, err := queue.NewMessage(someMessage(t, "one").Notification(), []event.TransportKey{"email", "sms"})
+	require.NoError(t, err)
+	require.NoError(t, q.Enqueue(t.Context(), msg))
+
+	var mu sync.Mutex
+	pushed := map[event.TransportKey]int{}
+	transport := func(key event.TransportKey, fails int) notifier.Transport {
+		return notifier.NewTransport(key, func(context.Context, event.Notification) error {
+			mu.Lock()
+			defer mu.Unlock()
+			pushed[key]++
+			if pushed[key] <= fails {
+				return errors.New("some error")
+			}
+			return nil
+		})
+	}
+
+	ntfr := notifier.New([]notifier.Transport{transport("email", 0), transport("sms", 1)}, preference.Default(true))
+
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+
+	queue.NewPool(q, ntfr, queue.WithPollInterval(10*time.Millisecond), queue.WithRetryDelay(time.Millisecond)).Run(ctx)
+
+	// The notification isn't sent twice via the transport which succeeded the first time
+	assert.Equal(t, map[event.TransportKey]int{"email": 1, "sms": 2}, pushed)
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestNotifier_Push(t *testing.T) {
+	t.Parallel()
+
+	q := queue.NewInMemoryQueue(time.Minute)
+	n := someMessage(t, "one").Notification()
+
+	err := queue.NewNotifier(q, []event.TransportKey{"email"}).Push(t.Context(), n)
+	require.NoError(t, err)
+
+	got, err := q.Dequeue(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, n.Context().ID, got.Notification().Context().ID)
+	assert.Equal(t, "hello one", got.Notification().Render("email"))
+}
+
+func TestNotifier_Push_Unserializable(t *testing.T) {
+	t.Parallel()
+
+	q := queue.NewInMemoryQueue(time.Minute)
+	n := notification.NewBuilder(event.Context{ID: "one"}).
+		WithDefaultMessage("hello one").
+		WithSlackOptions(slack.MsgOptionText("hello one", false)).
+		Build()
+
+	err := queue.NewNotifier(q, []event.TransportKey{"slack"}).Push(t.Context(), n)
+	assert.ErrorIs(t, err, notification.ErrUnserializable)
+	assert.Equal(t, 0, q.Len())
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package postgres provides a postgresql-backed implementation of the queue.Queue interface
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/queue"
+	"github.com/seatgeek/mailroom/pkg/validation"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MessageModel is the gorm model for a queued message
+type MessageModel struct {
+	ID          string                `gorm:"primarykey"`
+	Envelope    notification.Envelope `gorm:"serializer:json"`
+	Attempts    uint
+	EnqueuedAt  time.Time
+	LeasedUntil time.Time            `gorm:"index"`
+	Transports  []event.TransportKey `gorm:"serializer:json"`
+}
+
+func (m *MessageModel) TableName() string {
+	return "queued_notifications"
+}
+
+// ToMessage converts a MessageModel to a queue.Message
+func (m *MessageModel) ToMessage() *queue.Message {
+	return &queue.Message{
+		ID:         m.ID,
+		Envelope:   m.Envelope,
+		Attempts:   m.Attempts,
+		EnqueuedAt: m.EnqueuedAt,
+		Transports: m.Transports,
+	}
+}
+
+type Queue struct {
+	db    *gorm.DB
+	lease time.Duration
+}
+
+var (
+	_ queue.Queue          = &Queue{}
+	_ validation.Validator = &Queue{}
+)
+
+// NewPostgresQueue creates a new postgres-backed queue
+// Dequeued messages which are not acknowledged within the given lease duration will be redelivered.
+func NewPostgresQueue(db *gorm.DB, lease time.Duration) *Queue {
+	return &Queue{db: db, lease: lease}
+}
+
+// Enqueue implements queue.Queue.
+func (q *Queue) Enqueue(ctx context.Context, msg *queue.Message) error {
+	return q.db.WithContext(ctx).Create(&MessageModel{
+		ID:          msg.ID,
+		Envelope:    msg.Envelope,
+		Attempts:    msg.Attempts,
+		EnqueuedAt:  msg.EnqueuedAt,
+		LeasedUntil: msg.EnqueuedAt,
+		Transports:  msg.Transports,
+	}).Error
+}
+
+// Dequeue implements queue.Queue.
+// Rows are locked with SKIP LOCKED so that multiple replicas can safely drain the same queue.
+func (q *Queue) Dequeue(ctx context.Context) (*queue.Message, error) {
+	var m MessageModel
+
+	err := q.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("leased_until <= ?", now).
+			Order("enqueued_at").
+			First(&m).Error
+		if err != nil {
+			return err
+		}
+
+		m.Attempts++
+		m.LeasedUntil = now.Add(q.lease)
+
+		return tx.Model(&m).Updates(map[string]any{
+			"attempts":     m.Attempts,
+			"leased_until": m.LeasedUntil,
+		}).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, queue.ErrEmpty
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return m.ToMessage(), nil
+}
+
+// Ack implements queue.Queue.
+func (q *Queue) Ack(ctx context.Context, msg *queue.Message) error {
+	return q.db.WithContext(ctx).Delete(&MessageModel{ID: msg.ID}).Error
+}
+
+// Retry implements queue.Queue.
+func (q *Queue) Retry(ctx context.Context, msg *queue.Message, delay time.Duration) error {
+	return q.db.WithContext(ctx).Model(&MessageModel{ID: msg.ID}).Select("leased_until", "transports").Updates(&MessageModel{
+		LeasedUntil: time.Now().Add(delay),
+		Transports:  msg.Transports,
+	}).Error
+}
+
+// Validate checks that the queue table exists
+func (q *Queue) Validate(ctx context.Context) error {
+	if !q.db.WithContext(ctx).Migrator().HasTable(&MessageModel{}) {
+		return fmt.Errorf("table %q does not exist", (&MessageModel{}).TableName())
+	}
+
+	return nil
+}
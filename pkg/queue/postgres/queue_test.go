@@ -0,0 +1,128 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/queue"
+	"github.com/seatgeek/mailroom/pkg/queue/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	pgtc "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	pg "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestPostgresQueue(t *testing.T) {
+	t.Parallel()
+
+	q := createQueue(t, 500*time.Millisecond)
+
+	assert.NoError(t, q.Validate(t.Context()))
+
+	_, err := q.Dequeue(t.Context())
+	assert.ErrorIs(t, err, queue.ErrEmpty)
+
+	first := someMessage(t, "first")
+	second := someMessage(t, "second")
+	second.EnqueuedAt = first.EnqueuedAt.Add(time.Millisecond)
+	assert.NoError(t, q.Enqueue(t.Context(), first))
+	assert.NoError(t, q.Enqueue(t.Context(), second))
+
+	// Messages are dequeued in order
+	got, err := q.Dequeue(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, got.ID)
+	assert.Equal(t, uint(1), got.Attempts)
+	assert.Equal(t, first.Envelope, got.Envelope)
+
+	got, err = q.Dequeue(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, second.ID, got.ID)
+
+	// Both messages are leased, so nothing else is available
+	_, err = q.Dequeue(t.Context())
+	assert.ErrorIs(t, err, queue.ErrEmpty)
+
+	// Acked messages are gone for good; unacked messages are redelivered once their lease expires
+	assert.NoError(t, q.Ack(t.Context(), second))
+	time.Sleep(600 * time.Millisecond)
+
+	got, err = q.Dequeue(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, got.ID)
+	assert.Equal(t, uint(2), got.Attempts)
+
+	_, err = q.Dequeue(t.Context())
+	assert.ErrorIs(t, err, queue.ErrEmpty)
+
+	// Retried messages are redelivered after the given delay, only to the given transports
+	got.Transports = []event.TransportKey{"email"}
+	assert.NoError(t, q.Retry(t.Context(), got, 50*time.Millisecond))
+	time.Sleep(100 * time.Millisecond)
+
+	got, err = q.Dequeue(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, got.ID)
+	assert.Equal(t, uint(3), got.Attempts)
+	assert.Equal(t, []event.TransportKey{"email"}, got.Transports)
+}
+
+func someMessage(t *testing.T, id event.ID) *queue.Message {
+	t.Helper()
+
+	msg, err := queue.NewMessage(
+		notification.NewBuilder(event.Context{
+			ID:     id,
+			Source: event.MustSource("https://example.com"),
+			Type:   "com.example.test",
+			Time:   time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		}).
+			WithRecipientIdentifiers(identifier.New(identifier.GenericUsername, "codell")).
+			WithDefaultMessage("hello").
+			WithMessageForTransport("email", "hello via email").
+			Build(),
+		[]event.TransportKey{"email", "slack"},
+	)
+	require.NoError(t, err)
+
+	return msg
+}
+
+func createQueue(t *testing.T, lease time.Duration) *postgres.Queue {
+	t.Helper()
+
+	ctx := context.Background()
+
+	container, err := pgtc.Run(ctx, "postgres:16.2",
+		pgtc.WithInitScripts("../../../test/initdb/init.sql"),
+		pgtc.WithDatabase("mailroom"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second)),
+	)
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, container.Terminate(ctx))
+	})
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable", "application_name=test")
+	assert.NoError(t, err)
+
+	db, err := gorm.Open(pg.Open(dsn), &gorm.Config{})
+	assert.NoError(t, err)
+
+	return postgres.NewPostgresQueue(db, lease)
+}
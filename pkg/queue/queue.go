@@ -0,0 +1,96 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package queue provides durable, asynchronous delivery of notifications
+package queue
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+)
+
+// ErrEmpty is returned by Queue.Dequeue when no messages are currently available
+var ErrEmpty = errors.New("queue is empty")
+
+// Message is a single notification waiting to be delivered
+type Message struct {
+	ID       string                `json:"id"`
+	Envelope notification.Envelope `json:"envelope"`
+	// Attempts is the number of times this message has been dequeued
+	Attempts   uint      `json:"attempts"`
+	EnqueuedAt time.Time `json:"enqueued_at"`
+	// Transports are those the message is still to be delivered via; when it's retried, only those which failed remain
+	Transports []event.TransportKey `json:"transports,omitempty"`
+}
+
+// NewMessage creates a new Message for the given notification, pre-rendering it for each of the given transports
+// Notifications which can't be serialized return notification.ErrUnserializable rather than losing data.
+func NewMessage(n event.Notification, transports []event.TransportKey) (*Message, error) {
+	env, err := notification.Seal(n, transports)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		ID:         uuid.New().String(),
+		Envelope:   env,
+		EnqueuedAt: time.Now(),
+		Transports: slices.Clone(transports),
+	}, nil
+}
+
+// Notification returns the notification carried by the message
+func (m *Message) Notification() event.Notification {
+	return m.Envelope.Open()
+}
+
+// Queue stores messages until a worker is ready to deliver them.
+//
+// Dequeued messages are leased to the caller: they will not be returned by Dequeue again until the lease
+// expires, at which point they become available for redelivery. Callers MUST Ack a message once they are
+// done with it, otherwise it will eventually be delivered again (which is what lets deliveries survive restarts),
+// or Retry it to have it delivered again sooner or later than that.
+type Queue interface {
+	// Enqueue durably stores a message for later delivery
+	Enqueue(ctx context.Context, msg *Message) error
+	// Dequeue leases the next available message, or returns ErrEmpty if there is none
+	Dequeue(ctx context.Context) (*Message, error)
+	// Ack permanently removes a previously dequeued message from the queue
+	Ack(ctx context.Context, msg *Message) error
+	// Retry makes a previously dequeued message available again after the given delay, saving its Transports
+	Retry(ctx context.Context, msg *Message, delay time.Duration) error
+}
+
+// Notifier is a notifier.Notifier which enqueues notifications instead of sending them
+type Notifier struct {
+	queue      Queue
+	transports []event.TransportKey
+}
+
+var _ notifier.Notifier = &Notifier{}
+
+// NewNotifier creates a Notifier which enqueues notifications onto the given Queue.
+// Notifications are rendered for each of the given transports at the time they are enqueued.
+func NewNotifier(q Queue, transports []event.TransportKey) *Notifier {
+	return &Notifier{
+		queue:      q,
+		transports: transports,
+	}
+}
+
+func (n *Notifier) Push(ctx context.Context, notification event.Notification) error {
+	msg, err := NewMessage(notification, n.transports)
+	if err != nil {
+		return err
+	}
+
+	return n.queue.Enqueue(ctx, msg)
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// InMemoryStore is a simple in-memory implementation of the Store interface
+// Each replica keeps its own buckets, so use the postgres store to share limits between several replicas.
+type InMemoryStore struct {
+	buckets    map[string]*Bucket
+	suppressed map[string]int
+	mu         sync.Mutex
+}
+
+var _ Store = &InMemoryStore{}
+
+// NewInMemoryStore creates a new in-memory store
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{
+		buckets:    make(map[string]*Bucket),
+		suppressed: make(map[string]int),
+	}
+}
+
+func (s *InMemoryStore) Allow(_ context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	bucket, ok := s.buckets[key]
+	if !ok {
+		b := NewBucket(limit, now)
+		bucket = &b
+		s.buckets[key] = bucket
+	}
+
+	allowed, retryAfter := bucket.Take(limit, now)
+	return allowed, retryAfter, nil
+}
+
+func (s *InMemoryStore) Suppress(_ context.Context, key string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.suppressed[key]++
+	return s.suppressed[key], nil
+}
+
+func (s *InMemoryStore) TakeSuppressed(_ context.Context, key string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := s.suppressed[key]
+	delete(s.suppressed, key)
+	return count, nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package ratelimit_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	store := ratelimit.NewInMemoryStore()
+	limit := ratelimit.PerHour(1)
+
+	ok, _, err := store.Allow(t.Context(), "a", limit)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, retryAfter, err := store.Allow(t.Context(), "a", limit)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.InDelta(t, time.Hour, retryAfter, float64(time.Second))
+
+	// Each key has its own bucket
+	ok, _, err = store.Allow(t.Context(), "b", limit)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// Suppressed notifications are counted until they're taken
+	for want := 1; want <= 2; want++ {
+		count, err := store.Suppress(t.Context(), "a")
+		require.NoError(t, err)
+		assert.Equal(t, want, count)
+	}
+
+	count, err := store.TakeSuppressed(t.Context(), "a")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = store.TakeSuppressed(t.Context(), "a")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
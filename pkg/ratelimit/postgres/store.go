@@ -0,0 +1,127 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package postgres provides a postgresql-backed implementation of the ratelimit.Store interface
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/ratelimit"
+	"github.com/seatgeek/mailroom/pkg/validation"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BucketModel is the gorm model for a rate limit's token bucket
+type BucketModel struct {
+	Key        string `gorm:"primarykey"`
+	Tokens     float64
+	UpdatedAt  time.Time `gorm:"autoUpdateTime:false"`
+	Suppressed int
+}
+
+func (m *BucketModel) TableName() string {
+	return "rate_limits"
+}
+
+type Store struct {
+	db *gorm.DB
+}
+
+var (
+	_ ratelimit.Store      = &Store{}
+	_ validation.Validator = &Store{}
+)
+
+// NewPostgresStore creates a new postgres-backed rate limit store, which can be shared by several replicas
+func NewPostgresStore(db *gorm.DB) *Store {
+	return &Store{db: db}
+}
+
+// Allow implements ratelimit.Store.
+// Each bucket is locked while it's updated, so that replicas can't take the same token.
+func (s *Store) Allow(ctx context.Context, key string, limit ratelimit.Limit) (bool, time.Duration, error) {
+	var (
+		allowed    bool
+		retryAfter time.Duration
+	)
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+
+		// Make sure there's a (full) bucket to lock
+		bucket := ratelimit.NewBucket(limit, now)
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&BucketModel{Key: key, Tokens: bucket.Tokens, UpdatedAt: bucket.UpdatedAt}).Error
+		if err != nil {
+			return err
+		}
+
+		var m BucketModel
+		if err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("key = ?", key).First(&m).Error; err != nil {
+			return err
+		}
+
+		bucket = ratelimit.Bucket{Tokens: m.Tokens, UpdatedAt: m.UpdatedAt}
+		allowed, retryAfter = bucket.Take(limit, now)
+
+		return tx.Model(&m).Updates(map[string]any{
+			"tokens":     bucket.Tokens,
+			"updated_at": bucket.UpdatedAt,
+		}).Error
+	})
+
+	return allowed, retryAfter, err
+}
+
+// Suppress implements ratelimit.Store.
+func (s *Store) Suppress(ctx context.Context, key string) (int, error) {
+	var m BucketModel
+	err := s.db.WithContext(ctx).Model(&m).Clauses(clause.Returning{Columns: []clause.Column{{Name: "suppressed"}}}).
+		Where("key = ?", key).
+		Update("suppressed", gorm.Expr("suppressed + 1")).Error
+
+	return m.Suppressed, err
+}
+
+// TakeSuppressed implements ratelimit.Store.
+func (s *Store) TakeSuppressed(ctx context.Context, key string) (int, error) {
+	var count int
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var m BucketModel
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("key = ?", key).First(&m).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil // Nothing's been suppressed
+		}
+		if err != nil || m.Suppressed == 0 {
+			return err
+		}
+
+		count = m.Suppressed
+		return tx.Model(&m).Update("suppressed", 0).Error
+	})
+
+	return count, err
+}
+
+// Prune deletes buckets which haven't been used since the given time
+// Buckets which are old enough to be full again are the same as having no bucket, so this can be called periodically.
+// Any notifications suppressed for those recipients are forgotten, as a summary of them would be long out of date.
+func (s *Store) Prune(ctx context.Context, before time.Time) error {
+	return s.db.WithContext(ctx).Where("updated_at < ?", before).Delete(&BucketModel{}).Error
+}
+
+// Validate checks that the rate limits table exists
+func (s *Store) Validate(ctx context.Context) error {
+	if !s.db.WithContext(ctx).Migrator().HasTable(&BucketModel{}) {
+		return fmt.Errorf("table %q does not exist", (&BucketModel{}).TableName())
+	}
+
+	return nil
+}
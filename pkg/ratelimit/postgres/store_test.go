@@ -0,0 +1,107 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/ratelimit"
+	"github.com/seatgeek/mailroom/pkg/ratelimit/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/testcontainers/testcontainers-go"
+	pgtc "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	pg "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestPostgresStore(t *testing.T) {
+	t.Parallel()
+
+	store := createStore(t)
+
+	assert.NoError(t, store.Validate(t.Context()))
+
+	limit := ratelimit.Limit{Count: 2, Per: time.Hour}
+
+	// The bucket starts full
+	for range 2 {
+		ok, _, err := store.Allow(t.Context(), "a", limit)
+		assert.NoError(t, err)
+		assert.True(t, ok)
+	}
+
+	ok, retryAfter, err := store.Allow(t.Context(), "a", limit)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	assert.InDelta(t, 30*time.Minute, retryAfter, float64(time.Second))
+
+	// Each key has its own bucket
+	ok, _, err = store.Allow(t.Context(), "b", limit)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// Suppressed notifications are counted until they're taken
+	for want := 1; want <= 2; want++ {
+		count, err := store.Suppress(t.Context(), "a")
+		assert.NoError(t, err)
+		assert.Equal(t, want, count)
+	}
+
+	count, err := store.TakeSuppressed(t.Context(), "a")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = store.TakeSuppressed(t.Context(), "a")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	count, err = store.TakeSuppressed(t.Context(), "unknown")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	// Pruned buckets start full again, forgetting any suppressed notifications
+	_, err = store.Suppress(t.Context(), "a")
+	assert.NoError(t, err)
+	assert.NoError(t, store.Prune(t.Context(), time.Now().Add(time.Minute)))
+
+	ok, _, err = store.Allow(t.Context(), "a", limit)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	count, err = store.TakeSuppressed(t.Context(), "a")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func createStore(t *testing.T) *postgres.Store {
+	t.Helper()
+
+	ctx := context.Background()
+
+	container, err := pgtc.Run(ctx, "postgres:16.2",
+		pgtc.WithInitScripts("../../../test/initdb/init.sql"),
+		pgtc.WithDatabase("mailroom"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(5*time.Second)),
+	)
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		assert.NoError(t, container.Terminate(ctx))
+	})
+
+	dsn, err := container.ConnectionString(ctx, "sslmode=disable", "application_name=test")
+	assert.NoError(t, err)
+
+	db, err := gorm.Open(pg.Open(dsn), &gorm.Config{})
+	assert.NoError(t, err)
+
+	return postgres.NewPostgresStore(db)
+}
@@ -0,0 +1,293 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package ratelimit limits how many notifications each recipient receives via each transport
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/seatgeek/mailroom/pkg/validation"
+)
+
+// Limit allows a burst of Count notifications, refilling at a rate of Count every Per
+type Limit struct {
+	Count int
+	Per   time.Duration
+}
+
+// PerMinute allows n notifications per minute
+func PerMinute(n int) Limit {
+	return Limit{Count: n, Per: time.Minute}
+}
+
+// PerHour allows n notifications per hour
+func PerHour(n int) Limit {
+	return Limit{Count: n, Per: time.Hour}
+}
+
+func (l Limit) enabled() bool {
+	return l.Count > 0 && l.Per > 0
+}
+
+// Bucket is the state of a token bucket, for use by Store implementations
+type Bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// NewBucket returns a full bucket for the given limit
+func NewBucket(limit Limit, now time.Time) Bucket {
+	return Bucket{Tokens: float64(limit.Count), UpdatedAt: now}
+}
+
+// Take refills the bucket for the time since it was last updated, then takes a token if there is one
+// If there isn't, it returns how long until there will be.
+func (b *Bucket) Take(limit Limit, now time.Time) (ok bool, retryAfter time.Duration) {
+	perToken := limit.Per / time.Duration(limit.Count)
+
+	if elapsed := now.Sub(b.UpdatedAt); elapsed > 0 {
+		b.Tokens = min(float64(limit.Count), b.Tokens+float64(elapsed)/float64(perToken))
+	}
+	b.UpdatedAt = now
+
+	if b.Tokens >= 1 {
+		b.Tokens--
+		return true, 0
+	}
+
+	return false, time.Duration((1 - b.Tokens) * float64(perToken))
+}
+
+// Store keeps the state of each token bucket, along with how many notifications have been suppressed
+type Store interface {
+	// Allow takes a token from the key's bucket if there is one, otherwise returning how long until there will be
+	Allow(ctx context.Context, key string, limit Limit) (ok bool, retryAfter time.Duration, err error)
+	// Suppress counts a notification which was suppressed for the key, returning how many have been suppressed so far
+	Suppress(ctx context.Context, key string) (int, error)
+	// TakeSuppressed returns how many notifications have been suppressed for the key, resetting it to zero
+	TakeSuppressed(ctx context.Context, key string) (int, error)
+}
+
+// Policy decides what happens to notifications which exceed the limit
+type Policy int
+
+const (
+	// Drop discards notifications which exceed the limit
+	Drop Policy = iota
+	// Defer fails with a notifier.RetryAfter error saying when the recipient will be allowed another notification
+	// Use it with mailroom.WithQueue, which retries the notification then; otherwise, it fails like any other error.
+	Defer
+	// Collapse discards notifications which exceed the limit, and sends a "N more notifications suppressed"
+	// notification as soon as the recipient is allowed another one
+	Collapse
+)
+
+func (p Policy) String() string {
+	switch p {
+	case Drop:
+		return "drop"
+	case Defer:
+		return "defer"
+	case Collapse:
+		return "collapse"
+	default:
+		return fmt.Sprintf("Policy(%d)", int(p))
+	}
+}
+
+// Option configures WithRateLimit
+type Option func(*withRateLimit)
+
+// WithLimit sets the limit for event types which don't have their own (see WithEventTypeLimit)
+// Without it, only event types with their own limit are limited.
+func WithLimit(limit Limit) Option {
+	return func(w *withRateLimit) {
+		w.limit = limit
+	}
+}
+
+// WithEventTypeLimit sets the limit for an event type, which is counted separately from other event types
+func WithEventTypeLimit(eventType event.Type, limit Limit) Option {
+	return func(w *withRateLimit) {
+		w.eventTypeLimits[eventType] = limit
+	}
+}
+
+// WithPolicy sets what happens to notifications which exceed the limit (default Drop)
+func WithPolicy(policy Policy) Option {
+	return func(w *withRateLimit) {
+		w.policy = policy
+	}
+}
+
+// WithRateLimit decorates the given Transport so that each recipient can only receive so many notifications via it
+// Notifications without a recipient aren't limited. If the Store fails, notifications are sent anyway.
+func WithRateLimit(transport notifier.Transport, store Store, opts ...Option) notifier.Transport {
+	w := &withRateLimit{
+		Transport:       transport,
+		store:           store,
+		eventTypeLimits: make(map[event.Type]Limit),
+		summaries:       make(map[string]*time.Timer),
+	}
+
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	return w
+}
+
+type withRateLimit struct {
+	notifier.Transport
+	store           Store
+	limit           Limit
+	eventTypeLimits map[event.Type]Limit
+	policy          Policy
+
+	// summaries are waiting for the bucket of each key to refill
+	summaries map[string]*time.Timer
+	mu        sync.Mutex
+}
+
+// limitFor returns the notification's limit and the key of its bucket, or false if it isn't limited
+func (w *withRateLimit) limitFor(n event.Notification) (Limit, string, bool) {
+	if n.Recipient() == nil || n.Recipient().Len() == 0 {
+		return Limit{}, "", false
+	}
+
+	key := string(w.Key()) + " " + n.Recipient().String()
+	if limit, ok := w.eventTypeLimits[n.Context().Type]; ok {
+		return limit, key + " " + string(n.Context().Type), limit.enabled()
+	}
+
+	return w.limit, key, w.limit.enabled()
+}
+
+func (w *withRateLimit) Push(ctx context.Context, n event.Notification) error {
+	limit, key, limited := w.limitFor(n)
+	if !limited {
+		return w.Transport.Push(ctx, n)
+	}
+
+	ok, retryAfter, err := w.allow(ctx, key, limit)
+	if ok {
+		return w.Transport.Push(ctx, n)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch w.policy {
+	case Defer:
+		slog.DebugContext(ctx, "deferring notification exceeding rate limit", "id", n.Context().ID, "recipient", n.Recipient().String(), "transport", w.Key(), "retry_after", retryAfter)
+		return fmt.Errorf("rate limit exceeded: %w", notifier.RetryAfter(retryAfter))
+	case Collapse:
+		w.suppress(ctx, n, key, limit, retryAfter)
+		return nil
+	default:
+		slog.WarnContext(ctx, "dropped notification exceeding rate limit", "id", n.Context().ID, "type", n.Context().Type, "recipient", n.Recipient().String(), "transport", w.Key())
+		return nil
+	}
+}
+
+// allow takes a token for the key, failing open if the store doesn't work
+func (w *withRateLimit) allow(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	ok, retryAfter, err := w.store.Allow(ctx, key, limit)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return false, 0, ctxErr
+		}
+
+		slog.ErrorContext(ctx, "failed to check rate limit; sending anyway", "key", key, "transport", w.Key(), "error", err)
+		return true, 0, nil
+	}
+
+	return ok, retryAfter, nil
+}
+
+// suppress counts the notification, to be included in the summary sent once the recipient is allowed another one
+func (w *withRateLimit) suppress(ctx context.Context, n event.Notification, key string, limit Limit, retryAfter time.Duration) {
+	count, err := w.store.Suppress(ctx, key)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to count suppressed notification", "id", n.Context().ID, "transport", w.Key(), "error", err)
+		return
+	}
+
+	slog.WarnContext(ctx, "suppressed notification exceeding rate limit", "id", n.Context().ID, "type", n.Context().Type, "recipient", n.Recipient().String(), "transport", w.Key(), "suppressed", count)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.summaries[key]; !ok {
+		ctx = context.WithoutCancel(ctx)
+		w.summaries[key] = time.AfterFunc(retryAfter, func() { w.sendSummary(ctx, n, key, limit) })
+	}
+}
+
+// sendSummary sends a summary of the notifications suppressed for the key, or waits longer if its bucket is still empty
+// The summary takes a token like any other notification. The count is kept in the store (rather than in the timer),
+// so that replicas sharing the store send a single summary between them.
+func (w *withRateLimit) sendSummary(ctx context.Context, n event.Notification, key string, limit Limit) {
+	// allow only fails if ctx is canceled, which it can't be
+	if ok, retryAfter, _ := w.allow(ctx, key, limit); !ok {
+		w.mu.Lock()
+		w.summaries[key] = time.AfterFunc(retryAfter, func() { w.sendSummary(ctx, n, key, limit) })
+		w.mu.Unlock()
+		return
+	}
+
+	// Anything suppressed from now on is counted towards the next summary
+	w.mu.Lock()
+	delete(w.summaries, key)
+	w.mu.Unlock()
+
+	count, err := w.store.TakeSuppressed(ctx, key)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to count suppressed notifications", "transport", w.Key(), "error", err)
+		return
+	}
+	if count == 0 {
+		return // Another replica already sent the summary
+	}
+
+	if err = w.Transport.Push(ctx, Summary(n, count)); err != nil {
+		slog.ErrorContext(ctx, "failed to send suppressed notifications summary", "transport", w.Key(), "recipient", n.Recipient().String(), "error", err)
+	}
+}
+
+// Summary returns a notification telling the recipient of n that count notifications were suppressed
+func Summary(n event.Notification, count int) event.Notification {
+	message := fmt.Sprintf("%d more notifications suppressed", count)
+	if count == 1 {
+		message = "1 more notification suppressed"
+	}
+
+	return notification.NewBuilder(n.Context().WithID(event.ID("suppressed-" + uuid.New().String()))).
+		WithRecipient(n.Recipient().Copy()).
+		WithDefaultMessage(message).
+		Build()
+}
+
+func (w *withRateLimit) Validate(ctx context.Context) error {
+	var errs []error
+	if v, ok := w.Transport.(validation.Validator); ok {
+		errs = append(errs, v.Validate(ctx))
+	}
+
+	if v, ok := w.store.(validation.Validator); ok {
+		errs = append(errs, v.Validate(ctx))
+	}
+
+	return errors.Join(errs...)
+}
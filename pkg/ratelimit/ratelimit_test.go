@@ -0,0 +1,286 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/seatgeek/mailroom/pkg/ratelimit"
+	"github.com/seatgeek/mailroom/pkg/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	commented event.Type = "com.example.comment.created"
+	merged    event.Type = "com.example.merge_request.merged"
+)
+
+func someNotification(id event.ID, eventType event.Type, username string) event.Notification {
+	return notification.NewBuilder(event.Context{ID: id, Type: eventType}).
+		WithRecipientIdentifiers(identifier.New(identifier.GenericUsername, username)).
+		WithDefaultMessage("hello " + string(id)).
+		Build()
+}
+
+// recorder is a transport which records every notification pushed to it
+type recorder struct {
+	pushed []event.Notification
+	mu     sync.Mutex
+}
+
+func (r *recorder) Key() event.TransportKey {
+	return "test"
+}
+
+func (r *recorder) Push(_ context.Context, n event.Notification) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pushed = append(r.pushed, n)
+	return nil
+}
+
+func (r *recorder) messages() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	messages := make([]string, len(r.pushed))
+	for i, n := range r.pushed {
+		messages[i] = n.Render("test")
+	}
+	return messages
+}
+
+var _ notifier.Transport = &recorder{}
+
+func TestBucket_Take(t *testing.T) {
+	t.Parallel()
+
+	limit := ratelimit.Limit{Count: 2, Per: time.Minute}
+	now := time.Date(2025, 3, 14, 15, 9, 0, 0, time.UTC)
+	bucket := ratelimit.NewBucket(limit, now)
+
+	// The bucket starts full
+	for range 2 {
+		ok, _ := bucket.Take(limit, now)
+		assert.True(t, ok)
+	}
+
+	ok, retryAfter := bucket.Take(limit, now)
+	assert.False(t, ok)
+	assert.Equal(t, 30*time.Second, retryAfter)
+
+	// It refills gradually
+	ok, retryAfter = bucket.Take(limit, now.Add(20*time.Second))
+	assert.False(t, ok)
+	assert.Equal(t, 10*time.Second, retryAfter)
+
+	ok, _ = bucket.Take(limit, now.Add(30*time.Second))
+	assert.True(t, ok)
+
+	// But never beyond its capacity
+	bucket = ratelimit.NewBucket(limit, now)
+	for i := range 3 {
+		ok, _ = bucket.Take(limit, now.Add(time.Hour))
+		assert.Equal(t, i < 2, ok)
+	}
+}
+
+func TestWithRateLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		opts          []ratelimit.Option
+		notifications []event.Notification
+		want          []string
+	}{
+		{
+			name: "drops notifications over the limit",
+			opts: []ratelimit.Option{ratelimit.WithLimit(ratelimit.PerHour(2))},
+			notifications: []event.Notification{
+				someNotification("1", commented, "codell"),
+				someNotification("2", merged, "codell"),
+				someNotification("3", commented, "codell"),
+			},
+			want: []string{"hello 1", "hello 2"},
+		},
+		{
+			name: "limits each recipient separately",
+			opts: []ratelimit.Option{ratelimit.WithLimit(ratelimit.PerHour(1))},
+			notifications: []event.Notification{
+				someNotification("1", commented, "codell"),
+				someNotification("2", commented, "rufus"),
+				someNotification("3", commented, "codell"),
+			},
+			want: []string{"hello 1", "hello 2"},
+		},
+		{
+			name: "limits event types with their own limit separately",
+			opts: []ratelimit.Option{
+				ratelimit.WithLimit(ratelimit.PerHour(1)),
+				ratelimit.WithEventTypeLimit(commented, ratelimit.PerHour(2)),
+			},
+			notifications: []event.Notification{
+				someNotification("1", commented, "codell"),
+				someNotification("2", commented, "codell"),
+				someNotification("3", commented, "codell"),
+				someNotification("4", merged, "codell"),
+				someNotification("5", merged, "codell"),
+			},
+			want: []string{"hello 1", "hello 2", "hello 4"},
+		},
+		{
+			name: "only limits event types with their own limit if there's no default",
+			opts: []ratelimit.Option{ratelimit.WithEventTypeLimit(commented, ratelimit.PerHour(1))},
+			notifications: []event.Notification{
+				someNotification("1", commented, "codell"),
+				someNotification("2", commented, "codell"),
+				someNotification("3", merged, "codell"),
+				someNotification("4", merged, "codell"),
+			},
+			want: []string{"hello 1", "hello 3", "hello 4"},
+		},
+		{
+			name: "doesn't limit notifications without a recipient",
+			opts: []ratelimit.Option{ratelimit.WithLimit(ratelimit.PerHour(1))},
+			notifications: []event.Notification{
+				notification.NewBuilder(event.Context{ID: "1"}).WithDefaultMessage("hello 1").Build(),
+				notification.NewBuilder(event.Context{ID: "2"}).WithDefaultMessage("hello 2").Build(),
+			},
+			want: []string{"hello 1", "hello 2"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			transport := &recorder{}
+			limited := ratelimit.WithRateLimit(transport, ratelimit.NewInMemoryStore(), tc.opts...)
+
+			for _, n := range tc.notifications {
+				require.NoError(t, limited.Push(t.Context(), n))
+			}
+
+			assert.Equal(t, tc.want, transport.messages())
+		})
+	}
+}
+
+func TestWithRateLimit_Defer(t *testing.T) {
+	t.Parallel()
+
+	transport := &recorder{}
+	limited := ratelimit.WithRateLimit(transport, ratelimit.NewInMemoryStore(),
+		ratelimit.WithLimit(ratelimit.Limit{Count: 1, Per: 50 * time.Millisecond}),
+		ratelimit.WithPolicy(ratelimit.Defer),
+	)
+
+	require.NoError(t, limited.Push(t.Context(), someNotification("1", commented, "codell")))
+
+	// Notifications over the limit fail, saying when to try again
+	err := limited.Push(t.Context(), someNotification("2", commented, "codell"))
+	var retryAfter *backoff.RetryAfterError
+	require.ErrorAs(t, err, &retryAfter)
+	assert.InDelta(t, 50*time.Millisecond, retryAfter.Duration, float64(10*time.Millisecond))
+	assert.Equal(t, []string{"hello 1"}, transport.messages())
+
+	// Which succeeds
+	time.Sleep(retryAfter.Duration)
+	require.NoError(t, limited.Push(t.Context(), someNotification("2", commented, "codell")))
+	assert.Equal(t, []string{"hello 1", "hello 2"}, transport.messages())
+}
+
+func TestWithRateLimit_Collapse(t *testing.T) {
+	t.Parallel()
+
+	transport := &recorder{}
+	limited := ratelimit.WithRateLimit(transport, ratelimit.NewInMemoryStore(),
+		ratelimit.WithLimit(ratelimit.Limit{Count: 1, Per: 50 * time.Millisecond}),
+		ratelimit.WithPolicy(ratelimit.Collapse),
+	)
+
+	for _, id := range []event.ID{"1", "2", "3", "4"} {
+		require.NoError(t, limited.Push(t.Context(), someNotification(id, commented, "codell")))
+	}
+	assert.Equal(t, []string{"hello 1"}, transport.messages())
+
+	// The suppressed notifications are summarized as soon as the recipient is allowed another notification
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"hello 1", "3 more notifications suppressed"}, transport.messages())
+	}, time.Second, 5*time.Millisecond)
+
+	// The summary took that notification's token
+	require.NoError(t, limited.Push(t.Context(), someNotification("5", commented, "codell")))
+	assert.Equal(t, []string{"hello 1", "3 more notifications suppressed"}, transport.messages())
+
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual([]string{"hello 1", "3 more notifications suppressed", "1 more notification suppressed"}, transport.messages())
+	}, time.Second, 5*time.Millisecond)
+
+	// Only once
+	time.Sleep(60 * time.Millisecond)
+	require.NoError(t, limited.Push(t.Context(), someNotification("6", commented, "codell")))
+	assert.Equal(t, []string{"hello 1", "3 more notifications suppressed", "1 more notification suppressed", "hello 6"}, transport.messages())
+}
+
+func TestSummary(t *testing.T) {
+	t.Parallel()
+
+	n := someNotification("1", commented, "codell")
+
+	one := ratelimit.Summary(n, 1)
+	assert.Equal(t, "1 more notification suppressed", one.Render("test"))
+	assert.Equal(t, commented, one.Context().Type)
+	assert.Equal(t, n.Recipient().ToMap(), one.Recipient().ToMap())
+	assert.NotEqual(t, n.Context().ID, one.Context().ID)
+
+	assert.Equal(t, "5 more notifications suppressed", ratelimit.Summary(n, 5).Render("test"))
+}
+
+// failingStore is a ratelimit.Store which always fails
+type failingStore struct{}
+
+func (failingStore) Allow(context.Context, string, ratelimit.Limit) (bool, time.Duration, error) {
+	return false, 0, errors.New("store is down")
+}
+
+func (failingStore) Suppress(context.Context, string) (int, error) {
+	return 0, errors.New("store is down")
+}
+
+func (failingStore) TakeSuppressed(context.Context, string) (int, error) {
+	return 0, errors.New("store is down")
+}
+
+func (failingStore) Validate(context.Context) error {
+	return errors.New("store is down")
+}
+
+func TestWithRateLimit_StoreFails(t *testing.T) {
+	t.Parallel()
+
+	transport := &recorder{}
+	limited := ratelimit.WithRateLimit(transport, failingStore{}, ratelimit.WithLimit(ratelimit.PerHour(1)))
+
+	// Notifications are sent anyway
+	require.NoError(t, limited.Push(t.Context(), someNotification("1", commented, "codell")))
+	require.NoError(t, limited.Push(t.Context(), someNotification("2", commented, "codell")))
+	assert.Equal(t, []string{"hello 1", "hello 2"}, transport.messages())
+
+	// But the store fails validation
+	assert.ErrorContains(t, limited.(validation.Validator).Validate(t.Context()), "store is down")
+}
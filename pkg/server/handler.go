@@ -11,15 +11,36 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
 
+	"github.com/seatgeek/mailroom/pkg/dedup"
 	"github.com/seatgeek/mailroom/pkg/event"
 	"github.com/seatgeek/mailroom/pkg/notifier"
 )
 
+type handlerOpts struct {
+	deduplicator *dedup.Deduplicator
+}
+
+// HandlerOption configures optional behavior of the event processing handler
+type HandlerOption func(*handlerOpts)
+
+// WithDeduplicator skips events which the given dedup.Deduplicator has already seen, responding with a 200
+func WithDeduplicator(d *dedup.Deduplicator) HandlerOption {
+	return func(o *handlerOpts) {
+		o.deduplicator = d
+	}
+}
+
 // CreateEventProcessingHandler returns a handlerFunc that can be used to handle incoming webhooks.
 // It choreographs the parsing of the incoming request, the generation of notifications, dispatching the notifications
 // to the notifier, and returning a success or error response to the client.
-func CreateEventProcessingHandler(parserKey string, parser event.Parser, processors []event.Processor, ntfr notifier.Notifier) http.HandlerFunc { //nolint:revive
+func CreateEventProcessingHandler(parserKey string, parser event.Parser, processors []event.Processor, ntfr notifier.Notifier, opts ...HandlerOption) http.HandlerFunc { //nolint:revive
+	o := handlerOpts{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	return func(writer http.ResponseWriter, request *http.Request) {
 		logger := slog.With(
 			slog.String("parser", parserKey),
@@ -28,27 +49,41 @@ func CreateEventProcessingHandler(parserKey string, parser event.Parser, process
 
 		logger.DebugContext(request.Context(), "handling incoming webhook")
 
-		evt, err := parser.Parse(request)
+		events, err := parseEvents(parser, request)
 		if err != nil {
 			logAndSendErrorResponse(request.Context(), logger, writer, "failed to parse event", err)
 			return
 		}
 
-		if evt == nil { // Event is ignorable
+		if len(events) == 0 { // Event is ignorable
 			logger.DebugContext(request.Context(), "ignoring uninteresting event")
 			http.Error(writer, "thanks but we're not interested in that event", 200)
 			return
 		}
 
-		logger = logger.With(slog.String("event_id", string(evt.ID)))
+		if len(events) == 1 {
+			logger = logger.With(slog.String("event_id", string(events[0].ID)))
+		} else {
+			logger = logger.With(slog.Int("total_events", len(events)))
+		}
+
+		events = claimEvents(request.Context(), logger, o.deduplicator, parserKey, events)
+		if len(events) == 0 {
+			logger.InfoContext(request.Context(), "ignoring duplicate event")
+			http.Error(writer, "event was already processed", 200)
+			return
+		}
 
 		notifications := []event.Notification{}
 
-		for _, processor := range processors {
-			notifications, err = processor.Process(request.Context(), *evt, notifications)
-			if err != nil {
-				logAndSendErrorResponse(request.Context(), logger, writer, fmt.Sprintf("failed during processing (processor %T)", processor), err)
-				return
+		for _, evt := range events {
+			for _, processor := range processors {
+				notifications, err = processor.Process(request.Context(), *evt, notifications)
+				if err != nil {
+					releaseEvents(request.Context(), logger, o.deduplicator, parserKey, events)
+					logAndSendErrorResponse(request.Context(), logger, writer, fmt.Sprintf("failed during processing (processor %T)", processor), err)
+					return
+				}
 			}
 		}
 
@@ -62,16 +97,24 @@ func CreateEventProcessingHandler(parserKey string, parser event.Parser, process
 
 		logger.DebugContext(request.Context(), "dispatching notifications")
 
-		errorCount := 0
+		var errs []error
 		for _, n := range notifications {
 			if err = ntfr.Push(request.Context(), n); err != nil {
-				errorCount++
+				errs = append(errs, err)
 				logger.WarnContext(request.Context(), "failed to push notification", "notification_recipient", n.Recipient().String(), "error", err)
 			}
 		}
 
-		if errorCount > 0 {
-			logger.WarnContext(request.Context(), "some notifications failed to send", "failed_count", errorCount)
+		if len(errs) == len(notifications) {
+			// Nothing was sent, so the sender can safely redeliver the event without causing any duplicates
+			releaseEvents(request.Context(), logger, o.deduplicator, parserKey, events)
+			logAndSendErrorResponse(request.Context(), logger, writer, "failed to push notifications", errors.Join(errs...))
+			return
+		}
+
+		if len(errs) > 0 {
+			// Keep the events claimed: a redelivery would re-send the notifications which were already pushed
+			logger.WarnContext(request.Context(), "some notifications failed to send", "failed_count", len(errs))
 		}
 
 		writer.WriteHeader(http.StatusAccepted)
@@ -79,6 +122,61 @@ func CreateEventProcessingHandler(parserKey string, parser event.Parser, process
 	}
 }
 
+// parseEvents parses the request into zero or more interesting events, using ParseBatch if the parser supports it
+func parseEvents(parser event.Parser, request *http.Request) ([]*event.Event, error) {
+	if bp, ok := parser.(event.BatchParser); ok {
+		events, err := bp.ParseBatch(request)
+		if err != nil {
+			return nil, err
+		}
+
+		return slices.DeleteFunc(events, func(evt *event.Event) bool { return evt == nil }), nil
+	}
+
+	evt, err := parser.Parse(request)
+	if err != nil || evt == nil {
+		return nil, err
+	}
+
+	return []*event.Event{evt}, nil
+}
+
+// claimEvents returns only those events which have not already been processed
+func claimEvents(ctx context.Context, logger *slog.Logger, d *dedup.Deduplicator, parserKey string, events []*event.Event) []*event.Event {
+	if d == nil {
+		return events
+	}
+
+	claimed := make([]*event.Event, 0, len(events))
+	for _, evt := range events {
+		ok, err := d.Claim(ctx, parserKey, evt.ID)
+		if err != nil {
+			// Better to risk a duplicate notification than to drop the event entirely
+			logger.WarnContext(ctx, "failed to check whether event was already processed", "event_id", evt.ID, "error", err)
+			ok = true
+		}
+
+		if ok {
+			claimed = append(claimed, evt)
+		}
+	}
+
+	return claimed
+}
+
+// releaseEvents allows events to be processed again if they could not be handled successfully
+func releaseEvents(ctx context.Context, logger *slog.Logger, d *dedup.Deduplicator, parserKey string, events []*event.Event) {
+	if d == nil {
+		return
+	}
+
+	for _, evt := range events {
+		if err := d.Release(ctx, parserKey, evt.ID); err != nil {
+			logger.WarnContext(ctx, "failed to release event for reprocessing", "event_id", evt.ID, "error", err)
+		}
+	}
+}
+
 func logAndSendErrorResponse(ctx context.Context, logger *slog.Logger, writer http.ResponseWriter, errorPrefix string, err error) {
 	statusCode := 500
 
@@ -6,9 +6,11 @@ package server
 
 import (
 	"errors"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 
+	"github.com/seatgeek/mailroom/pkg/dedup"
 	"github.com/seatgeek/mailroom/pkg/event"
 	"github.com/seatgeek/mailroom/pkg/notification"
 	"github.com/seatgeek/mailroom/pkg/notifier"
@@ -76,7 +78,7 @@ func TestHandler(t *testing.T) {
 			parser:         parserThatReturns(t, &someEvent, nil),
 			processors:     []event.Processor{processorThatReturns(t, someNotifications, nil)},
 			notifier:       notifierThatReturns(t, someError),
-			wantStatusCode: 202,
+			wantStatusCode: 500,
 		},
 	}
 
@@ -95,6 +97,202 @@ func TestHandler(t *testing.T) {
 	}
 }
 
+func TestHandler_Deduplication(t *testing.T) {
+	t.Parallel()
+
+	someEvent := event.Event{
+		Context: event.Context{
+			ID:     "a1c11a53-c4be-488f-89b6-f83bf2d48dab",
+			Type:   "com.example.event",
+			Source: event.MustSource("example.com"),
+		},
+		Data: "some payload",
+	}
+	someNotifications := []event.Notification{
+		notification.NewBuilder(someEvent.Context).Build(),
+	}
+
+	t.Run("duplicate events are only processed once", func(t *testing.T) {
+		t.Parallel()
+
+		processor := event.NewMockProcessor(t)
+		processor.EXPECT().Process(mock.Anything, mock.Anything, mock.Anything).Return(someNotifications, nil).Once()
+
+		handler := CreateEventProcessingHandler(
+			"some-parser",
+			parserThatReturns(t, &someEvent, nil),
+			[]event.Processor{processor},
+			notifierThatReturns(t, nil),
+			WithDeduplicator(dedup.New(dedup.NewInMemoryStore())),
+		)
+
+		writer := httptest.NewRecorder()
+		handler(writer, httptest.NewRequestWithContext(t.Context(), "POST", "/some-handler", nil))
+		assert.Equal(t, 202, writer.Code)
+
+		writer = httptest.NewRecorder()
+		handler(writer, httptest.NewRequestWithContext(t.Context(), "POST", "/some-handler", nil))
+		assert.Equal(t, 200, writer.Code)
+	})
+
+	t.Run("events which fail processing can be retried", func(t *testing.T) {
+		t.Parallel()
+
+		processor := event.NewMockProcessor(t)
+		processor.EXPECT().Process(mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("some error")).Once()
+		processor.EXPECT().Process(mock.Anything, mock.Anything, mock.Anything).Return(someNotifications, nil).Once()
+
+		handler := CreateEventProcessingHandler(
+			"some-parser",
+			parserThatReturns(t, &someEvent, nil),
+			[]event.Processor{processor},
+			notifierThatReturns(t, nil),
+			WithDeduplicator(dedup.New(dedup.NewInMemoryStore())),
+		)
+
+		writer := httptest.NewRecorder()
+		handler(writer, httptest.NewRequestWithContext(t.Context(), "POST", "/some-handler", nil))
+		assert.Equal(t, 500, writer.Code)
+
+		writer = httptest.NewRecorder()
+		handler(writer, httptest.NewRequestWithContext(t.Context(), "POST", "/some-handler", nil))
+		assert.Equal(t, 202, writer.Code)
+	})
+
+	t.Run("events which fail to push can be retried", func(t *testing.T) {
+		t.Parallel()
+
+		processor := event.NewMockProcessor(t)
+		processor.EXPECT().Process(mock.Anything, mock.Anything, mock.Anything).Return(someNotifications, nil).Twice()
+
+		ntfr := notifier.NewMockNotifier(t)
+		ntfr.EXPECT().Push(mock.Anything, mock.Anything).Return(errors.New("some error")).Once()
+		ntfr.EXPECT().Push(mock.Anything, mock.Anything).Return(nil).Once()
+
+		handler := CreateEventProcessingHandler(
+			"some-parser",
+			parserThatReturns(t, &someEvent, nil),
+			[]event.Processor{processor},
+			ntfr,
+			WithDeduplicator(dedup.New(dedup.NewInMemoryStore())),
+		)
+
+		writer := httptest.NewRecorder()
+		handler(writer, httptest.NewRequestWithContext(t.Context(), "POST", "/some-handler", nil))
+		assert.Equal(t, 500, writer.Code)
+
+		// The redelivery is processed again rather than ignored as a duplicate
+		writer = httptest.NewRecorder()
+		handler(writer, httptest.NewRequestWithContext(t.Context(), "POST", "/some-handler", nil))
+		assert.Equal(t, 202, writer.Code)
+	})
+
+	t.Run("events which were partially pushed are not retried", func(t *testing.T) {
+		t.Parallel()
+
+		twoNotifications := []event.Notification{
+			notification.NewBuilder(someEvent.Context).Build(),
+			notification.NewBuilder(someEvent.Context).Build(),
+		}
+
+		processor := event.NewMockProcessor(t)
+		processor.EXPECT().Process(mock.Anything, mock.Anything, mock.Anything).Return(twoNotifications, nil).Once()
+
+		ntfr := notifier.NewMockNotifier(t)
+		ntfr.EXPECT().Push(mock.Anything, mock.Anything).Return(errors.New("some error")).Once()
+		ntfr.EXPECT().Push(mock.Anything, mock.Anything).Return(nil).Once()
+
+		handler := CreateEventProcessingHandler(
+			"some-parser",
+			parserThatReturns(t, &someEvent, nil),
+			[]event.Processor{processor},
+			ntfr,
+			WithDeduplicator(dedup.New(dedup.NewInMemoryStore())),
+		)
+
+		writer := httptest.NewRecorder()
+		handler(writer, httptest.NewRequestWithContext(t.Context(), "POST", "/some-handler", nil))
+		assert.Equal(t, 202, writer.Code)
+
+		// Redelivering the event would re-send the notification which succeeded
+		writer = httptest.NewRecorder()
+		handler(writer, httptest.NewRequestWithContext(t.Context(), "POST", "/some-handler", nil))
+		assert.Equal(t, 200, writer.Code)
+	})
+}
+
+func TestHandler_Batch(t *testing.T) {
+	t.Parallel()
+
+	first := &event.Event{Context: event.Context{ID: "first", Type: "com.example.event"}}
+	second := &event.Event{Context: event.Context{ID: "second", Type: "com.example.event"}}
+
+	t.Run("each event is processed", func(t *testing.T) {
+		t.Parallel()
+
+		fromFirst := notification.NewBuilder(first.Context).Build()
+		fromSecond := notification.NewBuilder(second.Context).Build()
+
+		// Notifications accumulate across every event in the batch
+		processor := event.NewMockProcessor(t)
+		processor.EXPECT().Process(mock.Anything, *first, []event.Notification{}).Return([]event.Notification{fromFirst}, nil).Once()
+		processor.EXPECT().Process(mock.Anything, *second, []event.Notification{fromFirst}).Return([]event.Notification{fromFirst, fromSecond}, nil).Once()
+
+		ntfr := notifier.NewMockNotifier(t)
+		ntfr.EXPECT().Push(mock.Anything, mock.Anything).Return(nil).Twice()
+
+		handler := CreateEventProcessingHandler("some-parser", &batchParser{events: []*event.Event{first, nil, second}}, []event.Processor{processor}, ntfr)
+
+		writer := httptest.NewRecorder()
+		handler(writer, httptest.NewRequestWithContext(t.Context(), "POST", "/some-handler", nil))
+		assert.Equal(t, 202, writer.Code)
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		t.Parallel()
+
+		handler := CreateEventProcessingHandler("some-parser", &batchParser{}, nil, nil)
+
+		writer := httptest.NewRecorder()
+		handler(writer, httptest.NewRequestWithContext(t.Context(), "POST", "/some-handler", nil))
+		assert.Equal(t, 200, writer.Code)
+	})
+
+	t.Run("duplicates within a batch are skipped", func(t *testing.T) {
+		t.Parallel()
+
+		d := dedup.New(dedup.NewInMemoryStore())
+		claimed, err := d.Claim(t.Context(), "some-parser", first.ID)
+		assert.NoError(t, err)
+		assert.True(t, claimed)
+
+		processor := event.NewMockProcessor(t)
+		processor.EXPECT().Process(mock.Anything, *second, mock.Anything).Return(nil, nil).Once()
+
+		handler := CreateEventProcessingHandler("some-parser", &batchParser{events: []*event.Event{first, second}}, []event.Processor{processor}, nil, WithDeduplicator(d))
+
+		writer := httptest.NewRecorder()
+		handler(writer, httptest.NewRequestWithContext(t.Context(), "POST", "/some-handler", nil))
+		assert.Equal(t, 200, writer.Code)
+	})
+}
+
+type batchParser struct {
+	events []*event.Event
+}
+
+func (b *batchParser) Parse(_ *http.Request) (*event.Event, error) {
+	panic("Parse should not be called on a BatchParser")
+}
+
+func (b *batchParser) ParseBatch(_ *http.Request) ([]*event.Event, error) {
+	return b.events, nil
+}
+
+func (b *batchParser) EventTypes() []event.TypeDescriptor {
+	return nil
+}
+
 func parserThatReturns(t *testing.T, evt *event.Event, err error) event.Parser {
 	t.Helper()
 
@@ -0,0 +1,196 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package stream
+
+import (
+	"log/slog"
+	"strconv"
+	"sync"
+)
+
+const (
+	// DefaultBufferSize is the number of events each connection may fall behind by before it is dropped
+	DefaultBufferSize = 16
+	// DefaultHistorySize is the number of recent events kept for clients resuming with a Last-Event-ID
+	DefaultHistorySize = 1000
+)
+
+// Broker fans out events to the connected subscribers of each user
+// Publishing never blocks: a subscriber whose buffer is full is disconnected instead, and can
+// catch up by reconnecting with the ID of the last event it received.
+type Broker struct {
+	bufferSize  int
+	historySize int
+
+	mu          sync.Mutex
+	seq         uint64
+	history     []Event
+	subscribers map[string]map[*Subscription]struct{}
+	closed      bool
+}
+
+// Option configures a Broker
+type Option func(*Broker)
+
+// WithBufferSize sets how many events each connection may fall behind by before it is dropped
+func WithBufferSize(size int) Option {
+	return func(b *Broker) {
+		b.bufferSize = size
+	}
+}
+
+// WithHistorySize sets how many recent events (across all users) are kept for resuming
+func WithHistorySize(size int) Option {
+	return func(b *Broker) {
+		b.historySize = size
+	}
+}
+
+// NewBroker creates a new, empty Broker
+func NewBroker(opts ...Option) *Broker {
+	b := &Broker{
+		bufferSize:  DefaultBufferSize,
+		historySize: DefaultHistorySize,
+		subscribers: make(map[string]map[*Subscription]struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	return b
+}
+
+// Subscription receives a single user's events until it is closed
+type Subscription struct {
+	userKey string
+	events  chan Event
+	done    chan struct{}
+	once    sync.Once
+}
+
+// Events returns the channel of new events
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Done is closed once the subscription ends, either because the subscriber fell too far behind
+// or because the Broker was closed
+func (s *Subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Subscription) end() {
+	s.once.Do(func() { close(s.done) })
+}
+
+// Publish assigns the event an ID and sends it to each of the user's subscribers
+func (b *Broker) Publish(evt Event) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	evt.ID = strconv.FormatUint(b.seq, 10)
+
+	if b.historySize > 0 {
+		if len(b.history) >= b.historySize {
+			b.history = b.history[len(b.history)-b.historySize+1:]
+		}
+		b.history = append(b.history, evt)
+	}
+
+	for sub := range b.subscribers[evt.UserKey] {
+		select {
+		case sub.events <- evt:
+		default:
+			slog.Warn("dropping slow stream subscriber", "user", evt.UserKey, "event", evt.ID)
+			b.remove(sub)
+		}
+	}
+
+	return evt
+}
+
+// Subscribe starts receiving the user's events
+// If lastEventID is given, any newer events still in the history are returned so they can be replayed first.
+func (b *Broker) Subscribe(userKey string, lastEventID string) (*Subscription, []Event) {
+	sub := &Subscription{
+		userKey: userKey,
+		events:  make(chan Event, b.bufferSize),
+		done:    make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		sub.end()
+		return sub, nil
+	}
+
+	if _, ok := b.subscribers[userKey]; !ok {
+		b.subscribers[userKey] = make(map[*Subscription]struct{})
+	}
+	b.subscribers[userKey][sub] = struct{}{}
+
+	return sub, b.missed(userKey, lastEventID)
+}
+
+// Unsubscribe stops sending events to the subscription
+func (b *Broker) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.remove(sub)
+}
+
+// Close ends all subscriptions and rejects new ones
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for _, subs := range b.subscribers {
+		for sub := range subs {
+			b.remove(sub)
+		}
+	}
+}
+
+// remove ends the subscription (the caller must hold the lock)
+func (b *Broker) remove(sub *Subscription) {
+	sub.end()
+
+	subs := b.subscribers[sub.userKey]
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(b.subscribers, sub.userKey)
+	}
+}
+
+// missed returns the user's events in the history after the given ID (the caller must hold the lock)
+func (b *Broker) missed(userKey string, lastEventID string) []Event {
+	if lastEventID == "" {
+		return nil
+	}
+
+	last, err := strconv.ParseUint(lastEventID, 10, 64)
+	if err != nil || last > b.seq {
+		// Either not one of ours, or from before a restart
+		return nil
+	}
+
+	var events []Event
+	for _, evt := range b.history {
+		if evt.UserKey != userKey {
+			continue
+		}
+		if id, _ := strconv.ParseUint(evt.ID, 10, 64); id > last {
+			events = append(events, evt)
+		}
+	}
+
+	return events
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package stream_test
+
+import (
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/stream"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroker_Publish(t *testing.T) {
+	t.Parallel()
+
+	broker := stream.NewBroker()
+
+	codell, _ := broker.Subscribe("codell", "")
+	alsoCodell, _ := broker.Subscribe("codell", "")
+	rufus, _ := broker.Subscribe("rufus", "")
+
+	first := broker.Publish(stream.Event{UserKey: "codell", Message: "hello"})
+	second := broker.Publish(stream.Event{UserKey: "codell", Message: "world"})
+
+	assert.Equal(t, "1", first.ID)
+	assert.Equal(t, "2", second.ID)
+
+	for _, sub := range []*stream.Subscription{codell, alsoCodell} {
+		assert.Equal(t, first, <-sub.Events())
+		assert.Equal(t, second, <-sub.Events())
+	}
+	assert.Empty(t, rufus.Events())
+}
+
+func TestBroker_Publish_DropsSlowSubscribers(t *testing.T) {
+	t.Parallel()
+
+	broker := stream.NewBroker(stream.WithBufferSize(2))
+
+	slow, _ := broker.Subscribe("codell", "")
+
+	for range 2 {
+		broker.Publish(stream.Event{UserKey: "codell"})
+	}
+	assert.NotPanics(t, func() {
+		broker.Publish(stream.Event{UserKey: "codell"})
+	})
+
+	select {
+	case <-slow.Done():
+	default:
+		t.Fatal("expected slow subscriber to be dropped")
+	}
+
+	// Buffered events can still be drained, and the missed one is available for resuming
+	assert.Equal(t, "1", (<-slow.Events()).ID)
+	assert.Equal(t, "2", (<-slow.Events()).ID)
+
+	_, missed := broker.Subscribe("codell", "2")
+	assert.Equal(t, []string{"3"}, ids(missed))
+}
+
+func TestBroker_Subscribe_Resume(t *testing.T) {
+	t.Parallel()
+
+	broker := stream.NewBroker(stream.WithHistorySize(4))
+
+	for _, key := range []string{"codell", "rufus", "codell", "codell", "rufus", "codell"} {
+		broker.Publish(stream.Event{UserKey: key})
+	}
+
+	tests := []struct {
+		name        string
+		lastEventID string
+		want        []string
+	}{
+		{
+			name:        "no last event ID",
+			lastEventID: "",
+			want:        nil,
+		},
+		{
+			name:        "resumes after the last event",
+			lastEventID: "3",
+			want:        []string{"4", "6"},
+		},
+		{
+			name:        "only returns events still in the history",
+			lastEventID: "1",
+			want:        []string{"3", "4", "6"},
+		},
+		{
+			name:        "nothing missed",
+			lastEventID: "6",
+			want:        nil,
+		},
+		{
+			name:        "unknown ID",
+			lastEventID: "99",
+			want:        nil,
+		},
+		{
+			name:        "invalid ID",
+			lastEventID: "abc",
+			want:        nil,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			sub, missed := broker.Subscribe("codell", tc.lastEventID)
+			defer broker.Unsubscribe(sub)
+
+			assert.Equal(t, tc.want, ids(missed))
+		})
+	}
+}
+
+func TestBroker_Unsubscribe(t *testing.T) {
+	t.Parallel()
+
+	broker := stream.NewBroker()
+
+	sub, _ := broker.Subscribe("codell", "")
+	broker.Unsubscribe(sub)
+	broker.Publish(stream.Event{UserKey: "codell"})
+
+	assert.Empty(t, sub.Events())
+	assert.NotPanics(t, func() {
+		broker.Unsubscribe(sub)
+	})
+}
+
+func TestBroker_Close(t *testing.T) {
+	t.Parallel()
+
+	broker := stream.NewBroker()
+
+	before, _ := broker.Subscribe("codell", "")
+	broker.Close()
+	after, _ := broker.Subscribe("codell", "")
+
+	for _, sub := range []*stream.Subscription{before, after} {
+		select {
+		case <-sub.Done():
+		default:
+			t.Fatal("expected subscription to be ended")
+		}
+	}
+}
+
+func ids(events []stream.Event) []string {
+	var ids []string
+	for _, evt := range events {
+		ids = append(ids, evt.ID)
+	}
+	return ids
+}
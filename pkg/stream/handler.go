@@ -0,0 +1,200 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// DefaultHeartbeat is how often idle connections are kept alive
+	DefaultHeartbeat = 30 * time.Second
+
+	writeTimeout = 10 * time.Second
+)
+
+// Handler streams each user's events to connected clients
+type Handler struct {
+	broker    *Broker
+	heartbeat time.Duration
+	upgrader  websocket.Upgrader
+}
+
+// HandlerOption configures a Handler
+type HandlerOption func(*Handler)
+
+// WithHeartbeat sets how often idle connections are kept alive, so that proxies don't close them
+func WithHeartbeat(interval time.Duration) HandlerOption {
+	return func(h *Handler) {
+		h.heartbeat = interval
+	}
+}
+
+// WithCheckOrigin sets the function which decides whether a WebSocket may connect from another origin
+// By default, only same-origin requests are accepted.
+func WithCheckOrigin(check func(r *http.Request) bool) HandlerOption {
+	return func(h *Handler) {
+		h.upgrader.CheckOrigin = check
+	}
+}
+
+// NewHandler creates a new Handler
+func NewHandler(broker *Broker, opts ...HandlerOption) *Handler {
+	h := &Handler{
+		broker:    broker,
+		heartbeat: DefaultHeartbeat,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	if h.heartbeat <= 0 {
+		h.heartbeat = DefaultHeartbeat
+	}
+
+	return h
+}
+
+// ServeSSE streams the user's events as Server-Sent Events
+// Clients resume where they left off with the Last-Event-ID header (or "last_event_id" query parameter).
+func (h *Handler) ServeSSE(writer http.ResponseWriter, request *http.Request) {
+	key := mux.Vars(request)["key"]
+	rc := http.NewResponseController(writer)
+
+	sub, missed := h.broker.Subscribe(key, lastEventID(request))
+	defer h.broker.Unsubscribe(sub)
+
+	writer.Header().Set("Content-Type", "text/event-stream")
+	writer.Header().Set("Cache-Control", "no-cache")
+	writer.Header().Set("X-Accel-Buffering", "no")
+	writer.WriteHeader(http.StatusOK)
+
+	write := func(chunk []byte) bool {
+		_ = rc.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if _, err := writer.Write(chunk); err != nil {
+			return false
+		}
+		return rc.Flush() == nil
+	}
+
+	for _, evt := range missed {
+		if !write(formatSSE(evt)) {
+			return
+		}
+	}
+	if !write([]byte(": connected\n\n")) {
+		return
+	}
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-request.Context().Done():
+			return
+		case <-sub.Done():
+			return
+		case evt := <-sub.Events():
+			if !write(formatSSE(evt)) {
+				return
+			}
+		case <-ticker.C:
+			if !write([]byte(": heartbeat\n\n")) {
+				return
+			}
+		}
+	}
+}
+
+// ServeWebSocket streams the user's events as JSON messages over a WebSocket
+// Clients resume where they left off with the "last_event_id" query parameter.
+func (h *Handler) ServeWebSocket(writer http.ResponseWriter, request *http.Request) {
+	key := mux.Vars(request)["key"]
+
+	// Subscribe first so that nothing published once the client is connected can be missed
+	sub, missed := h.broker.Subscribe(key, lastEventID(request))
+	defer h.broker.Unsubscribe(sub)
+
+	conn, err := h.upgrader.Upgrade(writer, request, nil)
+	if err != nil {
+		// The upgrader has already responded with an error
+		slog.DebugContext(request.Context(), "failed to upgrade stream to websocket", "key", key, "error", err)
+		return
+	}
+	defer conn.Close()
+
+	// Clients don't send us anything, but we need to keep reading to handle pongs and close frames
+	readDeadline := 2 * h.heartbeat
+	_ = conn.SetReadDeadline(time.Now().Add(readDeadline))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(readDeadline))
+	})
+
+	disconnected := make(chan struct{})
+	go func() {
+		defer close(disconnected)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	write := func(evt Event) bool {
+		_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		return conn.WriteJSON(evt) == nil
+	}
+
+	for _, evt := range missed {
+		if !write(evt) {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(h.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-disconnected:
+			return
+		case <-sub.Done():
+			// Let the client know it should reconnect
+			_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "stream ended"), time.Now().Add(writeTimeout))
+			return
+		case evt := <-sub.Events():
+			if !write(evt) {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func lastEventID(request *http.Request) string {
+	if id := request.Header.Get("Last-Event-ID"); id != "" {
+		return id
+	}
+
+	return request.URL.Query().Get("last_event_id")
+}
+
+func formatSSE(evt Event) []byte {
+	// Marshalling an Event can't fail, and the JSON never contains newlines
+	data, _ := json.Marshal(evt)
+	return fmt.Appendf(nil, "id: %s\nevent: notification\ndata: %s\n\n", evt.ID, data)
+}
@@ -0,0 +1,238 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package stream_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/seatgeek/mailroom/pkg/stream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ServeSSE(t *testing.T) {
+	t.Parallel()
+
+	broker := stream.NewBroker()
+	srv := createServer(t, broker)
+
+	broker.Publish(stream.Event{UserKey: "codell", Message: "missed"})
+	broker.Publish(stream.Event{UserKey: "codell", Message: "also missed"})
+
+	req, err := http.NewRequestWithContext(t.Context(), "GET", srv.URL+"/users/codell/stream", nil)
+	require.NoError(t, err)
+	req.Header.Set("Last-Event-ID", "1")
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+
+	// Missed events are replayed first
+	id, evt := readSSE(t, reader)
+	assert.Equal(t, "2", id)
+	assert.Equal(t, "also missed", evt.Message)
+	assert.Equal(t, ": connected", readLine(t, reader))
+	assert.Empty(t, readLine(t, reader))
+
+	broker.Publish(stream.Event{UserKey: "rufus", Message: "not for codell"})
+	broker.Publish(stream.Event{UserKey: "codell", Message: "live"})
+
+	id, evt = readSSE(t, reader)
+	assert.Equal(t, "4", id)
+	assert.Equal(t, "live", evt.Message)
+
+	// Closing the broker ends the stream
+	broker.Close()
+	_, err = reader.ReadString('\n')
+	assert.Error(t, err)
+}
+
+func TestHandler_ServeSSE_Heartbeat(t *testing.T) {
+	t.Parallel()
+
+	srv := createServer(t, stream.NewBroker(), stream.WithHeartbeat(10*time.Millisecond))
+
+	resp, err := srv.Client().Get(srv.URL + "/users/codell/stream")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+	assert.Equal(t, ": connected", readLine(t, reader))
+	assert.Empty(t, readLine(t, reader))
+	assert.Equal(t, ": heartbeat", readLine(t, reader))
+}
+
+func TestHandler_ServeWebSocket(t *testing.T) {
+	t.Parallel()
+
+	broker := stream.NewBroker()
+	srv := createServer(t, broker)
+
+	broker.Publish(stream.Event{UserKey: "codell", Message: "missed"})
+	broker.Publish(stream.Event{UserKey: "codell", Message: "also missed"})
+
+	conn := dial(t, srv, "/users/codell/stream/ws?last_event_id=1")
+
+	var evt stream.Event
+	require.NoError(t, conn.ReadJSON(&evt))
+	assert.Equal(t, "2", evt.ID)
+	assert.Equal(t, "also missed", evt.Message)
+
+	broker.Publish(stream.Event{UserKey: "rufus", Message: "not for codell"})
+	broker.Publish(stream.Event{UserKey: "codell", Message: "live"})
+
+	require.NoError(t, conn.ReadJSON(&evt))
+	assert.Equal(t, "4", evt.ID)
+	assert.Equal(t, "live", evt.Message)
+
+	// Closing the broker asks the client to reconnect later
+	broker.Close()
+	_, _, err := conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	require.True(t, errors.As(err, &closeErr))
+	assert.Equal(t, websocket.CloseTryAgainLater, closeErr.Code)
+}
+
+func TestHandler_ServeWebSocket_Heartbeat(t *testing.T) {
+	t.Parallel()
+
+	srv := createServer(t, stream.NewBroker(), stream.WithHeartbeat(10*time.Millisecond))
+
+	conn := dial(t, srv, "/users/codell/stream/ws")
+
+	pinged := make(chan struct{}, 1)
+	conn.SetPingHandler(func(string) error {
+		select {
+		case pinged <- struct{}{}:
+		default:
+		}
+		return nil
+	})
+	go func() {
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-pinged:
+	case <-time.After(time.Second):
+		t.Fatal("expected a ping")
+	}
+}
+
+func TestHandler_ServeWebSocket_CheckOrigin(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		opts       []stream.HandlerOption
+		wantStatus int
+	}{
+		{
+			name:       "rejects other origins by default",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "allows other origins if configured",
+			opts: []stream.HandlerOption{stream.WithCheckOrigin(func(r *http.Request) bool {
+				return r.Header.Get("Origin") == "https://example.com"
+			})},
+			wantStatus: http.StatusSwitchingProtocols,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := createServer(t, stream.NewBroker(), tc.opts...)
+
+			conn, resp, err := websocket.DefaultDialer.DialContext(t.Context(), wsURL(srv, "/users/codell/stream/ws"), http.Header{"Origin": {"https://example.com"}})
+			if conn != nil {
+				_ = conn.Close()
+			}
+			if tc.wantStatus == http.StatusSwitchingProtocols {
+				assert.NoError(t, err)
+			}
+			require.NotNil(t, resp)
+			assert.Equal(t, tc.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func createServer(t *testing.T, broker *stream.Broker, opts ...stream.HandlerOption) *httptest.Server {
+	t.Helper()
+
+	handler := stream.NewHandler(broker, opts...)
+
+	router := mux.NewRouter()
+	router.HandleFunc("/users/{key}/stream", handler.ServeSSE).Methods("GET")
+	router.HandleFunc("/users/{key}/stream/ws", handler.ServeWebSocket).Methods("GET")
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func wsURL(srv *httptest.Server, path string) string {
+	return "ws" + strings.TrimPrefix(srv.URL, "http") + path
+}
+
+func dial(t *testing.T, srv *httptest.Server, path string) *websocket.Conn {
+	t.Helper()
+
+	conn, resp, err := websocket.DefaultDialer.DialContext(t.Context(), wsURL(srv, path), nil)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func readLine(t *testing.T, reader *bufio.Reader) string {
+	t.Helper()
+
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+
+	return strings.TrimSuffix(line, "\n")
+}
+
+// readSSE reads a single notification from the stream
+func readSSE(t *testing.T, reader *bufio.Reader) (string, stream.Event) {
+	t.Helper()
+
+	id, ok := strings.CutPrefix(readLine(t, reader), "id: ")
+	require.True(t, ok)
+	assert.Equal(t, "event: notification", readLine(t, reader))
+
+	data, ok := strings.CutPrefix(readLine(t, reader), "data: ")
+	require.True(t, ok)
+	assert.Empty(t, readLine(t, reader))
+
+	var evt stream.Event
+	require.NoError(t, json.Unmarshal([]byte(data), &evt))
+	assert.Equal(t, id, evt.ID)
+
+	return id, evt
+}
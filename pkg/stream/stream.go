@@ -0,0 +1,86 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package stream pushes notifications to connected clients in real time, over Server-Sent Events or WebSockets
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier"
+	"github.com/seatgeek/mailroom/pkg/user"
+)
+
+// Event is a notification sent to a user's connected clients
+type Event struct {
+	// ID is assigned by the Broker and increases with every published event
+	ID        string                       `json:"id"`
+	UserKey   string                       `json:"-"`
+	Context   notification.EnvelopeContext `json:"context"`
+	Message   string                       `json:"message"`
+	CreatedAt time.Time                    `json:"created_at"`
+}
+
+// NewEvent creates a new Event for the given user
+func NewEvent(userKey string, n event.Notification, message string) Event {
+	return Event{
+		UserKey:   userKey,
+		Context:   notification.NewEnvelopeContext(n.Context()),
+		Message:   message,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Transport publishes notifications to the Broker for the matching user.User
+// Delivery is best-effort: users without a connected client only receive the event if they reconnect
+// before it falls out of the Broker's history.
+type Transport struct {
+	key       event.TransportKey
+	broker    *Broker
+	userStore user.Store
+}
+
+var _ notifier.Transport = &Transport{}
+
+// NewTransport creates a new streaming Transport
+// Recipients are looked up in the user.Store since subscribers connect by user.User.Key.
+func NewTransport(key event.TransportKey, broker *Broker, userStore user.Store) *Transport {
+	return &Transport{
+		key:       key,
+		broker:    broker,
+		userStore: userStore,
+	}
+}
+
+// Push publishes the notification to the recipient's subscribers without waiting for them
+func (t *Transport) Push(ctx context.Context, n event.Notification) error {
+	if t.userStore == nil {
+		return notifier.Permanent(errors.New("no user store configured"))
+	}
+
+	u, err := t.userStore.Find(ctx, n.Recipient())
+	if err != nil {
+		if errors.Is(err, user.ErrUserNotFound) {
+			return notifier.Permanent(fmt.Errorf("recipient cannot subscribe to a stream: %w", err))
+		}
+		return err
+	}
+
+	message := n.Render(t.key)
+	if message == "" {
+		return notifier.Permanent(errors.New("message is empty"))
+	}
+
+	t.broker.Publish(NewEvent(u.Key, n, message))
+	return nil
+}
+
+func (t *Transport) Key() event.TransportKey {
+	return t.key
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package stream_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cenkalti/backoff/v5"
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/stream"
+	"github.com/seatgeek/mailroom/pkg/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport_Push(t *testing.T) {
+	t.Parallel()
+
+	broker := stream.NewBroker()
+	users := user.NewInMemoryStore(user.New("codell", user.WithIdentifier(identifier.New(identifier.GenericEmail, "codell@example.com"))))
+	transport := stream.NewTransport("stream", broker, users)
+
+	sub, _ := broker.Subscribe("codell", "")
+
+	n := notification.NewBuilder(event.Context{
+		ID:     "a1c11a53-c4be-488f-89b6-f83bf2d48dab",
+		Type:   "com.example.test",
+		Labels: map[string]string{"project": "mailroom"},
+	}).
+		WithRecipientIdentifiers(identifier.New("gitlab.com/email", "codell@example.com")).
+		WithDefaultMessage("hello world").
+		WithMessageForTransport("stream", "hello stream").
+		Build()
+
+	require.NoError(t, transport.Push(t.Context(), n))
+
+	evt := <-sub.Events()
+	assert.Equal(t, "1", evt.ID)
+	assert.Equal(t, "codell", evt.UserKey)
+	assert.Equal(t, event.ID("a1c11a53-c4be-488f-89b6-f83bf2d48dab"), evt.Context.ID)
+	assert.Equal(t, map[string]string{"project": "mailroom"}, evt.Context.Labels)
+	assert.Equal(t, "hello stream", evt.Message)
+	assert.False(t, evt.CreatedAt.IsZero())
+}
+
+func TestTransport_Push_WithoutSubscribers(t *testing.T) {
+	t.Parallel()
+
+	broker := stream.NewBroker()
+	users := user.NewInMemoryStore(user.New("codell", user.WithIdentifier(identifier.New(identifier.GenericUsername, "codell"))))
+	transport := stream.NewTransport("stream", broker, users)
+
+	n := notification.NewBuilder(event.Context{Type: "com.example.test"}).
+		WithRecipientIdentifiers(identifier.New(identifier.GenericUsername, "codell")).
+		WithDefaultMessage("hello").
+		Build()
+
+	// Nobody is listening, but the event is kept for clients that reconnect
+	require.NoError(t, transport.Push(t.Context(), n))
+
+	_, missed := broker.Subscribe("codell", "0")
+	assert.Len(t, missed, 1)
+}
+
+func TestTransport_Push_Errors(t *testing.T) {
+	t.Parallel()
+
+	users := user.NewInMemoryStore(user.New("codell", user.WithIdentifier(identifier.New(identifier.GenericUsername, "codell"))))
+
+	tests := []struct {
+		name      string
+		userStore user.Store
+		recipient identifier.Identifier
+		message   string
+		wantErr   string
+	}{
+		{
+			name:      "no user store",
+			userStore: nil,
+			recipient: identifier.New(identifier.GenericUsername, "codell"),
+			message:   "hello",
+			wantErr:   "no user store configured",
+		},
+		{
+			name:      "unknown user",
+			userStore: users,
+			recipient: identifier.New(identifier.GenericUsername, "rufus"),
+			message:   "hello",
+			wantErr:   "recipient cannot subscribe to a stream",
+		},
+		{
+			name:      "empty message",
+			userStore: users,
+			recipient: identifier.New(identifier.GenericUsername, "codell"),
+			message:   "",
+			wantErr:   "message is empty",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			transport := stream.NewTransport("stream", stream.NewBroker(), tc.userStore)
+
+			err := transport.Push(t.Context(), notification.NewBuilder(event.Context{Type: "com.example.test"}).
+				WithRecipientIdentifiers(tc.recipient).
+				WithDefaultMessage(tc.message).
+				Build())
+			assert.ErrorContains(t, err, tc.wantErr)
+
+			var permanent *backoff.PermanentError
+			assert.True(t, errors.As(err, &permanent))
+		})
+	}
+}
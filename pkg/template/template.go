@@ -0,0 +1,280 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package template renders notification messages from templates, so that copy can be changed without changing any Go
+//
+// Templates are organized by event type, with one file per transport:
+//
+//	com.gitlab.merge_request.opened/
+//	    default.txt        # the default message (text/template)
+//	    slack.txt          # the message for the "slack" transport
+//	    email_subject.txt  # the email subject
+//	    email.html         # the email's HTML body (html/template)
+//
+// With WithCatalog, templates can translate messages into the recipient's language with {{ t "key" args... }}
+// and format times in the recipient's time zone with {{ localtime .Context.Time }}.
+package template
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/i18n"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/validation"
+)
+
+const (
+	// Default is the name of the template for the default message
+	Default = "default.txt"
+	// EmailSubject is the name of the template for the email subject
+	EmailSubject = "email_subject.txt"
+	// EmailHTML is the name of the template for the email's HTML body
+	EmailHTML = "email.html"
+)
+
+// ErrTemplateNotFound is returned when rendering a template which doesn't exist
+var ErrTemplateNotFound = errors.New("template not found")
+
+// Data is what templates are rendered with
+type Data struct {
+	// Context is the event's metadata, like {{ .Context.Subject }}
+	Context event.Context
+	// Payload is the event's payload, like {{ .Payload.User.Name }}
+	Payload event.Payload
+	// Recipient is who the notification is for, like {{ .Recipient.MustGet "email" }}
+	Recipient identifier.Set
+	// Locale is the recipient's locale (like "fr-CA"), used by the t and localtime functions
+	Locale string
+	// Location is the recipient's time zone, used by the localtime function
+	Location *time.Location
+}
+
+// executor renders a template with the given per-render functions
+type executor func(w io.Writer, data Data, funcs map[string]any) error
+
+// Registry holds the templates for each event type
+type Registry struct {
+	funcs     map[string]any
+	catalog   *i18n.Catalog
+	required  map[event.Type][]string
+	templates map[event.Type]map[string]executor
+	err       error
+}
+
+var _ validation.Validator = &Registry{}
+
+// Option configures a Registry
+type Option func(*Registry)
+
+// WithFuncs makes the given functions available to every template
+func WithFuncs(funcs map[string]any) Option {
+	return func(r *Registry) {
+		maps.Copy(r.funcs, funcs)
+	}
+}
+
+// WithCatalog makes the t function translate messages using the given catalog
+func WithCatalog(catalog *i18n.Catalog) Option {
+	return func(r *Registry) {
+		r.catalog = catalog
+	}
+}
+
+// WithRequired makes validation fail unless the event type has the named templates (or at least Default, if none are given)
+func WithRequired(eventType event.Type, names ...string) Option {
+	return func(r *Registry) {
+		if len(names) == 0 {
+			names = []string{Default}
+		}
+		r.required[eventType] = append(r.required[eventType], names...)
+	}
+}
+
+// New loads the templates in fsys, such as an embed.FS
+// Files are named <event type>/<name>.txt for text templates or <event type>/<name>.html for HTML templates; anything else is ignored.
+// Any problems loading them are reported by Validate.
+func New(fsys fs.FS, opts ...Option) *Registry {
+	r := &Registry{
+		funcs:     make(map[string]any),
+		required:  make(map[event.Type][]string),
+		templates: make(map[event.Type]map[string]executor),
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	var errs []error
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		dir, file := path.Split(name)
+		if d.IsDir() || strings.Count(name, "/") != 1 {
+			return nil
+		}
+
+		// Keep going, so that every broken template is reported at once
+		if err = r.load(fsys, event.Type(strings.TrimSuffix(dir, "/")), file); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+
+		return nil
+	})
+
+	r.err = errors.Join(append(errs, err)...)
+
+	return r
+}
+
+func (r *Registry) load(fsys fs.FS, eventType event.Type, file string) error {
+	src, err := fs.ReadFile(fsys, path.Join(string(eventType), file))
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := r.parse(file, string(src))
+	if err != nil || tmpl == nil {
+		return err
+	}
+
+	if r.templates[eventType] == nil {
+		r.templates[eventType] = make(map[string]executor)
+	}
+	r.templates[eventType][file] = tmpl
+
+	return nil
+}
+
+// FromDir loads the templates in the given directory (see New)
+func FromDir(dir string, opts ...Option) *Registry {
+	return New(os.DirFS(dir), opts...)
+}
+
+// parse parses the template according to its extension, returning nil if it isn't a template
+// The parsed template is never executed itself; each render executes a clone with the recipient's locale functions.
+func (r *Registry) parse(name string, src string) (executor, error) {
+	funcs := r.localeFuncs(Data{})
+
+	switch path.Ext(name) {
+	case ".txt":
+		tmpl, err := texttemplate.New(name).Option("missingkey=error").Funcs(r.funcs).Funcs(funcs).Parse(src)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(w io.Writer, data Data, funcs map[string]any) error {
+			clone, err := tmpl.Clone()
+			if err != nil {
+				return err
+			}
+			return clone.Funcs(funcs).Execute(w, data)
+		}, nil
+	case ".html":
+		tmpl, err := htmltemplate.New(name).Option("missingkey=error").Funcs(r.funcs).Funcs(funcs).Parse(src)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(w io.Writer, data Data, funcs map[string]any) error {
+			clone, err := tmpl.Clone()
+			if err != nil {
+				return err
+			}
+			return clone.Funcs(funcs).Execute(w, data)
+		}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// localeFuncs returns the template functions which depend on the recipient's locale and time zone
+func (r *Registry) localeFuncs(data Data) map[string]any {
+	location := data.Location
+	if location == nil {
+		location = time.UTC
+	}
+
+	return map[string]any{
+		// t translates the message with the given key, like {{ t "pipeline.failed" .Payload.ID }}
+		"t": func(key string, args ...any) string {
+			if r.catalog == nil {
+				return key
+			}
+			return r.catalog.Translate(data.Locale, key, args...)
+		},
+		// localtime formats a time in the recipient's time zone, like {{ localtime .Context.Time }}
+		"localtime": func(t time.Time) string {
+			layout := i18n.DefaultTimeLayout
+			if r.catalog != nil {
+				layout = r.catalog.TimeLayout(data.Locale)
+			}
+			return t.In(location).Format(layout)
+		},
+	}
+}
+
+// Has returns whether the event type has the named template
+func (r *Registry) Has(eventType event.Type, name string) bool {
+	_, ok := r.templates[eventType][name]
+	return ok
+}
+
+// Names returns the names of the event type's templates, in order
+func (r *Registry) Names(eventType event.Type) []string {
+	return slices.Sorted(maps.Keys(r.templates[eventType]))
+}
+
+// Render renders the event type's named template, with leading and trailing whitespace removed
+func (r *Registry) Render(eventType event.Type, name string, data Data) (string, error) {
+	tmpl, ok := r.templates[eventType][name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s/%s", ErrTemplateNotFound, eventType, name)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl(&buf, data, r.localeFuncs(data)); err != nil {
+		return "", fmt.Errorf("failed to render %s/%s: %w", eventType, name, err)
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// Validate returns an error if any templates failed to load, if any required templates are missing,
+// or if the catalog (see WithCatalog) is invalid
+func (r *Registry) Validate(ctx context.Context) error {
+	var errs []error
+	if r.err != nil {
+		errs = append(errs, fmt.Errorf("failed to load templates: %w", r.err))
+	}
+
+	if r.catalog != nil {
+		errs = append(errs, r.catalog.Validate(ctx))
+	}
+
+	for _, eventType := range slices.Sorted(maps.Keys(r.required)) {
+		for _, name := range r.required[eventType] {
+			if !r.Has(eventType, name) {
+				errs = append(errs, fmt.Errorf("%w: %s/%s", ErrTemplateNotFound, eventType, name))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,247 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package template_test
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/i18n"
+	"github.com/seatgeek/mailroom/pkg/template"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const buildFailed event.Type = "com.example.build.failed"
+
+type build struct {
+	Author string
+	Branch string
+	URL    string
+}
+
+var payload = build{Author: "Codell", Branch: "fix-<bug>", URL: "https://ci.example.com/builds/1?tab=logs&raw=1"}
+
+func TestFromDir(t *testing.T) {
+	t.Parallel()
+
+	templates := template.FromDir("testdata")
+	require.NoError(t, templates.Validate(t.Context()))
+
+	// Other files are ignored
+	assert.Equal(t, []string{"default.txt", "email.html", "email_subject.txt", "slack.txt"}, templates.Names(buildFailed))
+	assert.Empty(t, templates.Names("com.example.unknown"))
+}
+
+func TestRegistry_Render(t *testing.T) {
+	t.Parallel()
+
+	templates := template.FromDir("testdata")
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "default.txt",
+			want: "Codell, your build of fix-<bug> failed",
+		},
+		{
+			name: "slack.txt",
+			want: ":x: Your build of `fix-<bug>` failed: <https://ci.example.com/builds/1?tab=logs&raw=1|view logs>",
+		},
+		{
+			name: "email.html",
+			want: "<p>Hi Codell,</p>\n<p>Your build of <a href=\"https://ci.example.com/builds/1?tab=logs&amp;raw=1\">fix-&lt;bug&gt;</a> failed.</p>",
+		},
+		{
+			name:    "discord.txt",
+			wantErr: template.ErrTemplateNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := templates.Render(buildFailed, tc.name, template.Data{Payload: payload})
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRegistry_Render_Errors(t *testing.T) {
+	t.Parallel()
+
+	templates := template.New(fstest.MapFS{
+		"com.example.build.failed/default.txt": {Data: []byte("{{ .Payload.Commit }}")},
+		"com.example.build.failed/slack.txt":   {Data: []byte("{{ .Payload.commit }}")},
+	})
+	require.NoError(t, templates.Validate(t.Context()))
+
+	_, err := templates.Render(buildFailed, "default.txt", template.Data{Payload: payload})
+	assert.ErrorContains(t, err, "failed to render com.example.build.failed/default.txt")
+
+	// Missing keys are errors rather than "<no value>"
+	_, err = templates.Render(buildFailed, "slack.txt", template.Data{Payload: map[string]any{}})
+	assert.ErrorContains(t, err, `map has no entry for key "commit"`)
+}
+
+func TestWithFuncs(t *testing.T) {
+	t.Parallel()
+
+	templates := template.New(fstest.MapFS{
+		"com.example.build.failed/default.txt": {Data: []byte("{{ upper .Payload.Branch }}")},
+	}, template.WithFuncs(map[string]any{"upper": strings.ToUpper}))
+	require.NoError(t, templates.Validate(t.Context()))
+
+	got, err := templates.Render(buildFailed, template.Default, template.Data{Payload: payload})
+	require.NoError(t, err)
+	assert.Equal(t, "FIX-<BUG>", got)
+}
+
+func TestWithCatalog(t *testing.T) {
+	t.Parallel()
+
+	catalog := i18n.FromMap(map[string]map[string]string{
+		"en": {"build.failed": "%s, your build failed at %s"},
+		"fr": {"build.failed": "%s, votre build a échoué le %s", i18n.TimeLayoutKey: "02/01/2006 15:04"},
+	}, "en")
+
+	templates := template.New(fstest.MapFS{
+		"com.example.build.failed/default.txt": {Data: []byte(`{{ t "build.failed" .Payload.Author (localtime .Context.Time) }}`)},
+	}, template.WithCatalog(catalog))
+	require.NoError(t, templates.Validate(t.Context()))
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+
+	evtCtx := event.Context{Type: buildFailed, Time: time.Date(2025, 3, 14, 15, 9, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name     string
+		locale   string
+		location *time.Location
+		want     string
+	}{
+		{
+			name: "default locale in UTC",
+			want: "Codell, your build failed at Mar 14, 2025 3:09 PM UTC",
+		},
+		{
+			name:     "recipient's locale and time zone",
+			locale:   "fr-CA",
+			location: paris,
+			want:     "Codell, votre build a échoué le 14/03/2025 16:09",
+		},
+		{
+			name:     "unknown locale falls back to the default",
+			locale:   "de",
+			location: paris,
+			want:     "Codell, your build failed at Mar 14, 2025 4:09 PM CET",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := templates.Render(buildFailed, template.Default, template.Data{
+				Context:  evtCtx,
+				Payload:  payload,
+				Locale:   tc.locale,
+				Location: tc.location,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRegistry_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		opts    []template.Option
+		wantErr []string
+	}{
+		{
+			name: "valid",
+			fsys: fstest.MapFS{
+				"com.example.build.failed/default.txt": {Data: []byte("Build failed")},
+				"com.example.build.passed/slack.txt":   {Data: []byte("Build passed")},
+			},
+			opts: []template.Option{
+				template.WithRequired(buildFailed),
+				template.WithRequired("com.example.build.passed", "slack.txt"),
+			},
+		},
+		{
+			name: "bad syntax",
+			fsys: fstest.MapFS{
+				"com.example.build.failed/default.txt": {Data: []byte("{{ .Payload.Branch ")},
+				"com.example.build.failed/email.html":  {Data: []byte("{{ end }}")},
+				"com.example.build.failed/slack.txt":   {Data: []byte("{{ unknownFunc }}")},
+			},
+			wantErr: []string{
+				"com.example.build.failed/default.txt: template: default.txt:1: unclosed action",
+				"com.example.build.failed/email.html: template: email.html:1: unexpected {{end}}",
+				`com.example.build.failed/slack.txt: template: slack.txt:1: function "unknownFunc" not defined`,
+			},
+		},
+		{
+			name: "missing templates",
+			fsys: fstest.MapFS{
+				"com.example.build.failed/slack.txt": {Data: []byte("Build failed")},
+			},
+			opts: []template.Option{
+				template.WithRequired(buildFailed),
+				template.WithRequired("com.example.build.passed", "slack.txt", "email.html"),
+			},
+			wantErr: []string{
+				"template not found: com.example.build.failed/default.txt",
+				"template not found: com.example.build.passed/slack.txt",
+				"template not found: com.example.build.passed/email.html",
+			},
+		},
+		{
+			name: "invalid catalog",
+			fsys: fstest.MapFS{
+				"com.example.build.failed/default.txt": {Data: []byte(`{{ t "build.failed" }}`)},
+			},
+			opts: []template.Option{
+				template.WithCatalog(i18n.FromMap(map[string]map[string]string{"fr": {"build.failed": "Échec du build"}}, "en")),
+			},
+			wantErr: []string{
+				`message catalog has no messages for the default locale "en"`,
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := template.New(tc.fsys, tc.opts...).Validate(t.Context())
+
+			if len(tc.wantErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			for _, want := range tc.wantErr {
+				assert.ErrorContains(t, err, want)
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"slices"
 
 	"github.com/gorilla/mux"
+	"github.com/seatgeek/mailroom/internal/httputil"
 	"github.com/seatgeek/mailroom/pkg/event"
 	"github.com/seatgeek/mailroom/pkg/notification"
 	"github.com/seatgeek/mailroom/pkg/notifier/preference"
@@ -62,7 +63,7 @@ func (ph *PreferencesHandler) GetPreferences(writer http.ResponseWriter, request
 	hydratedUserPreferences := ph.buildCurrentUserPreferences(request.Context(), preference.Chain{u.Preferences, ph.defaults})
 	resp := preferencesBody{Preferences: hydratedUserPreferences}
 
-	writeJson(request.Context(), writer, resp)
+	httputil.WriteJSON(request.Context(), writer, resp)
 }
 
 // UpdatePreferences updates the preferences for a given user
@@ -90,7 +91,7 @@ func (ph *PreferencesHandler) UpdatePreferences(writer http.ResponseWriter, requ
 		return
 	}
 
-	writeJson(request.Context(), writer, preferencesBody{
+	httputil.WriteJSON(request.Context(), writer, preferencesBody{
 		Preferences: ph.buildCurrentUserPreferences(request.Context(), preference.Chain{req.Preferences, ph.defaults}),
 	})
 }
@@ -178,12 +179,5 @@ func (ph *PreferencesHandler) ListOptions(writer http.ResponseWriter, request *h
 		Transports: transports,
 	}
 
-	writeJson(request.Context(), writer, resp)
-}
-
-func writeJson(ctx context.Context, writer http.ResponseWriter, value any) {
-	if err := json.NewEncoder(writer).Encode(value); err != nil {
-		slog.ErrorContext(ctx, "failed to encode response", "error", err)
-		writer.WriteHeader(500)
-	}
+	httputil.WriteJSON(request.Context(), writer, resp)
 }
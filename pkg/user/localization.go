@@ -0,0 +1,58 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/i18n"
+)
+
+// LocalizationProcessor is a processor that renders each notification in its recipient's
+// locale and time zone, as stored in the user.Store
+// Only notifications implementing i18n.Localizable (like those built with templates) are affected.
+type LocalizationProcessor struct {
+	userStore Store
+}
+
+// NewLocalizationProcessor creates a new LocalizationProcessor.
+func NewLocalizationProcessor(us Store) *LocalizationProcessor {
+	if us == nil {
+		panic("user.Store cannot be nil for LocalizationProcessor")
+	}
+
+	return &LocalizationProcessor{userStore: us}
+}
+
+// Process localizes each notification for its recipient.
+func (p *LocalizationProcessor) Process(ctx context.Context, evt event.Event, notifications []event.Notification) ([]event.Notification, error) {
+	for _, n := range notifications {
+		localizable, ok := n.(i18n.Localizable)
+		if !ok || n.Recipient() == nil {
+			continue
+		}
+
+		foundUser, err := p.userStore.Find(ctx, n.Recipient())
+		if err != nil {
+			if errors.Is(err, ErrUserNotFound) {
+				slog.DebugContext(ctx, "user not found for localization", "eventID", evt.ID, "recipient", n.Recipient().String())
+			} else {
+				slog.WarnContext(ctx, "error finding user for localization", "eventID", evt.ID, "recipient", n.Recipient().String(), "error", err)
+			}
+			continue
+		}
+
+		if foundUser.Locale == "" && foundUser.TimeZone == "" {
+			continue
+		}
+
+		localizable.Localize(foundUser.Locale, foundUser.Location())
+	}
+
+	return notifications, nil
+}
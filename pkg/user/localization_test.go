@@ -0,0 +1,117 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package user_test
+
+import (
+	"errors"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/i18n"
+	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/template"
+	"github.com/seatgeek/mailroom/pkg/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalizationProcessor_Process(t *testing.T) {
+	t.Parallel()
+
+	evt := event.Event{Context: event.Context{ID: "test-event"}}
+	id := identifier.New("email", "test@example.com")
+
+	templates := template.New(fstest.MapFS{
+		"some-event/default.txt": {Data: []byte(`{{ t "greeting" }} {{ localtime .Context.Time }}`)},
+	}, template.WithCatalog(i18n.FromMap(map[string]map[string]string{
+		"en": {"greeting": "Hello!"},
+		"fr": {"greeting": "Bonjour !", i18n.TimeLayoutKey: "02/01/2006 15:04"},
+	}, "en")))
+
+	localizable := func() event.Notification {
+		return notification.NewBuilder(event.Context{ID: "some-event", Type: "some-event", Time: time.Date(2025, 3, 14, 15, 9, 0, 0, time.UTC)}).
+			WithRecipient(identifier.NewSet(id)).
+			WithTemplates(templates, nil).
+			Build()
+	}
+
+	testCases := []struct {
+		name           string
+		notification   event.Notification
+		mockStoreSetup func(mockStore *user.MockStore)
+		want           string
+	}{
+		{
+			name:         "user's locale and time zone",
+			notification: localizable(),
+			mockStoreSetup: func(mockStore *user.MockStore) {
+				foundUser := user.New("test-user", user.WithLocale("fr-CA"), user.WithTimeZone("America/Toronto"))
+				mockStore.On("Find", t.Context(), identifier.NewSet(id)).Return(foundUser, nil).Once()
+			},
+			want: "Bonjour ! 14/03/2025 11:09",
+		},
+		{
+			name:         "user without a locale",
+			notification: localizable(),
+			mockStoreSetup: func(mockStore *user.MockStore) {
+				mockStore.On("Find", t.Context(), identifier.NewSet(id)).Return(user.New("test-user"), nil).Once()
+			},
+			want: "Hello! Mar 14, 2025 3:09 PM UTC",
+		},
+		{
+			name:         "user not found",
+			notification: localizable(),
+			mockStoreSetup: func(mockStore *user.MockStore) {
+				mockStore.On("Find", t.Context(), identifier.NewSet(id)).Return(nil, user.ErrUserNotFound).Once()
+			},
+			want: "Hello! Mar 14, 2025 3:09 PM UTC",
+		},
+		{
+			name:         "error finding user",
+			notification: localizable(),
+			mockStoreSetup: func(mockStore *user.MockStore) {
+				mockStore.On("Find", t.Context(), identifier.NewSet(id)).Return(nil, errors.New("some db error")).Once()
+			},
+			want: "Hello! Mar 14, 2025 3:09 PM UTC",
+		},
+		{
+			name:         "notification without templates",
+			notification: notificationFor("some-event", identifier.NewSet(id)),
+			mockStoreSetup: func(mockStore *user.MockStore) {
+				foundUser := user.New("test-user", user.WithLocale("fr"))
+				mockStore.On("Find", t.Context(), identifier.NewSet(id)).Return(foundUser, nil).Once()
+			},
+			want: "hello world",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockUserStore := user.NewMockStore(t)
+			tc.mockStoreSetup(mockUserStore)
+
+			processor := user.NewLocalizationProcessor(mockUserStore)
+
+			result, err := processor.Process(t.Context(), evt, []event.Notification{tc.notification})
+
+			assert.NoError(t, err)
+			assert.Len(t, result, 1)
+			assert.Equal(t, tc.want, result[0].Render("email"))
+			mockUserStore.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNewLocalizationProcessor_NilStore(t *testing.T) {
+	t.Parallel()
+
+	assert.PanicsWithValue(t, "user.Store cannot be nil for LocalizationProcessor", func() {
+		user.NewLocalizationProcessor(nil)
+	})
+}
@@ -26,6 +26,9 @@ type UserModel struct {
 	// Emails contains the subset of Identifiers that have Kind=="email" (for easier fallback lookup)
 	Emails []string `gorm:"serializer:json"`
 
+	Locale   string
+	TimeZone string
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
@@ -41,6 +44,8 @@ func (u *UserModel) ToUser() *user.User {
 		Key:         u.Key,
 		Preferences: u.Preferences,
 		Identifiers: identifier.NewSetFromMap(u.Identifiers),
+		Locale:      u.Locale,
+		TimeZone:    u.TimeZone,
 	}
 }
 
@@ -55,22 +60,29 @@ func NewPostgresStore(db *gorm.DB) *Store {
 
 // Add upserts a user to the postgres store
 func (s *Store) Add(ctx context.Context, u *user.User) error {
-	var emails []string
-	for _, id := range u.Identifiers.ToList() {
-		if id.Kind() == identifier.KindEmail {
-			emails = append(emails, id.Value)
-		}
-	}
-
 	result := s.db.WithContext(ctx).Save(&UserModel{
 		Key:         u.Key,
 		Preferences: u.Preferences,
 		Identifiers: u.Identifiers.ToMap(),
-		Emails:      emails,
+		Emails:      emails(u.Identifiers),
+		Locale:      u.Locale,
+		TimeZone:    u.TimeZone,
 	})
 	return result.Error
 }
 
+// emails returns the values of all email identifiers
+func emails(ids identifier.Set) []string {
+	var emails []string
+	for _, id := range ids.ToList() {
+		if id.Kind() == identifier.KindEmail {
+			emails = append(emails, id.Value)
+		}
+	}
+
+	return emails
+}
+
 // Find implements user.Store.
 func (s *Store) Find(ctx context.Context, possibleIdentifiers identifier.Set) (*user.User, error) {
 	if possibleIdentifiers.Len() == 0 {
@@ -159,4 +171,53 @@ func (s *Store) SetPreferences(ctx context.Context, key string, prefs preference
 	return s.db.WithContext(ctx).Model(&UserModel{}).Where("key = ?", key).Update("preferences", prefs).Error
 }
 
-var _ user.Store = &Store{}
+// AddIdentifier implements user.IdentifierAdder.
+func (s *Store) AddIdentifier(ctx context.Context, possibleIdentifiers identifier.Set, id identifier.Identifier) error {
+	u, err := s.Find(ctx, possibleIdentifiers)
+	if err != nil {
+		return err
+	}
+
+	ids := u.Identifiers.Copy()
+	ids.Add(id)
+
+	// Only update the identifiers, so that concurrent preference changes aren't lost
+	return s.db.WithContext(ctx).Model(&UserModel{}).Where("key = ?", u.Key).
+		Select("identifiers", "emails").
+		Updates(&UserModel{Identifiers: ids.ToMap(), Emails: emails(ids)}).Error
+}
+
+// RemoveIdentifierValue implements user.IdentifierValueRemover.
+func (s *Store) RemoveIdentifierValue(ctx context.Context, namespaceAndKind identifier.NamespaceAndKind, value string) error {
+	// Narrow down the candidates in SQL, then remove the exact value in Go
+	var users []UserModel
+	if err := s.db.WithContext(ctx).Where("identifiers ->> ? LIKE ?", string(namespaceAndKind), "%"+value+"%").Find(&users).Error; err != nil {
+		return err
+	}
+
+	for _, u := range users {
+		current := identifier.New(namespaceAndKind, u.Identifiers[namespaceAndKind])
+		remaining := current.Without(value)
+		if remaining == current {
+			continue
+		}
+
+		if remaining.Value != "" {
+			u.Identifiers[namespaceAndKind] = remaining.Value
+		} else {
+			delete(u.Identifiers, namespaceAndKind)
+		}
+
+		if err := s.db.WithContext(ctx).Model(&UserModel{}).Where("key = ?", u.Key).Update("identifiers", u.Identifiers).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+var (
+	_ user.Store                  = &Store{}
+	_ user.IdentifierValueRemover = &Store{}
+	_ user.IdentifierAdder        = &Store{}
+)
@@ -35,6 +35,8 @@ func TestPostgresStore_Add(t *testing.T) {
 	u := user.New(
 		"codell",
 		user.WithIdentifier(identifier.New("email", "codell@seatgeek.com")),
+		user.WithLocale("fr-CA"),
+		user.WithTimeZone("America/Toronto"),
 	)
 
 	err = store.Add(t.Context(), u)
@@ -316,6 +318,64 @@ func TestPostgresStore_SetPreferences(t *testing.T) {
 	assert.Equal(t, expectedUser, got)
 }
 
+func TestPostgresStore_AddIdentifier(t *testing.T) {
+	t.Parallel()
+
+	store := createDatastore(t)
+
+	email := identifier.New("email", "codell@seatgeek.com")
+	slackID := identifier.New("slack.com/id", "U123")
+	slackEmail := identifier.New("slack.com/email", "codell@slack.example.com")
+	prefs := preference.Map{"com.example.type": {"slack": false}}
+
+	assert.NoError(t, store.Add(t.Context(), user.New("codell", user.WithIdentifier(email), user.WithPreferences(prefs))))
+
+	assert.NoError(t, store.AddIdentifier(t.Context(), identifier.NewSet(email), slackID))
+	assert.NoError(t, store.AddIdentifier(t.Context(), identifier.NewSet(slackID), slackEmail))
+
+	got, err := store.Get(t.Context(), "codell")
+	assert.NoError(t, err)
+	assert.Equal(t, identifier.NewSet(email, slackID, slackEmail), got.Identifiers)
+	assert.Equal(t, prefs, got.Preferences)
+
+	// New email identifiers can be used to find the user
+	got, err = store.GetByIdentifier(t.Context(), identifier.New("email", "codell@slack.example.com"))
+	assert.NoError(t, err)
+	assert.Equal(t, "codell", got.Key)
+
+	err = store.AddIdentifier(t.Context(), identifier.NewSet(identifier.New("email", "rufus@seatgeek.com")), slackID)
+	assert.ErrorIs(t, err, user.ErrUserNotFound)
+}
+
+func TestPostgresStore_RemoveIdentifierValue(t *testing.T) {
+	t.Parallel()
+
+	store := createDatastore(t)
+
+	devices := identifier.NamespaceAndKind("apple.com/device_token")
+	email := identifier.New("email", "codell@seatgeek.com")
+
+	assert.NoError(t, store.Add(t.Context(), user.New("codell", user.WithIdentifier(email), user.WithIdentifier(identifier.NewMulti(devices, "abc", "def")))))
+	assert.NoError(t, store.Add(t.Context(), user.New("zhammer", user.WithIdentifier(identifier.NewMulti(devices, "def")))))
+	assert.NoError(t, store.Add(t.Context(), user.New("rufus", user.WithIdentifier(identifier.NewMulti(devices, "abcdefg")))))
+
+	err := store.RemoveIdentifierValue(t.Context(), devices, "def")
+	assert.NoError(t, err)
+
+	got, err := store.Get(t.Context(), "codell")
+	assert.NoError(t, err)
+	assert.Equal(t, identifier.NewSet(email, identifier.New(devices, "abc")), got.Identifiers)
+
+	got, err = store.Get(t.Context(), "zhammer")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, got.Identifiers.Len())
+
+	// Values which merely contain the removed one are left alone
+	got, err = store.Get(t.Context(), "rufus")
+	assert.NoError(t, err)
+	assert.Equal(t, identifier.NewSet(identifier.New(devices, "abcdefg")), got.Identifiers)
+}
+
 func createDatastore(t *testing.T) *postgres.Store {
 	t.Helper()
 
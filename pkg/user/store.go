@@ -39,6 +39,22 @@ type Store interface {
 	SetPreferences(ctx context.Context, key string, prefs preference.Map) error
 }
 
+// IdentifierValueRemover is an optional interface for Stores which can remove a single value from a multi-valued
+// identifier (see identifier.NewMulti), such as a device token which is no longer registered
+type IdentifierValueRemover interface {
+	// RemoveIdentifierValue removes the value from every user's identifier of the given namespace+kind
+	// The identifier is removed entirely once it has no values left.
+	RemoveIdentifierValue(ctx context.Context, namespaceAndKind identifier.NamespaceAndKind, value string) error
+}
+
+// IdentifierAdder is an optional interface for Stores which can save newly discovered identifiers,
+// such as a Slack ID looked up by email address
+type IdentifierAdder interface {
+	// AddIdentifier adds the identifier to the user matching any of the possible identifiers (as in Store.Find),
+	// replacing any existing identifier with the same namespace+kind, or returns ErrUserNotFound
+	AddIdentifier(ctx context.Context, possibleIdentifiers identifier.Set, id identifier.Identifier) error
+}
+
 // InMemoryStore is a simple in-memory implementation of the Store interface
 // This is especially useful for testing, but can also be used for simple applications which don't need durable preference storage.
 type InMemoryStore struct {
@@ -46,7 +62,11 @@ type InMemoryStore struct {
 	mu    sync.RWMutex
 }
 
-var _ Store = &InMemoryStore{}
+var (
+	_ Store                  = &InMemoryStore{}
+	_ IdentifierValueRemover = &InMemoryStore{}
+	_ IdentifierAdder        = &InMemoryStore{}
+)
 
 // NewInMemoryStore creates a new in-memory store with the given users
 func NewInMemoryStore(users ...*User) *InMemoryStore {
@@ -117,3 +137,42 @@ func (s *InMemoryStore) SetPreferences(ctx context.Context, key string, prefs pr
 	u.Preferences = prefs
 	return nil
 }
+
+func (s *InMemoryStore) AddIdentifier(ctx context.Context, possibleIdentifiers identifier.Set, id identifier.Identifier) error {
+	u, err := s.Find(ctx, possibleIdentifiers)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Copy the set, since it may be shared with notifications which were enriched from it
+	ids := u.Identifiers.ToMap()
+	ids[id.NamespaceAndKind] = id.Value
+	u.Identifiers = identifier.NewSetFromMap(ids)
+
+	return nil
+}
+
+func (s *InMemoryStore) RemoveIdentifierValue(_ context.Context, namespaceAndKind identifier.NamespaceAndKind, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, u := range s.users {
+		current, ok := u.Identifiers.Get(namespaceAndKind)
+		if !ok {
+			continue
+		}
+
+		ids := u.Identifiers.ToMap()
+		if remaining := identifier.New(namespaceAndKind, current).Without(value); remaining.Value != "" {
+			ids[namespaceAndKind] = remaining.Value
+		} else {
+			delete(ids, namespaceAndKind)
+		}
+		u.Identifiers = identifier.NewSetFromMap(ids)
+	}
+
+	return nil
+}
@@ -163,3 +163,47 @@ func TestInMemoryStore_Add(t *testing.T) {
 	assert.Equal(t, userB, u)
 	assert.NoError(t, err)
 }
+
+func TestInMemoryStore_AddIdentifier(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	email := identifier.New("email", "codell@seatgeek.com")
+	slackID := identifier.New("slack.com/id", "U123")
+
+	store := NewInMemoryStore(New("codell", WithIdentifier(email)))
+
+	assert.NoError(t, store.AddIdentifier(ctx, identifier.NewSet(identifier.New("gitlab.com/email", "codell@seatgeek.com")), slackID))
+
+	u, err := store.Get(ctx, "codell")
+	assert.NoError(t, err)
+	assert.Equal(t, identifier.NewSet(email, slackID), u.Identifiers)
+
+	err = store.AddIdentifier(ctx, identifier.NewSet(identifier.New("email", "rufus@seatgeek.com")), slackID)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestInMemoryStore_RemoveIdentifierValue(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	devices := identifier.NamespaceAndKind("apple.com/device_token")
+	email := identifier.New("email", "codell@seatgeek.com")
+
+	userA := New("codell", WithIdentifier(email), WithIdentifier(identifier.NewMulti(devices, "abc", "def")))
+	userB := New("zhammer", WithIdentifier(identifier.NewMulti(devices, "def")))
+
+	store := NewInMemoryStore(userA, userB)
+
+	assert.NoError(t, store.RemoveIdentifierValue(ctx, devices, "def"))
+
+	u, err := store.Get(ctx, "codell")
+	assert.NoError(t, err)
+	assert.Equal(t, identifier.NewSet(email, identifier.New(devices, "abc")), u.Identifiers)
+
+	u, err = store.Get(ctx, "zhammer")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, u.Identifiers.Len())
+}
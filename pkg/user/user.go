@@ -6,6 +6,9 @@
 package user
 
 import (
+	"log/slog"
+	"time"
+
 	"github.com/seatgeek/mailroom/pkg/event"
 	"github.com/seatgeek/mailroom/pkg/identifier"
 	"github.com/seatgeek/mailroom/pkg/notifier/preference"
@@ -19,6 +22,10 @@ type User struct {
 	// scope of external systems, e.g. a gitlab.com/id or a slack.com/id.
 	Identifiers identifier.Set
 	Preferences preference.Map
+	// Locale is the user's preferred language and region for notifications, like "fr-CA" (optional)
+	Locale string
+	// TimeZone is the IANA time zone that times are shown in, like "America/Toronto" (optional)
+	TimeZone string
 }
 
 // New creates a new User with the given options
@@ -70,6 +77,35 @@ func WithPreferences(p preference.Map) Option {
 	}
 }
 
+// WithLocale sets the User's preferred locale, like "fr-CA"
+func WithLocale(locale string) Option {
+	return func(u *User) {
+		u.Locale = locale
+	}
+}
+
+// WithTimeZone sets the User's IANA time zone, like "America/Toronto"
+func WithTimeZone(tz string) Option {
+	return func(u *User) {
+		u.TimeZone = tz
+	}
+}
+
+// Location returns the User's time zone, or UTC if it's unset or unknown
+func (r *User) Location() *time.Location {
+	if r == nil || r.TimeZone == "" {
+		return time.UTC
+	}
+
+	location, err := time.LoadLocation(r.TimeZone)
+	if err != nil {
+		slog.Warn("unknown time zone for user", "user", r.String(), "time_zone", r.TimeZone, "error", err)
+		return time.UTC
+	}
+
+	return location
+}
+
 // String returns a simple string representation of a User's identify (useful for logging)
 func (r *User) String() string {
 	if (r == nil) || (r.Identifiers == nil) {
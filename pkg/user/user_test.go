@@ -6,6 +6,7 @@ package user
 
 import (
 	"testing"
+	"time"
 
 	"github.com/seatgeek/mailroom/pkg/identifier"
 	"github.com/seatgeek/mailroom/pkg/notifier/preference"
@@ -22,6 +23,8 @@ func TestNew(t *testing.T) {
 			identifier.New("email", "rufus@seatgeek.com"),
 		)),
 		WithPreference("com.example.notification", "email", true),
+		WithLocale("fr-CA"),
+		WithTimeZone("America/Toronto"),
 	)
 
 	wantIdentifiers := []identifier.Identifier{
@@ -37,6 +40,48 @@ func TestNew(t *testing.T) {
 
 	assert.ElementsMatch(t, wantIdentifiers, user.Identifiers.ToList())
 	assert.Equal(t, wantPreferences, user.Preferences)
+	assert.Equal(t, "fr-CA", user.Locale)
+	assert.Equal(t, "America/Toronto", user.TimeZone)
+}
+
+func TestUser_Location(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		user *User
+		want string
+	}{
+		{
+			name: "time zone",
+			user: New("rufus", WithTimeZone("America/Toronto")),
+			want: "America/Toronto",
+		},
+		{
+			name: "no time zone",
+			user: New("rufus"),
+			want: "UTC",
+		},
+		{
+			name: "unknown time zone",
+			user: New("rufus", WithTimeZone("Mars/Olympus_Mons")),
+			want: "UTC",
+		},
+		{
+			name: "nil user",
+			want: "UTC",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.want, tc.user.Location().String())
+		})
+	}
+
+	assert.Equal(t, time.UTC, New("rufus").Location())
 }
 
 func TestUser_String(t *testing.T) {
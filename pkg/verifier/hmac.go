@@ -0,0 +1,85 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package verifier
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// HMACVerifier verifies a hex-encoded HMAC-SHA256 signature of the request body found in a header
+type HMACVerifier struct {
+	header string
+	prefix string
+	secret []byte
+}
+
+var _ Verifier = &HMACVerifier{}
+
+// HMACOption configures an HMACVerifier
+type HMACOption func(*HMACVerifier)
+
+// WithPrefix sets a prefix which precedes the hex-encoded signature in the header, such as "sha256="
+func WithPrefix(prefix string) HMACOption {
+	return func(h *HMACVerifier) {
+		h.prefix = prefix
+	}
+}
+
+// NewHMAC creates a new HMACVerifier which reads the signature from the given header
+func NewHMAC(header string, secret []byte, opts ...HMACOption) *HMACVerifier {
+	h := &HMACVerifier{
+		header: header,
+		secret: secret,
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// GitHub creates an HMACVerifier for GitHub-style webhooks, which are signed using the X-Hub-Signature-256 header
+// See https://docs.github.com/en/webhooks/using-webhooks/validating-webhook-deliveries
+func GitHub(secret []byte) *HMACVerifier {
+	return NewHMAC("X-Hub-Signature-256", secret, WithPrefix("sha256="))
+}
+
+func (h *HMACVerifier) Verify(req *http.Request, body []byte) error {
+	header := req.Header.Get(h.header)
+	if header == "" {
+		return ErrMissingSignature
+	}
+
+	signature, ok := strings.CutPrefix(header, h.prefix)
+	if !ok {
+		return ErrInvalidSignature
+	}
+
+	if !validHexSignature(signature, sign(h.secret, body)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func sign(secret []byte, payload []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func validHexSignature(signature string, expected []byte) bool {
+	decoded, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(decoded, expected)
+}
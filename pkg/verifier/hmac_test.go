@@ -0,0 +1,67 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package verifier_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/verifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitHub(t *testing.T) {
+	t.Parallel()
+
+	v := verifier.GitHub([]byte("s3cr3t"))
+
+	tests := []struct {
+		name      string
+		signature string
+		wantErr   error
+	}{
+		{
+			name:      "valid signature",
+			signature: "sha256=" + sign("s3cr3t", someBody),
+		},
+		{
+			name:    "missing signature",
+			wantErr: verifier.ErrMissingSignature,
+		},
+		{
+			name:      "signed with a different secret",
+			signature: "sha256=" + sign("wrong", someBody),
+			wantErr:   verifier.ErrInvalidSignature,
+		},
+		{
+			name:      "missing prefix",
+			signature: sign("s3cr3t", someBody),
+			wantErr:   verifier.ErrInvalidSignature,
+		},
+		{
+			name:      "not hex",
+			signature: "sha256=zzzz",
+			wantErr:   verifier.ErrInvalidSignature,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequestWithContext(t.Context(), "POST", "/", nil)
+			if tc.signature != "" {
+				req.Header.Set("X-Hub-Signature-256", tc.signature)
+			}
+
+			err := v.Verify(req, []byte(someBody))
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
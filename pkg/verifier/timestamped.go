@@ -0,0 +1,132 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package verifier
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultReplayWindow is how far a signature's timestamp may drift from the current time before it is rejected
+const DefaultReplayWindow = 5 * time.Minute
+
+// Scheme describes how a provider transmits a timestamped signature
+type Scheme struct {
+	// Extract returns the raw unix timestamp and the candidate hex-encoded signatures found in the request
+	Extract func(req *http.Request) (timestamp string, signatures []string)
+	// Payload returns the bytes which the provider signed
+	Payload func(timestamp string, body []byte) []byte
+}
+
+// SlackScheme is the Scheme used by Slack's request signing
+// See https://api.slack.com/authentication/verifying-requests-from-slack
+var SlackScheme = Scheme{
+	Extract: func(req *http.Request) (string, []string) {
+		signature, ok := strings.CutPrefix(req.Header.Get("X-Slack-Signature"), "v0=")
+		if !ok {
+			return req.Header.Get("X-Slack-Request-Timestamp"), nil
+		}
+
+		return req.Header.Get("X-Slack-Request-Timestamp"), []string{signature}
+	},
+	Payload: func(timestamp string, body []byte) []byte {
+		return []byte("v0:" + timestamp + ":" + string(body))
+	},
+}
+
+// StripeScheme is the Scheme used by Stripe's webhook signatures
+// See https://docs.stripe.com/webhooks#verify-manually
+var StripeScheme = Scheme{
+	Extract: func(req *http.Request) (string, []string) {
+		var timestamp string
+		var signatures []string
+		for part := range strings.SplitSeq(req.Header.Get("Stripe-Signature"), ",") {
+			key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+			switch key {
+			case "t":
+				timestamp = value
+			case "v1":
+				signatures = append(signatures, value)
+			}
+		}
+
+		return timestamp, signatures
+	},
+	Payload: func(timestamp string, body []byte) []byte {
+		return []byte(timestamp + "." + string(body))
+	},
+}
+
+// TimestampedVerifier verifies HMAC-SHA256 signatures which cover a timestamp,
+// rejecting any whose timestamp falls outside the replay window
+type TimestampedVerifier struct {
+	scheme Scheme
+	secret []byte
+	window time.Duration
+}
+
+var _ Verifier = &TimestampedVerifier{}
+
+// TimestampedOption configures a TimestampedVerifier
+type TimestampedOption func(*TimestampedVerifier)
+
+// WithReplayWindow sets how far a signature's timestamp may drift from the current time (default 5 minutes)
+func WithReplayWindow(window time.Duration) TimestampedOption {
+	return func(t *TimestampedVerifier) {
+		t.window = window
+	}
+}
+
+// NewTimestamped creates a new TimestampedVerifier for the given Scheme
+func NewTimestamped(scheme Scheme, secret []byte, opts ...TimestampedOption) *TimestampedVerifier {
+	t := &TimestampedVerifier{
+		scheme: scheme,
+		secret: secret,
+		window: DefaultReplayWindow,
+	}
+
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t
+}
+
+// Slack creates a TimestampedVerifier for requests signed by Slack
+func Slack(signingSecret []byte, opts ...TimestampedOption) *TimestampedVerifier {
+	return NewTimestamped(SlackScheme, signingSecret, opts...)
+}
+
+// Stripe creates a TimestampedVerifier for webhooks signed by Stripe
+func Stripe(endpointSecret []byte, opts ...TimestampedOption) *TimestampedVerifier {
+	return NewTimestamped(StripeScheme, endpointSecret, opts...)
+}
+
+func (t *TimestampedVerifier) Verify(req *http.Request, body []byte) error {
+	timestamp, signatures := t.scheme.Extract(req)
+	if timestamp == "" || len(signatures) == 0 {
+		return ErrMissingSignature
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	if drift := time.Since(time.Unix(unix, 0)).Abs(); drift > t.window {
+		return ErrStaleTimestamp
+	}
+
+	expected := sign(t.secret, t.scheme.Payload(timestamp, body))
+	for _, signature := range signatures {
+		if validHexSignature(signature, expected) {
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
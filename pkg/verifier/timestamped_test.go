@@ -0,0 +1,144 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package verifier_test
+
+import (
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/seatgeek/mailroom/pkg/verifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlack(t *testing.T) {
+	t.Parallel()
+
+	v := verifier.Slack([]byte("s3cr3t"))
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+
+	tests := []struct {
+		name      string
+		timestamp string
+		signature string
+		wantErr   error
+	}{
+		{
+			name:      "valid signature",
+			timestamp: now,
+			signature: "v0=" + sign("s3cr3t", "v0:"+now+":"+someBody),
+		},
+		{
+			name:      "missing signature",
+			timestamp: now,
+			wantErr:   verifier.ErrMissingSignature,
+		},
+		{
+			name:      "missing timestamp",
+			signature: "v0=" + sign("s3cr3t", "v0:"+now+":"+someBody),
+			wantErr:   verifier.ErrMissingSignature,
+		},
+		{
+			name:      "timestamp doesn't match signature",
+			timestamp: now,
+			signature: "v0=" + sign("s3cr3t", "v0:12345:"+someBody),
+			wantErr:   verifier.ErrInvalidSignature,
+		},
+		{
+			name:      "replayed request",
+			timestamp: stale,
+			signature: "v0=" + sign("s3cr3t", "v0:"+stale+":"+someBody),
+			wantErr:   verifier.ErrStaleTimestamp,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequestWithContext(t.Context(), "POST", "/", nil)
+			if tc.timestamp != "" {
+				req.Header.Set("X-Slack-Request-Timestamp", tc.timestamp)
+			}
+			if tc.signature != "" {
+				req.Header.Set("X-Slack-Signature", tc.signature)
+			}
+
+			err := v.Verify(req, []byte(someBody))
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestStripe(t *testing.T) {
+	t.Parallel()
+
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+
+	tests := []struct {
+		name    string
+		window  time.Duration
+		header  string
+		wantErr error
+	}{
+		{
+			name:   "valid signature",
+			header: "t=" + now + ",v1=" + sign("s3cr3t", now+"."+someBody),
+		},
+		{
+			name:   "one of several signatures is valid",
+			header: "t=" + now + ",v1=" + sign("old", now+"."+someBody) + ",v1=" + sign("s3cr3t", now+"."+someBody) + ",v0=abc",
+		},
+		{
+			name:    "no v1 signatures",
+			header:  "t=" + now + ",v0=" + sign("s3cr3t", now+"."+someBody),
+			wantErr: verifier.ErrMissingSignature,
+		},
+		{
+			name:    "invalid signature",
+			header:  "t=" + now + ",v1=" + sign("wrong", now+"."+someBody),
+			wantErr: verifier.ErrInvalidSignature,
+		},
+		{
+			name:    "replayed request",
+			header:  "t=" + stale + ",v1=" + sign("s3cr3t", stale+"."+someBody),
+			wantErr: verifier.ErrStaleTimestamp,
+		},
+		{
+			name:   "replayed request within a wider window",
+			window: time.Hour,
+			header: "t=" + stale + ",v1=" + sign("s3cr3t", stale+"."+someBody),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var opts []verifier.TimestampedOption
+			if tc.window != 0 {
+				opts = append(opts, verifier.WithReplayWindow(tc.window))
+			}
+			v := verifier.Stripe([]byte("s3cr3t"), opts...)
+
+			req := httptest.NewRequestWithContext(t.Context(), "POST", "/", nil)
+			req.Header.Set("Stripe-Signature", tc.header)
+
+			err := v.Verify(req, []byte(someBody))
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
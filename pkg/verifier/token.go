@@ -0,0 +1,45 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package verifier
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// TokenVerifier verifies that a header contains a shared secret token
+type TokenVerifier struct {
+	header string
+	token  []byte
+}
+
+var _ Verifier = &TokenVerifier{}
+
+// NewToken creates a new TokenVerifier which expects the given header to contain the given token
+func NewToken(header string, token []byte) *TokenVerifier {
+	return &TokenVerifier{
+		header: header,
+		token:  token,
+	}
+}
+
+// GitLab creates a TokenVerifier for GitLab-style webhooks, which send the secret token in the X-Gitlab-Token header
+// See https://docs.gitlab.com/ee/user/project/integrations/webhooks.html#validate-payloads-by-using-a-secret-token
+func GitLab(token []byte) *TokenVerifier {
+	return NewToken("X-Gitlab-Token", token)
+}
+
+func (t *TokenVerifier) Verify(req *http.Request, _ []byte) error {
+	got := req.Header.Get(t.header)
+	if got == "" {
+		return ErrMissingSignature
+	}
+
+	if subtle.ConstantTimeCompare([]byte(got), t.token) != 1 {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
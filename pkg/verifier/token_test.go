@@ -0,0 +1,47 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package verifier_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/verifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitLab(t *testing.T) {
+	t.Parallel()
+
+	v := verifier.GitLab([]byte("s3cr3t"))
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "valid token", token: "s3cr3t"},
+		{name: "missing token", wantErr: verifier.ErrMissingSignature},
+		{name: "wrong token", token: "s3cr3", wantErr: verifier.ErrInvalidSignature},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequestWithContext(t.Context(), "POST", "/", nil)
+			if tc.token != "" {
+				req.Header.Set("X-Gitlab-Token", tc.token)
+			}
+
+			err := v.Verify(req, nil)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+// Package verifier provides reusable authenticity checks for incoming webhooks, such as HMAC signatures and shared tokens
+package verifier
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/server"
+	"github.com/seatgeek/mailroom/pkg/validation"
+)
+
+var (
+	// ErrMissingSignature is returned when the request does not contain the expected signature or token
+	ErrMissingSignature = errors.New("missing signature")
+	// ErrInvalidSignature is returned when the signature or token does not match
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrStaleTimestamp is returned when a timestamped signature falls outside the allowed replay window
+	ErrStaleTimestamp = errors.New("timestamp outside of allowed window")
+)
+
+// Verifier checks that an incoming webhook request is authentic
+type Verifier interface {
+	// Verify returns nil if the request is authentic, or an error describing why it is not.
+	// The body is provided separately since the request body may only be read once.
+	Verify(req *http.Request, body []byte) error
+}
+
+// VerifierFunc is a function that implements the Verifier interface
+type VerifierFunc func(req *http.Request, body []byte) error
+
+func (f VerifierFunc) Verify(req *http.Request, body []byte) error {
+	return f(req, body)
+}
+
+// Any returns a Verifier which accepts the request if any of the given verifiers does.
+// This is useful for rotating secrets without downtime.
+func Any(verifiers ...Verifier) Verifier {
+	return VerifierFunc(func(req *http.Request, body []byte) error {
+		errs := make([]error, 0, len(verifiers))
+		for _, v := range verifiers {
+			err := v.Verify(req, body)
+			if err == nil {
+				return nil
+			}
+
+			errs = append(errs, err)
+		}
+
+		if len(errs) == 0 {
+			return ErrInvalidSignature
+		}
+
+		return errors.Join(errs...)
+	})
+}
+
+// Parser is an event.Parser which verifies each request before handing it to the wrapped parser
+type Parser struct {
+	event.Parser
+	verifier Verifier
+}
+
+var (
+	_ event.BatchParser    = &Parser{}
+	_ validation.Validator = &Parser{}
+)
+
+// WrapParser returns a Parser which verifies each request using v before passing it on to parser.
+// The request body is buffered, so the wrapped parser can still read it.
+// Requests which fail verification are rejected with a 401 Unauthorized.
+func WrapParser(parser event.Parser, v Verifier) *Parser {
+	return &Parser{
+		Parser:   parser,
+		verifier: v,
+	}
+}
+
+func (p *Parser) Parse(req *http.Request) (*event.Event, error) {
+	if err := p.verify(req); err != nil {
+		return nil, err
+	}
+
+	return p.Parser.Parse(req)
+}
+
+// ParseBatch verifies the request and then passes it to the wrapped parser's ParseBatch, if it supports batches
+func (p *Parser) ParseBatch(req *http.Request) ([]*event.Event, error) {
+	if err := p.verify(req); err != nil {
+		return nil, err
+	}
+
+	if bp, ok := p.Parser.(event.BatchParser); ok {
+		return bp.ParseBatch(req)
+	}
+
+	evt, err := p.Parser.Parse(req)
+	if err != nil || evt == nil {
+		return nil, err
+	}
+
+	return []*event.Event{evt}, nil
+}
+
+// verify checks the request, leaving a fresh copy of the body behind for the wrapped parser
+func (p *Parser) verify(req *http.Request) error {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return &server.Error{Code: http.StatusBadRequest, Reason: fmt.Errorf("failed to read request body: %w", err)}
+		}
+	}
+
+	if err := p.verifier.Verify(req, body); err != nil {
+		return &server.Error{Code: http.StatusUnauthorized, Reason: err}
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return nil
+}
+
+// Validate validates the wrapped parser, if it supports validation
+func (p *Parser) Validate(ctx context.Context) error {
+	if v, ok := p.Parser.(validation.Validator); ok {
+		return v.Validate(ctx)
+	}
+
+	return nil
+}
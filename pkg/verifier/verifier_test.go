@@ -0,0 +1,130 @@
+// Copyright 2025 SeatGeek, Inc.
+//
+// Licensed under the terms of the Apache-2.0 license. See LICENSE file in project root for terms.
+
+package verifier_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/server"
+	"github.com/seatgeek/mailroom/pkg/verifier"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const someBody = `{"hello": "world"}`
+
+func TestParser_Parse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("passes verified requests to the wrapped parser with the body intact", func(t *testing.T) {
+		t.Parallel()
+
+		want := &event.Event{Context: event.Context{ID: "a1c11a53-c4be-488f-89b6-f83bf2d48dab"}}
+		inner := event.NewMockParser(t)
+		inner.EXPECT().Parse(mock.Anything).Run(func(req *http.Request) {
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			assert.Equal(t, someBody, string(body))
+		}).Return(want, nil)
+
+		parser := verifier.WrapParser(inner, verifier.GitLab([]byte("s3cr3t")))
+
+		req := httptest.NewRequestWithContext(t.Context(), "POST", "/", strings.NewReader(someBody))
+		req.Header.Set("X-Gitlab-Token", "s3cr3t")
+
+		got, err := parser.Parse(req)
+		assert.NoError(t, err)
+		assert.Same(t, want, got)
+	})
+
+	t.Run("rejects unverified requests", func(t *testing.T) {
+		t.Parallel()
+
+		inner := event.NewMockParser(t)
+		parser := verifier.WrapParser(inner, verifier.GitLab([]byte("s3cr3t")))
+
+		req := httptest.NewRequestWithContext(t.Context(), "POST", "/", strings.NewReader(someBody))
+		req.Header.Set("X-Gitlab-Token", "wrong")
+
+		got, err := parser.Parse(req)
+		assert.Nil(t, got)
+		assert.ErrorIs(t, err, &server.Error{Code: http.StatusUnauthorized, Reason: verifier.ErrInvalidSignature})
+		inner.AssertNotCalled(t, "Parse", mock.Anything)
+	})
+}
+
+func TestParser_ParseBatch(t *testing.T) {
+	t.Parallel()
+
+	want := &event.Event{Context: event.Context{ID: "a1c11a53-c4be-488f-89b6-f83bf2d48dab"}}
+	inner := event.NewMockParser(t)
+	inner.EXPECT().Parse(mock.Anything).Return(want, nil)
+
+	parser := verifier.WrapParser(inner, verifier.GitLab([]byte("s3cr3t")))
+
+	req := httptest.NewRequestWithContext(t.Context(), "POST", "/", strings.NewReader(someBody))
+	req.Header.Set("X-Gitlab-Token", "s3cr3t")
+
+	got, err := parser.ParseBatch(req)
+	assert.NoError(t, err)
+	assert.Equal(t, []*event.Event{want}, got)
+}
+
+func TestParser_EventTypes(t *testing.T) {
+	t.Parallel()
+
+	want := []event.TypeDescriptor{{Key: "com.example.test", Title: "Test"}}
+	inner := event.NewMockParser(t)
+	inner.EXPECT().EventTypes().Return(want)
+
+	assert.Equal(t, want, verifier.WrapParser(inner, verifier.GitLab([]byte("s3cr3t"))).EventTypes())
+}
+
+func TestAny(t *testing.T) {
+	t.Parallel()
+
+	v := verifier.Any(verifier.GitLab([]byte("old")), verifier.GitLab([]byte("new")))
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "first matches", token: "old"},
+		{name: "second matches", token: "new"},
+		{name: "neither matches", token: "nope", wantErr: verifier.ErrInvalidSignature},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequestWithContext(t.Context(), "POST", "/", nil)
+			req.Header.Set("X-Gitlab-Token", tc.token)
+
+			err := v.Verify(req, nil)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func sign(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
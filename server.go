@@ -9,13 +9,22 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/seatgeek/mailroom/pkg/deadletter"
+	"github.com/seatgeek/mailroom/pkg/dedup"
+	"github.com/seatgeek/mailroom/pkg/delivery"
+	"github.com/seatgeek/mailroom/pkg/digest"
 	"github.com/seatgeek/mailroom/pkg/event"
+	"github.com/seatgeek/mailroom/pkg/inbox"
 	"github.com/seatgeek/mailroom/pkg/notifier"
 	"github.com/seatgeek/mailroom/pkg/notifier/preference"
+	"github.com/seatgeek/mailroom/pkg/notifier/slack/actions"
+	"github.com/seatgeek/mailroom/pkg/queue"
 	"github.com/seatgeek/mailroom/pkg/server"
+	"github.com/seatgeek/mailroom/pkg/stream"
 	"github.com/seatgeek/mailroom/pkg/user"
 	"github.com/seatgeek/mailroom/pkg/validation"
 )
@@ -31,6 +40,23 @@ type Server struct {
 	defaultPreferences preference.Provider
 	userStore          user.Store
 	router             *mux.Router
+	queue              queue.Queue
+	queueOpts          []queue.Option
+	pool               *queue.Pool
+	deadLetters        deadletter.Store
+	deduplicator       *dedup.Deduplicator
+	deliveries         delivery.Store
+	inboxKey           event.TransportKey
+	inbox              inbox.Store
+	streamKey          event.TransportKey
+	stream             *stream.Broker
+	streamOpts         []stream.HandlerOption
+	slackSecret        []byte
+	slackActionOpts    []actions.Option
+	validators         []validation.Validator
+	digestStore        digest.Store
+	digestOpts         []digest.Option
+	digester           *digest.Digester
 }
 
 type Opt func(s *Server)
@@ -48,10 +74,46 @@ func New(opts ...Opt) *Server {
 		opt(s)
 	}
 
-	s.notifier = notifier.New(s.transports, preference.Chain{
+	if s.inbox != nil {
+		s.transports = append(s.transports, inbox.NewTransport(s.inboxKey, s.inbox, s.userStore))
+	}
+
+	if s.stream != nil {
+		s.transports = append(s.transports, stream.NewTransport(s.streamKey, s.stream, s.userStore))
+	}
+
+	transports := s.transports
+	if s.deadLetters != nil && s.queue != nil {
+		// Failed deliveries are retried by the queue, so only capture those it has given up on
+		s.queueOpts = append(slices.Clip(s.queueOpts), queue.WithDeadLetters(s.deadLetters))
+	} else if s.deadLetters != nil {
+		transports = make([]notifier.Transport, len(s.transports))
+		for i, t := range s.transports {
+			transports[i] = deadletter.WithDeadLetters(t, s.deadLetters)
+		}
+	}
+
+	var notifierOpts []notifier.Option
+	if s.deliveries != nil {
+		notifierOpts = append(notifierOpts, notifier.WithObservers(delivery.NewRecorder(s.deliveries)))
+	}
+
+	s.notifier = notifier.New(transports, preference.Chain{
 		user.NewPreferenceProvider(s.userStore),
 		s.defaultPreferences,
-	})
+	}, notifierOpts...)
+
+	if s.queue != nil {
+		// Deliver notifications in the background, and have the handlers enqueue them instead
+		s.pool = queue.NewPool(s.queue, s.notifier, s.queueOpts...)
+		s.notifier = queue.NewNotifier(s.queue, transportKeys(s.transports))
+	}
+
+	if s.digestStore != nil {
+		// Hold back notifications for noisy event types before they're delivered (or enqueued)
+		s.digester = digest.New(s.digestStore, s.notifier, transportKeys(s.transports), s.digestOpts...)
+		s.notifier = s.digester
+	}
 
 	return s
 }
@@ -114,6 +176,85 @@ func WithRouter(router *mux.Router) Opt {
 	}
 }
 
+// WithQueue enables asynchronous delivery: notifications are enqueued onto the given queue.Queue
+// and delivered in the background by a pool of workers configured by the given options.
+func WithQueue(q queue.Queue, opts ...queue.Option) Opt {
+	return func(s *Server) {
+		s.queue = q
+		s.queueOpts = opts
+	}
+}
+
+// WithDeadLetterStore captures any notifications which transports fail to deliver in the given deadletter.Store,
+// and exposes routes for listing, inspecting, replaying and purging them.
+func WithDeadLetterStore(store deadletter.Store) Opt {
+	return func(s *Server) {
+		s.deadLetters = store
+	}
+}
+
+// WithDeduplication skips incoming events whose event.ID was already processed, using the given dedup.Store.
+// The dedup window may be configured globally or per parser key with the given options.
+func WithDeduplication(store dedup.Store, opts ...dedup.Option) Opt {
+	return func(s *Server) {
+		s.deduplicator = dedup.New(store, opts...)
+	}
+}
+
+// WithDeliveryLog records the outcome of every delivery attempt in the given delivery.Store,
+// and exposes routes for querying them by event ID or by user.
+func WithDeliveryLog(store delivery.Store) Opt {
+	return func(s *Server) {
+		s.deliveries = store
+	}
+}
+
+// WithInbox adds a transport with the given key which keeps notifications in each user's inbox.Store,
+// and exposes routes for reading them, marking them as read, and archiving them.
+// Recipients must exist in the user.Store to have an inbox.
+func WithInbox(key event.TransportKey, store inbox.Store) Opt {
+	return func(s *Server) {
+		s.inboxKey = key
+		s.inbox = store
+	}
+}
+
+// WithStreaming adds a transport with the given key which publishes notifications to the stream.Broker,
+// and exposes routes for clients to receive them in real time over Server-Sent Events or WebSockets.
+// Recipients must exist in the user.Store to receive them.
+func WithStreaming(key event.TransportKey, broker *stream.Broker, opts ...stream.HandlerOption) Opt {
+	return func(s *Server) {
+		s.streamKey = key
+		s.stream = broker
+		s.streamOpts = opts
+	}
+}
+
+// WithSlackActions handles clicks on buttons in Slack notifications, using your Slack app's signing secret to verify them
+// Buttons created with actions.PreferenceButton are handled automatically; register handlers for your own with actions.WithActionHandler.
+func WithSlackActions(signingSecret []byte, opts ...actions.Option) Opt {
+	return func(s *Server) {
+		s.slackSecret = signingSecret
+		s.slackActionOpts = opts
+	}
+}
+
+// WithDigest combines notifications for the event types given by digest.WithSchedule into one per recipient,
+// keeping them in the given digest.Store until they're due.
+func WithDigest(store digest.Store, opts ...digest.Option) Opt {
+	return func(s *Server) {
+		s.digestStore = store
+		s.digestOpts = opts
+	}
+}
+
+// WithValidators adds anything else which should be validated at startup, like a template.Registry used by your processors
+func WithValidators(validators ...validation.Validator) Opt {
+	return func(s *Server) {
+		s.validators = append(s.validators, validators...)
+	}
+}
+
 func (s *Server) validate(ctx context.Context) error { //nolint:revive // high cognitive complexity okay here
 	for key, parser := range s.parsers {
 		if v, ok := parser.(validation.Validator); ok {
@@ -151,6 +292,42 @@ func (s *Server) validate(ctx context.Context) error { //nolint:revive // high c
 		}
 	}
 
+	if v, ok := s.deadLetters.(validation.Validator); ok {
+		if err := v.Validate(ctx); err != nil {
+			return fmt.Errorf("dead letter store failed to validate: %w", err)
+		}
+	}
+
+	if s.deduplicator != nil {
+		if err := s.deduplicator.Validate(ctx); err != nil {
+			return fmt.Errorf("dedup store failed to validate: %w", err)
+		}
+	}
+
+	if v, ok := s.deliveries.(validation.Validator); ok {
+		if err := v.Validate(ctx); err != nil {
+			return fmt.Errorf("delivery store failed to validate: %w", err)
+		}
+	}
+
+	if v, ok := s.queue.(validation.Validator); ok {
+		if err := v.Validate(ctx); err != nil {
+			return fmt.Errorf("queue failed to validate: %w", err)
+		}
+	}
+
+	if v, ok := s.digestStore.(validation.Validator); ok {
+		if err := v.Validate(ctx); err != nil {
+			return fmt.Errorf("digest store failed to validate: %w", err)
+		}
+	}
+
+	for _, v := range s.validators {
+		if err := v.Validate(ctx); err != nil {
+			return fmt.Errorf("%T failed to validate: %w", v, err)
+		}
+	}
+
 	return nil
 }
 
@@ -161,7 +338,45 @@ func (s *Server) Run(ctx context.Context) error {
 		return fmt.Errorf("server validation failed: %w", err)
 	}
 
-	return s.serveHttp(ctx)
+	// Keep delivering queued notifications and flushing digests until the http server has stopped accepting new ones
+	var stopPool, stopDigester func()
+	if s.pool != nil {
+		stopPool = runInBackground(ctx, s.pool.Run)
+	}
+	if s.digester != nil {
+		stopDigester = runInBackground(ctx, s.digester.Run)
+	}
+
+	err := s.serveHttp(ctx)
+
+	// Digests are flushed first, since they may be delivered via the queue
+	if stopDigester != nil {
+		slog.InfoContext(ctx, "waiting for digests to be flushed")
+		stopDigester()
+	}
+
+	if stopPool != nil {
+		slog.InfoContext(ctx, "waiting for queue workers to finish")
+		stopPool()
+	}
+
+	return err
+}
+
+// runInBackground calls run in a Goroutine, returning a function which stops it and waits for it to return
+// It isn't stopped when the given context is canceled, so it can outlive the http server.
+func runInBackground(ctx context.Context, run func(context.Context)) (stop func()) {
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		run(runCtx)
+	}()
+
+	return func() {
+		cancel()
+		<-exited
+	}
 }
 
 func (s *Server) serveHttp(ctx context.Context) error {
@@ -172,11 +387,16 @@ func (s *Server) serveHttp(ctx context.Context) error {
 		_, _ = writer.Write([]byte("^_^\n"))
 	})
 
+	var handlerOpts []server.HandlerOption
+	if s.deduplicator != nil {
+		handlerOpts = append(handlerOpts, server.WithDeduplicator(s.deduplicator))
+	}
+
 	// Mount all parsers
 	for key, parser := range s.parsers {
 		endpoint := "/event/" + key
 		slog.DebugContext(ctx, "mounting parser", "endpoint", endpoint)
-		hsm.HandleFunc(endpoint, server.CreateEventProcessingHandler(key, parser, s.processors, s.notifier))
+		hsm.HandleFunc(endpoint, server.CreateEventProcessingHandler(key, parser, s.processors, s.notifier, handlerOpts...))
 	}
 
 	// Expose routes for managing user preferences
@@ -185,12 +405,62 @@ func (s *Server) serveHttp(ctx context.Context) error {
 	hsm.HandleFunc("/users/{key}/preferences", prefs.UpdatePreferences).Methods("PUT")
 	hsm.HandleFunc("/configuration", prefs.ListOptions).Methods("GET")
 
+	// Expose routes for managing undelivered notifications
+	if s.deadLetters != nil {
+		dl := deadletter.NewHandler(s.deadLetters, s.transports)
+		hsm.HandleFunc("/dead-letters", dl.List).Methods("GET")
+		hsm.HandleFunc("/dead-letters", dl.Purge).Methods("DELETE")
+		hsm.HandleFunc("/dead-letters/{id}", dl.Get).Methods("GET")
+		hsm.HandleFunc("/dead-letters/{id}", dl.Delete).Methods("DELETE")
+		hsm.HandleFunc("/dead-letters/{id}/replay", dl.Replay).Methods("POST")
+	}
+
+	// Expose routes for finding out what happened to notifications
+	if s.deliveries != nil {
+		dh := delivery.NewHandler(s.deliveries, s.userStore)
+		hsm.HandleFunc("/events/{id}/deliveries", dh.ListByEvent).Methods("GET")
+		hsm.HandleFunc("/users/{key}/deliveries", dh.ListByUser).Methods("GET")
+	}
+
+	// Expose routes for in-app notifications
+	if s.inbox != nil {
+		ih := inbox.NewHandler(s.inbox)
+		hsm.HandleFunc("/users/{key}/inbox", ih.List).Methods("GET")
+		hsm.HandleFunc("/users/{key}/inbox/unread-count", ih.UnreadCount).Methods("GET")
+		hsm.HandleFunc("/users/{key}/inbox/read", ih.MarkAllRead).Methods("POST")
+		hsm.HandleFunc("/users/{key}/inbox/{id}/read", ih.MarkRead).Methods("POST")
+		hsm.HandleFunc("/users/{key}/inbox/{id}/archive", ih.Archive).Methods("POST")
+	}
+
+	// Expose routes for real-time notifications
+	if s.stream != nil {
+		sh := stream.NewHandler(s.stream, s.streamOpts...)
+		hsm.HandleFunc("/users/{key}/stream", sh.ServeSSE).Methods("GET")
+		hsm.HandleFunc("/users/{key}/stream/ws", sh.ServeWebSocket).Methods("GET")
+	}
+
+	// Expose a route for Slack's interactivity requests
+	var slackActions *actions.Handler
+	if s.slackSecret != nil {
+		// The built-in handlers come first so they can be replaced
+		opts := append([]actions.Option{
+			actions.WithActionHandler(actions.SetPreferenceActionID, actions.NewPreferenceHandler(s.userStore)),
+		}, s.slackActionOpts...)
+		slackActions = actions.NewHandler(s.slackSecret, opts...)
+		hsm.Handle("/slack/actions", slackActions).Methods("POST")
+	}
+
 	hs := &http.Server{
 		Addr:              s.listenAddr,
 		Handler:           hsm,
 		ReadHeaderTimeout: 2 * time.Second,
 	}
 
+	if s.stream != nil {
+		// Streams never finish on their own, so end them to let the server shut down gracefully
+		hs.RegisterOnShutdown(s.stream.Close)
+	}
+
 	// Run the server in a Goroutine
 	httpExited := make(chan error)
 	go (func() {
@@ -212,6 +482,11 @@ func (s *Server) serveHttp(ctx context.Context) error {
 			return fmt.Errorf("failed to gracefully shutdown http server: %w", err)
 		}
 
+		// Slack actions are acknowledged before they're handled, so let them finish too
+		if slackActions != nil {
+			slackActions.Wait()
+		}
+
 		return nil
 	// Or wait for the server to exit on its own (with some error)
 	case err := <-httpExited:
@@ -11,9 +11,17 @@ import (
 	"testing"
 	"time"
 
+	"github.com/seatgeek/mailroom/pkg/deadletter"
+	"github.com/seatgeek/mailroom/pkg/delivery"
+	"github.com/seatgeek/mailroom/pkg/digest"
 	"github.com/seatgeek/mailroom/pkg/event"
 	"github.com/seatgeek/mailroom/pkg/identifier"
+	"github.com/seatgeek/mailroom/pkg/inbox"
+	"github.com/seatgeek/mailroom/pkg/notification"
+	"github.com/seatgeek/mailroom/pkg/notifier"
 	"github.com/seatgeek/mailroom/pkg/notifier/preference"
+	"github.com/seatgeek/mailroom/pkg/queue"
+	"github.com/seatgeek/mailroom/pkg/stream"
 	"github.com/seatgeek/mailroom/pkg/user"
 	"github.com/seatgeek/mailroom/pkg/validation"
 	"github.com/stretchr/testify/assert"
@@ -41,6 +49,186 @@ func TestServer_Options(t *testing.T) {
 	assert.Contains(t, s.processors, processor)
 }
 
+func TestServer_WithQueue(t *testing.T) {
+	t.Parallel()
+
+	q := queue.NewInMemoryQueue(time.Minute)
+	delivered := make(chan event.Notification, 1)
+
+	s := New(
+		WithListenAddr(":0"),
+		WithQueue(q, queue.WithPollInterval(10*time.Millisecond)),
+		WithTransports(notifier.NewTransport("test", func(_ context.Context, n event.Notification) error {
+			delivered <- n
+			return nil
+		})),
+	)
+
+	// Notifications pushed by the handlers should be enqueued rather than delivered immediately
+	n := notification.NewBuilder(event.Context{ID: "a1c11a53-c4be-488f-89b6-f83bf2d48dab", Type: "com.example.test"}).
+		WithDefaultMessage("hello").
+		Build()
+	assert.NoError(t, s.notifier.Push(t.Context(), n))
+	assert.Equal(t, 1, q.Len())
+
+	// And then delivered in the background once the server is running
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	go func() {
+		_ = s.Run(ctx)
+	}()
+
+	select {
+	case got := <-delivered:
+		assert.Equal(t, n.Context().ID, got.Context().ID)
+		assert.Equal(t, "hello", got.Render("test"))
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for queued notification to be delivered")
+	}
+}
+
+func TestServer_WithDigest(t *testing.T) {
+	t.Parallel()
+
+	store := digest.NewInMemoryStore()
+	var delivered []event.Notification
+
+	s := New(
+		WithListenAddr(":0"),
+		WithDigest(store, digest.WithSchedule("com.example.test", digest.Window(time.Hour)), digest.WithFlushAllOnShutdown()),
+		WithTransports(notifier.NewTransport("test", func(_ context.Context, n event.Notification) error {
+			delivered = append(delivered, n)
+			return nil
+		})),
+	)
+
+	// Notifications pushed by the handlers should be held back
+	for _, id := range []event.ID{"a1c11a53-c4be-488f-89b6-f83bf2d48dab", "b5d1b5f2-6f0e-4a53-8c1a-8d2d1c1f5e2a"} {
+		n := notification.NewBuilder(event.Context{ID: id, Type: "com.example.test"}).
+			WithRecipientIdentifiers(identifier.New(identifier.GenericUsername, "codell")).
+			WithDefaultMessage("hello").
+			Build()
+		assert.NoError(t, s.notifier.Push(t.Context(), n))
+	}
+	assert.Equal(t, 2, store.Len())
+	assert.Empty(t, delivered)
+
+	// And then combined when the server shuts down
+	ctx, cancel := context.WithCancel(t.Context())
+	exited := make(chan error)
+	go func() {
+		exited <- s.Run(ctx)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-exited:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the server to shut down")
+	}
+
+	assert.Equal(t, 0, store.Len())
+	if assert.Len(t, delivered, 1) {
+		assert.Equal(t, "You have 2 new notifications:\n\n• hello\n• hello", delivered[0].Render("test"))
+	}
+}
+
+func TestServer_WithDeadLetterStore(t *testing.T) {
+	t.Parallel()
+
+	store := deadletter.NewInMemoryStore()
+	errDeliveryFailed := errors.New("delivery failed")
+
+	s := New(
+		WithDeadLetterStore(store),
+		WithTransports(notifier.NewTransport("test", func(_ context.Context, _ event.Notification) error {
+			return errDeliveryFailed
+		})),
+	)
+
+	n := notification.NewBuilder(event.Context{ID: "a1c11a53-c4be-488f-89b6-f83bf2d48dab", Type: "com.example.test"}).Build()
+	assert.ErrorIs(t, s.notifier.Push(t.Context(), n), errDeliveryFailed)
+
+	page, err := store.List(t.Context(), deadletter.ListOptions{})
+	assert.NoError(t, err)
+	if assert.Len(t, page.DeadLetters, 1) {
+		assert.Equal(t, event.TransportKey("test"), page.DeadLetters[0].Transport)
+		assert.Equal(t, n.Context().ID, page.DeadLetters[0].Notification().Context().ID)
+	}
+}
+
+func TestServer_WithDeliveryLog(t *testing.T) {
+	t.Parallel()
+
+	store := delivery.NewInMemoryStore()
+
+	s := New(
+		WithDeliveryLog(store),
+		WithTransports(notifier.NewTransport("test", func(_ context.Context, _ event.Notification) error {
+			return nil
+		})),
+	)
+
+	n := notification.NewBuilder(event.Context{ID: "a1c11a53-c4be-488f-89b6-f83bf2d48dab", Type: "com.example.test"}).Build()
+	assert.NoError(t, s.notifier.Push(t.Context(), n))
+
+	page, err := store.ListByEvent(t.Context(), n.Context().ID, delivery.ListOptions{})
+	assert.NoError(t, err)
+	if assert.Len(t, page.Deliveries, 1) {
+		assert.Equal(t, event.TransportKey("test"), page.Deliveries[0].Transport)
+		assert.Equal(t, delivery.OutcomeDelivered, page.Deliveries[0].Outcome)
+	}
+}
+
+func TestServer_WithInbox(t *testing.T) {
+	t.Parallel()
+
+	store := inbox.NewInMemoryStore()
+
+	s := New(
+		WithUserStore(user.NewInMemoryStore(user.New("codell", user.WithIdentifier(identifier.New(identifier.GenericUsername, "codell"))))),
+		WithInbox("inbox", store),
+	)
+
+	assert.Equal(t, []event.TransportKey{"inbox"}, transportKeys(s.transports))
+
+	n := notification.NewBuilder(event.Context{ID: "a1c11a53-c4be-488f-89b6-f83bf2d48dab", Type: "com.example.test"}).
+		WithRecipientIdentifiers(identifier.New(identifier.GenericUsername, "codell")).
+		WithDefaultMessage("hello world").
+		Build()
+	assert.NoError(t, s.notifier.Push(t.Context(), n))
+
+	count, err := store.UnreadCount(t.Context(), "codell")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestServer_WithStreaming(t *testing.T) {
+	t.Parallel()
+
+	broker := stream.NewBroker()
+
+	s := New(
+		WithUserStore(user.NewInMemoryStore(user.New("codell", user.WithIdentifier(identifier.New(identifier.GenericUsername, "codell"))))),
+		WithStreaming("stream", broker),
+	)
+
+	assert.Equal(t, []event.TransportKey{"stream"}, transportKeys(s.transports))
+
+	sub, _ := broker.Subscribe("codell", "")
+
+	n := notification.NewBuilder(event.Context{ID: "a1c11a53-c4be-488f-89b6-f83bf2d48dab", Type: "com.example.test"}).
+		WithRecipientIdentifiers(identifier.New(identifier.GenericUsername, "codell")).
+		WithDefaultMessage("hello world").
+		Build()
+	assert.NoError(t, s.notifier.Push(t.Context(), n))
+
+	evt := <-sub.Events()
+	assert.Equal(t, "hello world", evt.Message)
+}
+
 func TestRun(t *testing.T) {
 	t.Parallel()
 
@@ -94,6 +282,16 @@ func TestRun(t *testing.T) {
 			},
 			wantErr: errValidationFailed,
 		},
+		{
+			name: "returns error if an extra validator fails",
+			opts: []Opt{
+				WithListenAddr(":0"),
+				WithValidators(processorThatFailsToValidate{
+					err: errValidationFailed,
+				}),
+			},
+			wantErr: errValidationFailed,
+		},
 	}
 
 	for _, tt := range tests {
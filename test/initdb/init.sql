@@ -3,6 +3,8 @@ create table public.users (
   preferences jsonb,
   identifiers jsonb,
   emails jsonb,
+  locale varchar(35) not null default '',
+  time_zone varchar(64) not null default '',
   created_at timestamp default current_timestamp not null,
   updated_at timestamp default current_timestamp not null,
   deleted_at timestamp null
@@ -10,3 +12,89 @@ create table public.users (
 
 create index idx_users_identifiers on public.users using gin (identifiers);
 create index idx_users_emails on public.users using gin (emails);
+
+create table public.queued_notifications (
+  id varchar(255) primary key,
+  envelope jsonb not null,
+  attempts integer default 0 not null,
+  enqueued_at timestamptz not null,
+  leased_until timestamptz not null
+);
+
+create index idx_queued_notifications_leased_until on public.queued_notifications (leased_until);
+
+create table public.dead_letters (
+  id varchar(255) primary key,
+  envelope jsonb not null,
+  transport varchar(255) not null,
+  errors jsonb,
+  attempts integer default 0 not null,
+  failed_at timestamptz not null
+);
+
+create index idx_dead_letters_failed_at on public.dead_letters (failed_at);
+
+create table public.seen_events (
+  key varchar(512) primary key,
+  expires_at timestamptz not null
+);
+
+create index idx_seen_events_expires_at on public.seen_events (expires_at);
+
+create table public.delivery_records (
+  id varchar(255) primary key,
+  event_id varchar(255) not null,
+  notification jsonb not null,
+  recipient jsonb,
+  transport varchar(255) not null,
+  preference varchar(32) not null,
+  outcome varchar(32) not null,
+  error text,
+  started_at timestamptz not null,
+  finished_at timestamptz not null
+);
+
+create index idx_delivery_records_event_id on public.delivery_records (event_id);
+create index idx_delivery_records_recipient on public.delivery_records using gin (recipient);
+
+create table public.inbox_items (
+  id varchar(255) primary key,
+  user_key varchar(255) not null,
+  type varchar(255) not null,
+  context jsonb not null,
+  message text not null,
+  created_at timestamptz not null,
+  read_at timestamptz null,
+  archived_at timestamptz null
+);
+
+create index idx_inbox_items_user_key_created_at on public.inbox_items (user_key, created_at desc, id desc);
+
+create table public.slack_messages (
+  key varchar(512) primary key,
+  channel varchar(255) not null,
+  timestamp varchar(64) not null,
+  updated_at timestamptz not null
+);
+
+create index idx_slack_messages_updated_at on public.slack_messages (updated_at);
+
+create table public.digest_items (
+  id varchar(255) primary key,
+  group_key text not null,
+  envelope jsonb not null,
+  added_at timestamptz not null,
+  flush_at timestamptz not null
+);
+
+create index idx_digest_items_group_key on public.digest_items (group_key);
+create index idx_digest_items_flush_at on public.digest_items (flush_at);
+
+create table public.rate_limits (
+  key varchar(1024) primary key,
+  tokens double precision not null,
+  updated_at timestamptz not null,
+  suppressed integer default 0 not null
+);
+
+create index idx_rate_limits_updated_at on public.rate_limits (updated_at);